@@ -20,9 +20,17 @@ func main() {
 	}
 
 	// Build dependencies
-	container := httpPresentation.NewContainer(cfg)
+	container, err := httpPresentation.NewContainer(cfg)
+	if err != nil {
+		log.Fatal("Failed to build dependencies:", err)
+	}
 	server := container.BuildServer()
 
+	// Start background workers, stopped before the server shuts down
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	container.StartWorkers(workerCtx)
+
 	// Start server in a goroutine
 	go func() {
 		log.Printf("Server starting on port %s", cfg.Server.Port)
@@ -36,6 +44,7 @@ func main() {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 	log.Println("Server shutting down...")
+	stopWorkers()
 
 	// Graceful shutdown with timeout
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"log/slog"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/config"
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/persistence/mysql/migrate"
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/persistence/mysql/migrations"
+)
+
+const usage = `Usage: migrate <command> [arg]
+
+Commands:
+  up [n]            apply all or the next n pending migrations
+  down [n]          revert the last n applied migrations (default 1)
+  status            list migrations and whether they are applied
+  force <version>   mark the schema as exactly at version without running scripts`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Println(usage)
+		os.Exit(2)
+	}
+
+	// Load configuration
+	dbConfig, err := config.LoadDatabase()
+	if err != nil {
+		log.Fatal("Failed to load config:", err)
+	}
+
+	db, err := migrate.Open(dbConfig.DSN)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer db.Close()
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	migrator, err := migrate.NewMigrator(db, migrations.FS, logger)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	if err := run(ctx, migrator, os.Args[1], os.Args[2:]); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(ctx context.Context, migrator *migrate.Migrator, command string, args []string) error {
+	switch command {
+	case "up":
+		steps, err := intArg(args, 0)
+		if err != nil {
+			return err
+		}
+
+		count, err := migrator.Up(ctx, steps)
+		fmt.Printf("Applied %d migration(s)\n", count)
+		return err
+
+	case "down":
+		steps, err := intArg(args, 1)
+		if err != nil {
+			return err
+		}
+
+		count, err := migrator.Down(ctx, steps)
+		fmt.Printf("Reverted %d migration(s)\n", count)
+		return err
+
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, status := range statuses {
+			state := "pending"
+			if status.Applied {
+				state = "applied " + status.AppliedAt.Format(time.RFC3339)
+			}
+			if status.Dirty {
+				state += " (dirty)"
+			}
+			if status.Modified {
+				state += " (modified)"
+			}
+			fmt.Printf("%03d  %-30s %s\n", status.Version, status.Name, state)
+		}
+		return nil
+
+	case "force":
+		if len(args) != 1 {
+			return fmt.Errorf("force needs a version\n\n%s", usage)
+		}
+
+		version, err := strconv.Atoi(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid version %q", args[0])
+		}
+		return migrator.Force(ctx, version)
+
+	default:
+		return fmt.Errorf("unknown command %q\n\n%s", command, usage)
+	}
+}
+
+func intArg(args []string, defaultValue int) (int, error) {
+	if len(args) == 0 {
+		return defaultValue, nil
+	}
+
+	value, err := strconv.Atoi(args[0])
+	if err != nil || value < 0 {
+		return 0, fmt.Errorf("invalid step count %q", args[0])
+	}
+
+	return value, nil
+}
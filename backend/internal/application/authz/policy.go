@@ -0,0 +1,57 @@
+package authz
+
+import (
+	"errors"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/application/interfaces"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/user"
+)
+
+var (
+	// ErrUnauthenticated is returned when no authenticated actor is present
+	ErrUnauthenticated = errors.New("authentication required")
+
+	// ErrForbidden is returned when the actor lacks a required permission
+	ErrForbidden = errors.New("insufficient permissions")
+)
+
+// Require checks that the token claims grant every given permission.
+// Handlers use it with the claims taken from the request context.
+func Require(claims *interfaces.TokenClaims, permissions ...user.Permission) error {
+	if claims == nil {
+		return ErrUnauthenticated
+	}
+
+	for _, permission := range permissions {
+		if !claims.HasPermission(permission.String()) {
+			return ErrForbidden
+		}
+	}
+
+	return nil
+}
+
+// RequireUser checks that the user's role grants every given permission.
+// Use cases use it once the acting user has been loaded from the repository.
+func RequireUser(actor *user.User, permissions ...user.Permission) error {
+	if actor == nil {
+		return ErrUnauthenticated
+	}
+
+	for _, permission := range permissions {
+		if !actor.HasPermission(permission) {
+			return ErrForbidden
+		}
+	}
+
+	return nil
+}
+
+// PermissionStrings converts domain permissions to their token representation
+func PermissionStrings(permissions []user.Permission) []string {
+	result := make([]string, 0, len(permissions))
+	for _, p := range permissions {
+		result = append(result, p.String())
+	}
+	return result
+}
@@ -0,0 +1,34 @@
+package feature
+
+import "github.com/StefanPenchev05/Amora/backend/internal/domain/feature"
+
+// SaveFlagRequest represents the full definition of a stored flag
+type SaveFlagRequest struct {
+	ActorID string `json:"-"`
+	Key     string `json:"-"`
+
+	// ExpectedVersion is the version the administrator edited, nil replaces any version
+	ExpectedVersion *int64 `json:"-"`
+
+	Description         string   `json:"description,omitempty"`
+	Enabled             bool     `json:"enabled"`
+	Environments        []string `json:"environments,omitempty"`
+	UserIDs             []string `json:"user_ids,omitempty"`
+	RelationshipIDs     []string `json:"relationship_ids,omitempty"`
+	RolloutPercentage   int      `json:"rollout_percentage"`
+	RequiredEntitlement string   `json:"required_entitlement,omitempty"`
+}
+
+// ToFlag converts the request to a domain flag
+func (r SaveFlagRequest) ToFlag() feature.Flag {
+	return feature.Flag{
+		Key:                 r.Key,
+		Description:         r.Description,
+		Enabled:             r.Enabled,
+		Environments:        r.Environments,
+		UserIDs:             r.UserIDs,
+		RelationshipIDs:     r.RelationshipIDs,
+		RolloutPercentage:   r.RolloutPercentage,
+		RequiredEntitlement: feature.Entitlement(r.RequiredEntitlement),
+	}
+}
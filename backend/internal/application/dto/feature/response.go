@@ -0,0 +1,52 @@
+package feature
+
+import "github.com/StefanPenchev05/Amora/backend/internal/domain/feature"
+
+// FlagResponse represents a flag as it is evaluated
+type FlagResponse struct {
+	Key                 string   `json:"key"`
+	Description         string   `json:"description,omitempty"`
+	Enabled             bool     `json:"enabled"`
+	Environments        []string `json:"environments"`
+	UserIDs             []string `json:"user_ids"`
+	RelationshipIDs     []string `json:"relationship_ids"`
+	RolloutPercentage   int      `json:"rollout_percentage"`
+	RequiredEntitlement string   `json:"required_entitlement,omitempty"`
+	Version             int64    `json:"version"`
+}
+
+// FlagsResponse lists every flag from the defaults, the flags file and the database
+type FlagsResponse struct {
+	Flags []FlagResponse `json:"flags"`
+}
+
+// Helper function to convert a domain flag to response
+func NewFlagResponse(flag feature.Flag) FlagResponse {
+	return FlagResponse{
+		Key:                 flag.Key,
+		Description:         flag.Description,
+		Enabled:             flag.Enabled,
+		Environments:        nonNil(flag.Environments),
+		UserIDs:             nonNil(flag.UserIDs),
+		RelationshipIDs:     nonNil(flag.RelationshipIDs),
+		RolloutPercentage:   flag.RolloutPercentage,
+		RequiredEntitlement: string(flag.RequiredEntitlement),
+		Version:             flag.Version,
+	}
+}
+
+func NewFlagsResponse(flags []feature.Flag) FlagsResponse {
+	response := FlagsResponse{Flags: make([]FlagResponse, 0, len(flags))}
+	for _, flag := range flags {
+		response.Flags = append(response.Flags, NewFlagResponse(flag))
+	}
+	return response
+}
+
+// nonNil lists empty targeting as [] rather than null
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,75 @@
+package relationship
+
+import "github.com/StefanPenchev05/Amora/backend/internal/domain/relationship"
+
+// SendInviteRequest represents an invite to the user with the username or email
+type SendInviteRequest struct {
+	InviterID string `json:"-"`
+	Username  string `json:"username,omitempty"`
+	Email     string `json:"email,omitempty" validate:"omitempty,email"`
+	Message   string `json:"message,omitempty" validate:"max=255"`
+}
+
+// InviteActionRequest represents accepting, declining or cancelling an invite
+type InviteActionRequest struct {
+	UserID   string
+	InviteID string
+}
+
+// CreateInviteCodeRequest represents an invite shared as a code
+type CreateInviteCodeRequest struct {
+	InviterID string `json:"-"`
+	Message   string `json:"message,omitempty" validate:"max=255"`
+}
+
+// RedeemInviteCodeRequest represents an existing user redeeming a code
+type RedeemInviteCodeRequest struct {
+	UserID string `json:"-"`
+	Code   string `json:"code" validate:"required"`
+}
+
+// InviteCodeQRRequest represents rendering the QR code of an invite code. The
+// code travels in the body, URLs end up in access logs.
+type InviteCodeQRRequest struct {
+	UserID string `json:"-"`
+	Code   string `json:"code" validate:"required"`
+	Format string `json:"format" validate:"required"`
+}
+
+// RelationshipActionRequest represents a partner reading or changing the status of a relationship
+type RelationshipActionRequest struct {
+	UserID         string
+	RelationshipID string
+}
+
+// UpdateProfileRequest represents a partial update of the profile of the current
+// relationship, omitted fields are left unchanged and empty strings clear them
+type UpdateProfileRequest struct {
+	UserID string `json:"-"`
+
+	// ExpectedVersion is the version the client edited, nil applies the patch to any version
+	ExpectedVersion *int64 `json:"-"`
+
+	Title         *string `json:"title,omitempty"`
+	Slug          *string `json:"slug,omitempty"`
+	AnniversaryAt *string `json:"anniversary_at,omitempty"`
+	AvatarPhotoID *string `json:"avatar_photo_id,omitempty"`
+	BannerPhotoID *string `json:"banner_photo_id,omitempty"`
+}
+
+// ToPatch converts the request into a domain patch
+func (r UpdateProfileRequest) ToPatch() relationship.ProfilePatch {
+	return relationship.ProfilePatch{
+		Title:         r.Title,
+		Slug:          r.Slug,
+		AnniversaryAt: r.AnniversaryAt,
+		AvatarPhotoID: r.AvatarPhotoID,
+		BannerPhotoID: r.BannerPhotoID,
+	}
+}
+
+// RelationshipBySlugRequest represents opening a relationship by its slug
+type RelationshipBySlugRequest struct {
+	UserID string
+	Slug   string
+}
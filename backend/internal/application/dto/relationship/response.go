@@ -0,0 +1,153 @@
+package relationship
+
+import (
+	"time"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/relationship"
+)
+
+const timeFormat = "2006-01-02T15:04:05Z"
+
+// InviteResponse represents an invite from the point of view of one of its users
+type InviteResponse struct {
+	ID          string  `json:"id"`
+	InviterID   string  `json:"inviter_id"`
+	InviteeID   string  `json:"invitee_id,omitempty"`
+	ViaCode     bool    `json:"via_code,omitempty"`
+	Status      string  `json:"status"`
+	Message     string  `json:"message,omitempty"`
+	CreatedAt   string  `json:"created_at"`
+	ExpiresAt   string  `json:"expires_at"`
+	RespondedAt *string `json:"responded_at,omitempty"`
+}
+
+// NewInviteResponse converts a domain invite to its response
+func NewInviteResponse(invite *relationship.Invite) InviteResponse {
+	response := InviteResponse{
+		ID:        invite.ID,
+		InviterID: invite.InviterID,
+		InviteeID: invite.InviteeID,
+		ViaCode:   invite.IsCodeInvite(),
+		Status:    string(invite.CurrentStatus(time.Now())),
+		Message:   invite.Message,
+		CreatedAt: invite.CreatedAt.UTC().Format(timeFormat),
+		ExpiresAt: invite.ExpiresAt.UTC().Format(timeFormat),
+	}
+	if invite.RespondedAt != nil {
+		respondedAt := invite.RespondedAt.UTC().Format(timeFormat)
+		response.RespondedAt = &respondedAt
+	}
+
+	return response
+}
+
+// SendInviteResponse represents the output of sending an invite. Invites by email
+// answer neutrally without the invite, so they cannot be used to discover accounts.
+type SendInviteResponse struct {
+	Status  string          `json:"status"`
+	Message string          `json:"message,omitempty"`
+	Invite  *InviteResponse `json:"invite,omitempty"`
+}
+
+// NewInviteSentResponse returns the invite sent to a username
+func NewInviteSentResponse(invite *relationship.Invite) *SendInviteResponse {
+	response := NewInviteResponse(invite)
+	return &SendInviteResponse{
+		Status: "sent",
+		Invite: &response,
+	}
+}
+
+// NewInviteAcceptedResponse returns the neutral answer to an invite by email
+func NewInviteAcceptedResponse() *SendInviteResponse {
+	return &SendInviteResponse{
+		Status:  "accepted",
+		Message: "If the email belongs to an account, the invite is on its way",
+	}
+}
+
+// InviteCodeResponse represents a new invite code. The code is only shown
+// once, it cannot be recovered from what is stored.
+type InviteCodeResponse struct {
+	Invite InviteResponse `json:"invite"`
+	Code   string         `json:"code"`
+	URL    string         `json:"url"`
+}
+
+// PendingInvitesResponse splits the pending invites of a user by direction
+type PendingInvitesResponse struct {
+	Sent     []InviteResponse `json:"sent"`
+	Received []InviteResponse `json:"received"`
+}
+
+// RelationshipResponse represents a relationship
+type RelationshipResponse struct {
+	ID            string           `json:"id"`
+	PartnerIDs    []string         `json:"partner_ids"`
+	Status        string           `json:"status"`
+	Visibility    string           `json:"visibility"`
+	Title         string           `json:"title,omitempty"`
+	Slug          string           `json:"slug,omitempty"`
+	AnniversaryAt string           `json:"anniversary_at,omitempty"`
+	AvatarPhotoID string           `json:"avatar_photo_id,omitempty"`
+	BannerPhotoID string           `json:"banner_photo_id,omitempty"`
+	ReadOnly      bool             `json:"read_only"`
+	StartedAt     string           `json:"started_at"`
+	EndedAt       *string          `json:"ended_at,omitempty"`
+	ReadableUntil *string          `json:"readable_until,omitempty"`
+	Pending       *RequestResponse `json:"pending_request,omitempty"`
+	Version       int64            `json:"version"`
+}
+
+// RequestResponse represents an end or reconnect waiting for confirmation
+type RequestResponse struct {
+	Kind          string  `json:"kind"`
+	RequestedBy   string  `json:"requested_by"`
+	RequestedAt   string  `json:"requested_at"`
+	ConfirmableAt *string `json:"confirmable_at,omitempty"`
+}
+
+// NewRelationshipResponse converts a domain relationship to its response
+func NewRelationshipResponse(rel *relationship.Relationship) RelationshipResponse {
+	response := RelationshipResponse{
+		ID:            rel.ID,
+		PartnerIDs:    []string{rel.PartnerAID, rel.PartnerBID},
+		Status:        string(rel.Status),
+		Visibility:    string(rel.Visibility),
+		Title:         rel.Title,
+		Slug:          rel.Slug,
+		AvatarPhotoID: rel.AvatarPhotoID,
+		BannerPhotoID: rel.BannerPhotoID,
+		ReadOnly:      rel.Status != relationship.StatusActive,
+		StartedAt:     rel.StartedAt.UTC().Format(timeFormat),
+		EndedAt:       formatOptional(rel.EndedAt),
+		ReadableUntil: formatOptional(rel.ReadableUntil()),
+		Version:       rel.Version,
+	}
+	if rel.AnniversaryAt != nil {
+		response.AnniversaryAt = rel.AnniversaryAt.Format(relationship.AnniversaryLayout)
+	}
+	if rel.Pending != nil {
+		response.Pending = &RequestResponse{
+			Kind:          string(rel.Pending.Kind),
+			RequestedBy:   rel.Pending.RequestedBy,
+			RequestedAt:   rel.Pending.RequestedAt.UTC().Format(timeFormat),
+			ConfirmableAt: formatOptional(rel.Pending.ConfirmableAt()),
+		}
+	}
+
+	return response
+}
+
+// RelationshipsResponse lists the relationships a user can still read
+type RelationshipsResponse struct {
+	Relationships []RelationshipResponse `json:"relationships"`
+}
+
+func formatOptional(value *time.Time) *string {
+	if value == nil {
+		return nil
+	}
+	formatted := value.UTC().Format(timeFormat)
+	return &formatted
+}
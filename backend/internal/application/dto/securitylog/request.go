@@ -0,0 +1,8 @@
+package securitylog
+
+// ListSecurityLogRequest represents a page of the user's security log
+type ListSecurityLogRequest struct {
+	UserID string
+	Before string `json:"before,omitempty"`
+	Limit  int    `json:"limit,omitempty"`
+}
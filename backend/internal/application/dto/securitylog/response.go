@@ -0,0 +1,50 @@
+package securitylog
+
+import (
+	domainSecurityLog "github.com/StefanPenchev05/Amora/backend/internal/domain/securitylog"
+)
+
+// ListSecurityLogResponse represents a page of security log entries, newest first
+type ListSecurityLogResponse struct {
+	Entries    []EntryResponse `json:"entries"`
+	NextBefore *string         `json:"next_before"`
+}
+
+type EntryResponse struct {
+	EventType  string            `json:"event_type"`
+	IPAddress  string            `json:"ip_address,omitempty"`
+	UserAgent  string            `json:"user_agent,omitempty"`
+	Country    string            `json:"country,omitempty"`
+	City       string            `json:"city,omitempty"`
+	RequestID  string            `json:"request_id,omitempty"`
+	Metadata   map[string]string `json:"metadata,omitempty"`
+	OccurredAt string            `json:"occurred_at"`
+}
+
+// Helper function to convert domain entries to a page
+func NewListSecurityLogResponse(entries []*domainSecurityLog.Entry, limit int) *ListSecurityLogResponse {
+	response := &ListSecurityLogResponse{
+		Entries: make([]EntryResponse, 0, len(entries)),
+	}
+
+	for _, entry := range entries {
+		response.Entries = append(response.Entries, EntryResponse{
+			EventType:  string(entry.EventType),
+			IPAddress:  entry.IPAddress,
+			UserAgent:  entry.UserAgent,
+			Country:    entry.Country,
+			City:       entry.City,
+			RequestID:  entry.RequestID,
+			Metadata:   entry.Metadata,
+			OccurredAt: entry.OccurredAt.UTC().Format("2006-01-02T15:04:05.000000Z"),
+		})
+	}
+
+	// A full page means there may be older entries
+	if len(entries) == limit && len(entries) > 0 {
+		next := domainSecurityLog.CursorOf(entries[len(entries)-1]).String()
+		response.NextBefore = &next
+	}
+
+	return response
+}
@@ -0,0 +1,29 @@
+package settings
+
+// UpdateSettingsRequest represents a partial settings update, omitted fields are left unchanged
+type UpdateSettingsRequest struct {
+	UserID string `json:"-"`
+
+	// ExpectedRevision is the revision the client edited, nil applies the patch to any revision
+	ExpectedRevision *int64 `json:"-"`
+
+	Theme         *string             `json:"theme,omitempty"`
+	Notifications *NotificationsPatch `json:"notifications,omitempty"`
+	WeekStart     *string             `json:"week_start,omitempty"`
+	Units         *string             `json:"units,omitempty"`
+	Privacy       *PrivacyPatch       `json:"privacy,omitempty"`
+}
+
+type NotificationsPatch struct {
+	Enabled         *bool `json:"enabled,omitempty"`
+	Posts           *bool `json:"posts,omitempty"`
+	Calendar        *bool `json:"calendar,omitempty"`
+	Notes           *bool `json:"notes,omitempty"`
+	Mood            *bool `json:"mood,omitempty"`
+	PartnerActivity *bool `json:"partner_activity,omitempty"`
+}
+
+type PrivacyPatch struct {
+	DefaultPostVisibility *string `json:"default_post_visibility,omitempty"`
+	ShowOnlineStatus      *bool   `json:"show_online_status,omitempty"`
+}
@@ -0,0 +1,71 @@
+package settings
+
+import (
+	domainSettings "github.com/StefanPenchev05/Amora/backend/internal/domain/settings"
+)
+
+// SettingsResponse represents the stored settings of a user
+type SettingsResponse struct {
+	SchemaVersion int                          `json:"schema_version"`
+	Theme         string                       `json:"theme"`
+	Notifications domainSettings.Notifications `json:"notifications"`
+	WeekStart     string                       `json:"week_start"`
+	Units         string                       `json:"units"`
+	Privacy       PrivacyResponse              `json:"privacy"`
+	Revision      int64                        `json:"revision"`
+	UpdatedAt     *string                      `json:"updated_at"`
+}
+
+type PrivacyResponse struct {
+	DefaultPostVisibility string `json:"default_post_visibility"`
+	ShowOnlineStatus      bool   `json:"show_online_status"`
+}
+
+// Helper function to convert domain settings to response
+func NewSettingsResponse(s *domainSettings.UserSettings) *SettingsResponse {
+	response := &SettingsResponse{
+		SchemaVersion: s.SchemaVersion,
+		Theme:         string(s.Theme),
+		Notifications: s.Notifications,
+		WeekStart:     string(s.WeekStart),
+		Units:         string(s.Units),
+		Privacy: PrivacyResponse{
+			DefaultPostVisibility: string(s.Privacy.DefaultPostVisibility),
+			ShowOnlineStatus:      s.Privacy.ShowOnlineStatus,
+		},
+		Revision: s.Revision,
+	}
+
+	// Defaults that were never saved have no update time
+	if !s.UpdatedAt.IsZero() {
+		updatedAt := s.UpdatedAt.UTC().Format("2006-01-02T15:04:05Z")
+		response.UpdatedAt = &updatedAt
+	}
+
+	return response
+}
+
+// ToPatch converts the request into a domain patch
+func (r UpdateSettingsRequest) ToPatch() domainSettings.Patch {
+	patch := domainSettings.Patch{
+		Theme:     r.Theme,
+		WeekStart: r.WeekStart,
+		Units:     r.Units,
+	}
+
+	if r.Notifications != nil {
+		patch.NotificationsEnabled = r.Notifications.Enabled
+		patch.NotifyPosts = r.Notifications.Posts
+		patch.NotifyCalendar = r.Notifications.Calendar
+		patch.NotifyNotes = r.Notifications.Notes
+		patch.NotifyMood = r.Notifications.Mood
+		patch.NotifyPartnerActivity = r.Notifications.PartnerActivity
+	}
+
+	if r.Privacy != nil {
+		patch.DefaultPostVisibility = r.Privacy.DefaultPostVisibility
+		patch.ShowOnlineStatus = r.Privacy.ShowOnlineStatus
+	}
+
+	return patch
+}
@@ -7,12 +7,66 @@ type CreateUserRequest struct {
 	FirstName string `json:"first_name" validate:"required,max=50"`
 	LastName  string `json:"last_name" validate:"required,max=50"`
 	Password  string `json:"password" validate:"required,min=8"`
+
+	// InviteCode links the new user to the partner who shared it
+	InviteCode string `json:"invite_code,omitempty"`
 }
 
 // AuthenticateRequest represents the login input
 type AuthenticateUserRequest struct {
 	EmailOrUsername string `json:"email_or_username" validate:"required"`
 	Password        string `json:"password" validate:"required"`
+	DeviceToken     string `json:"device_token,omitempty"`
 	IPAddress       string `json:"ip_address,omitempty"`
 	UserAgent       string `json:"user_agent,omitempty"`
 }
+
+// VerifyMFARequest represents the MFA step of a login
+type VerifyMFARequest struct {
+	MFAToken    string `json:"mfa_token" validate:"required"`
+	Code        string `json:"code" validate:"required,len=6"`
+	TrustDevice bool   `json:"trust_device,omitempty"`
+	IPAddress   string `json:"-"`
+	UserAgent   string `json:"-"`
+}
+
+// RefreshTokenRequest represents exchanging a refresh token for a new access token
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+// RevokeSessionRequest represents a "this wasn't me" report from a new device alert
+type RevokeSessionRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+// DeviceTrustRequest represents marking a device as trusted or untrusted
+type DeviceTrustRequest struct {
+	UserID    string
+	SessionID string
+	DeviceID  string
+}
+
+// SuggestUsernamesRequest represents the names typed on the signup screen
+type SuggestUsernamesRequest struct {
+	FirstName string
+	LastName  string
+	Count     int
+}
+
+// CheckUsernameRequest represents an availability check while the username is typed
+type CheckUsernameRequest struct {
+	Username  string
+	FirstName string
+	LastName  string
+}
+
+// ChangeRoleRequest represents an administrator assigning a role to a user
+type ChangeRoleRequest struct {
+	ActorID string `json:"-"`
+	UserID  string `json:"-"`
+	Role    string `json:"role" validate:"required"`
+
+	// ExpectedVersion is the version of the user the administrator saw, nil changes any version
+	ExpectedVersion *int64 `json:"-"`
+}
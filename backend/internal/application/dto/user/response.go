@@ -1,31 +1,104 @@
 package user
 
 import (
+	"time"
+
+	settingsDto "github.com/StefanPenchev05/Amora/backend/internal/application/dto/settings"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/session"
+	domainSettings "github.com/StefanPenchev05/Amora/backend/internal/domain/settings"
 	domainUser "github.com/StefanPenchev05/Amora/backend/internal/domain/user"
 )
 
-// CreateUserResponse represents the output after user creation
+// CreateUserResponse represents the output after user creation. It is identical
+// whether or not the email was already registered, so signup cannot be used to
+// discover accounts.
 type CreateUserResponse struct {
-	ID        string `json:"id"`
-	Email     string `json:"email"`
-	Username  string `json:"username"`
-	FirstName string `json:"first_name"`
-	LastName  string `json:"last_name"`
-	CreatedAt string `json:"created_at"`
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+// NewCreateUserResponse returns the neutral signup response
+func NewCreateUserResponse() *CreateUserResponse {
+	return &CreateUserResponse{
+		Status:  "accepted",
+		Message: "Check your email to continue",
+	}
 }
 
 // AuthenticateUserResponse represents the output after login
 type AuthenticateUserResponse struct {
-	AccessToken  string         `json:"access_token"`
+	AccessToken  string         `json:"access_token,omitempty"`
 	RefreshToken string         `json:"refresh_token,omitempty"`
-	ExpiresIn    int            `json:"exp"`
-	TokenType    string         `json:"token_type"`
-	User         UserProfile    `json:"user,omitempty"`
+	ExpiresIn    int            `json:"exp,omitempty"`
+	TokenType    string         `json:"token_type,omitempty"`
+	User         *UserProfile   `json:"user,omitempty"`
 	Bootstrap    *AuthBootstrap `json:"bootstrap,omitempty"`
+
+	// DeviceToken is returned once when the device was trusted, later logins
+	// present it to skip the MFA step
+	DeviceToken string `json:"device_token,omitempty"`
+
+	// MFARequired is set instead of tokens when the device must complete the MFA step
+	MFARequired bool   `json:"mfa_required,omitempty"`
+	MFAToken    string `json:"mfa_token,omitempty"`
+}
+
+// NewMFARequiredResponse asks the client to complete the MFA step
+func NewMFARequiredResponse(mfaToken string) *AuthenticateUserResponse {
+	return &AuthenticateUserResponse{
+		MFARequired: true,
+		MFAToken:    mfaToken,
+	}
+}
+
+// UsernameSuggestionsResponse lists available usernames for the signup screen
+type UsernameSuggestionsResponse struct {
+	Suggestions []string `json:"suggestions"`
+}
+
+// UsernameAvailabilityResponse tells whether a username is free, with
+// alternatives when it is taken
+type UsernameAvailabilityResponse struct {
+	Username    string   `json:"username"`
+	Available   bool     `json:"available"`
+	Suggestions []string `json:"suggestions,omitempty"`
+}
+
+// DeviceResponse represents a device the user has logged in from
+type DeviceResponse struct {
+	ID           string  `json:"id"`
+	Description  string  `json:"description"`
+	IPBlock      string  `json:"ip_block"`
+	FirstSeenAt  string  `json:"first_seen_at"`
+	LastSeenAt   string  `json:"last_seen_at"`
+	Trusted      bool    `json:"trusted"`
+	TrustedUntil *string `json:"trusted_until"`
+
+	// DeviceToken is only set right after trusting the device
+	DeviceToken string `json:"device_token,omitempty"`
+}
+
+// Helper function to convert a domain device to response
+func NewDeviceResponse(device *session.Device) DeviceResponse {
+	response := DeviceResponse{
+		ID:          device.ID,
+		Description: device.UserAgentFamily,
+		IPBlock:     device.IPBlock,
+		FirstSeenAt: device.FirstSeenAt.UTC().Format("2006-01-02T15:04:05Z"),
+		LastSeenAt:  device.LastSeenAt.UTC().Format("2006-01-02T15:04:05Z"),
+		Trusted:     device.HasTrust(time.Now()),
+	}
+	if response.Trusted {
+		trustedUntil := device.TrustedUntil.UTC().Format("2006-01-02T15:04:05Z")
+		response.TrustedUntil = &trustedUntil
+	}
+
+	return response
 }
 
 // Wrapper for UserProfile
 type UserProfile struct {
+	Role          string  `json:"role"`
 	Email         string  `json:"email"`
 	Username      string  `json:"username"`
 	FirstName     string  `json:"first_name"`
@@ -43,14 +116,15 @@ type UserProfile struct {
 
 // AuthBootstrap contains intial data needed by the client application
 type AuthBootstrap struct {
-	Permissions []string               `json:"permissions"`
-	Settings    map[string]interface{} `json:"settings"`
-	Features    []string               `json:"features"`
+	Permissions []string                      `json:"permissions"`
+	Settings    *settingsDto.SettingsResponse `json:"settings"`
+	Features    []string                      `json:"features"`
 }
 
 // Helper function to convert domain user to prfile
 func NewUserProfile(domainUser *domainUser.User) UserProfile {
 	profile := UserProfile{
+		Role:          domainUser.Role.String(),
 		Email:         domainUser.Credentials.Email.String(),
 		Username:      domainUser.Credentials.Username.String(),
 		FirstName:     domainUser.Profile.FirstName,
@@ -69,31 +143,32 @@ func NewUserProfile(domainUser *domainUser.User) UserProfile {
 	return profile
 }
 
-// Helper function to create bootstrap data
-func NewAuthBootstrap(domainUser *domainUser.User) *AuthBootstrap {
-	permissions := []string{"read:profile", "write:profile"}
-
-	// TODO: Add admin permissions if user is admin
-	// if domainUser.IsAdmin() {
-	//     permissions = append(permissions, "admin:users", "admin:system")
-	// }
-
-	settings := map[string]interface{}{
-		"theme":         "auto",
-		"notifications": true,
-		"language":      domainUser.Profile.Locale,
-	}
+// ManagedUserResponse is a user as administrators see and change it
+type ManagedUserResponse struct {
+	ID      string      `json:"id"`
+	Version int64       `json:"version"`
+	Profile UserProfile `json:"profile"`
+}
 
-	features := []string{"posts", "calendar", "notes"}
+func NewManagedUserResponse(domainUser *domainUser.User) ManagedUserResponse {
+	return ManagedUserResponse{
+		ID:      domainUser.ID,
+		Version: domainUser.Version,
+		Profile: NewUserProfile(domainUser),
+	}
+}
 
-	// TODO: Add premium features if user has premium
-	// if domainUser.HasPremium() {
-	//     features = append(features, "advanced_analytics", "priority_support")
-	// }
+// Helper function to create bootstrap data
+func NewAuthBootstrap(domainUser *domainUser.User, features []string, userSettings *domainSettings.UserSettings) *AuthBootstrap {
+	// Permissions are derived from the user's role
+	permissions := make([]string, 0)
+	for _, permission := range domainUser.Permissions() {
+		permissions = append(permissions, permission.String())
+	}
 
 	return &AuthBootstrap{
 		Permissions: permissions,
-		Settings:    settings,
+		Settings:    settingsDto.NewSettingsResponse(userSettings),
 		Features:    features,
 	}
 }
@@ -0,0 +1,51 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/user"
+)
+
+// JSONCodec encodes domain events as JSON envelopes for brokers and outside consumers
+type JSONCodec struct {
+	registry *Registry
+}
+
+func NewJSONCodec(registry *Registry) *JSONCodec {
+	return &JSONCodec{registry: registry}
+}
+
+// Marshal encodes the event with the metadata of ctx
+func (c *JSONCodec) Marshal(ctx context.Context, event user.DomainEvent) ([]byte, error) {
+	envelope, err := c.registry.Encode(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.MarshalEnvelope(envelope)
+}
+
+func (c *JSONCodec) MarshalEnvelope(envelope Envelope) ([]byte, error) {
+	data, err := json.Marshal(envelope)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode envelope of %s: %w", envelope.EventType, err)
+	}
+	return data, nil
+}
+
+// Unmarshal decodes an envelope and its typed event, upcasting older payloads
+func (c *JSONCodec) Unmarshal(data []byte) (Envelope, user.DomainEvent, error) {
+	var envelope Envelope
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return Envelope{}, nil, fmt.Errorf("failed to decode envelope: %w", err)
+	}
+
+	event, err := c.registry.Decode(envelope)
+	if err != nil {
+		return envelope, nil, err
+	}
+
+	return envelope, event, nil
+}
@@ -0,0 +1,14 @@
+package events
+
+import "context"
+
+// DeliveryLog remembers which handlers handled an event. A redelivered event
+// skips them, so a failing handler does not make the others repeat their side
+// effects, like sending an email again.
+type DeliveryLog interface {
+	// Handled reports whether the handler already handled the event
+	Handled(ctx context.Context, eventID, handler string) (bool, error)
+
+	// MarkHandled records that the handler handled the event, marking it twice is not an error
+	MarkHandled(ctx context.Context, eventID, handler string) error
+}
@@ -0,0 +1,62 @@
+package events
+
+import (
+	"context"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/relationship"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/user"
+)
+
+const (
+	aggregateUser         = "user"
+	aggregateRelationship = "relationship"
+	aggregateInvite       = "relationship_invite"
+)
+
+var defaultRegistry = newDomainRegistry()
+
+// DefaultRegistry returns the registry of every domain event of the application
+func DefaultRegistry() *Registry {
+	return defaultRegistry
+}
+
+// Encode wraps a domain event using the default registry
+func Encode(ctx context.Context, event user.DomainEvent) (Envelope, error) {
+	return defaultRegistry.Encode(ctx, event)
+}
+
+// newDomainRegistry registers the current schema version of each domain event.
+// Bump the version and register an upcaster whenever a payload changes shape.
+func newDomainRegistry() *Registry {
+	registry := NewRegistry()
+
+	registry.Register("user.created", aggregateUser, 1, func() user.DomainEvent { return &user.UserCreatedEvent{} })
+	registry.Register("user.logged_in", aggregateUser, 1, func() user.DomainEvent { return &user.UserLoggedInEvent{} })
+	registry.Register("user.login_failed", aggregateUser, 1, func() user.DomainEvent { return &user.UserLoginFailedEvent{} })
+	registry.Register("user.mfa_failed", aggregateUser, 1, func() user.DomainEvent { return &user.UserMFAFailedEvent{} })
+	registry.Register("user.role_changed", aggregateUser, 1, func() user.DomainEvent { return &user.UserRoleChangedEvent{} })
+	registry.Register("user.password_changed", aggregateUser, 1, func() user.DomainEvent { return &user.UserPasswordChangedEvent{} })
+	registry.Register("user.mfa_enabled", aggregateUser, 1, func() user.DomainEvent { return &user.UserMFAEnabledEvent{} })
+	registry.Register("user.mfa_disabled", aggregateUser, 1, func() user.DomainEvent { return &user.UserMFADisabledEvent{} })
+	registry.Register("email.email_verified", aggregateUser, 1, func() user.DomainEvent { return &user.UserEmailVerifiedEvent{} })
+
+	// Sessions belong to the user, the event is keyed by the user ID
+	registry.Register("session.revoked", aggregateUser, 1, func() user.DomainEvent { return &user.SessionRevokedEvent{} })
+
+	registry.Register("relationship.created", aggregateRelationship, 1, func() user.DomainEvent { return &relationship.RelationshipCreatedEvent{} })
+	registry.Register("relationship.paused", aggregateRelationship, 1, func() user.DomainEvent { return &relationship.RelationshipPausedEvent{} })
+	registry.Register("relationship.resumed", aggregateRelationship, 1, func() user.DomainEvent { return &relationship.RelationshipResumedEvent{} })
+	registry.Register("relationship.end_requested", aggregateRelationship, 1, func() user.DomainEvent { return &relationship.RelationshipEndRequestedEvent{} })
+	registry.Register("relationship.ended", aggregateRelationship, 1, func() user.DomainEvent { return &relationship.RelationshipEndedEvent{} })
+	registry.Register("relationship.reconnect_requested", aggregateRelationship, 1, func() user.DomainEvent { return &relationship.RelationshipReconnectRequestedEvent{} })
+	registry.Register("relationship.reconnected", aggregateRelationship, 1, func() user.DomainEvent { return &relationship.RelationshipReconnectedEvent{} })
+	registry.Register("relationship.expired", aggregateRelationship, 1, func() user.DomainEvent { return &relationship.RelationshipExpiredEvent{} })
+	registry.Register("relationship.profile_updated", aggregateRelationship, 1, func() user.DomainEvent { return &relationship.RelationshipProfileUpdatedEvent{} })
+	registry.Register("relationship.request_withdrawn", aggregateRelationship, 1, func() user.DomainEvent { return &relationship.RelationshipRequestWithdrawnEvent{} })
+	registry.Register("relationship.invite_sent", aggregateInvite, 1, func() user.DomainEvent { return &relationship.InviteSentEvent{} })
+	registry.Register("relationship.invite_accepted", aggregateInvite, 1, func() user.DomainEvent { return &relationship.InviteAcceptedEvent{} })
+	registry.Register("relationship.invite_declined", aggregateInvite, 1, func() user.DomainEvent { return &relationship.InviteDeclinedEvent{} })
+	registry.Register("relationship.invite_cancelled", aggregateInvite, 1, func() user.DomainEvent { return &relationship.InviteCancelledEvent{} })
+
+	return registry
+}
@@ -0,0 +1,70 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/application/interfaces"
+)
+
+// Envelope is the serialized form of a domain event shared by the outbox, the
+// event bus and outside consumers. Payload is encoded in SchemaVersion of the type.
+type Envelope struct {
+	EventID       string          `json:"event_id"`
+	EventType     string          `json:"event_type"`
+	SchemaVersion int             `json:"schema_version"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   string          `json:"aggregate_id"`
+	ActorID       string          `json:"actor_id,omitempty"`
+	CorrelationID string          `json:"correlation_id,omitempty"`
+	CausationID   string          `json:"causation_id,omitempty"`
+	IPAddress     string          `json:"ip_address,omitempty"`
+	UserAgent     string          `json:"user_agent,omitempty"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+	Payload       json.RawMessage `json:"payload"`
+}
+
+// Publisher delivers encoded events to their consumers
+type Publisher interface {
+	Publish(ctx context.Context, envelopes ...Envelope) error
+}
+
+// Metadata tells who started the operation raising an event, from which client, and what caused it
+type Metadata struct {
+	ActorID       string
+	CorrelationID string
+	CausationID   string
+	IPAddress     string
+	UserAgent     string
+}
+
+type metadataContextKey struct{}
+
+// WithCause returns a copy of ctx for handling the envelope. Events raised while
+// handling it share its correlation ID and name it as their cause.
+func WithCause(ctx context.Context, envelope Envelope) context.Context {
+	return context.WithValue(ctx, metadataContextKey{}, Metadata{
+		ActorID:       envelope.ActorID,
+		CorrelationID: envelope.CorrelationID,
+		CausationID:   envelope.EventID,
+		IPAddress:     envelope.IPAddress,
+		UserAgent:     envelope.UserAgent,
+	})
+}
+
+// MetadataFromContext returns the metadata of events raised in ctx. Inside a request
+// the request ID correlates the events and the authenticated user is the actor.
+func MetadataFromContext(ctx context.Context) Metadata {
+	if metadata, ok := ctx.Value(metadataContextKey{}).(Metadata); ok {
+		return metadata
+	}
+
+	info := interfaces.RequestInfoFromContext(ctx)
+	return Metadata{
+		ActorID:       info.ActorID,
+		CorrelationID: info.RequestID,
+		IPAddress:     info.IPAddress,
+		UserAgent:     info.UserAgent,
+	}
+}
@@ -0,0 +1,15 @@
+package events
+
+import (
+	"context"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/user"
+)
+
+// Handler reacts to domain events delivered by the event bus. Delivery is at
+// least once, so handlers must tolerate seeing the same event ID again.
+type Handler interface {
+	// EventTypes lists the event types the handler subscribes to
+	EventTypes() []string
+	Handle(ctx context.Context, event user.DomainEvent) error
+}
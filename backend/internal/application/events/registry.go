@@ -0,0 +1,146 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/user"
+)
+
+var (
+	ErrUnknownEventType = errors.New("unknown event type")
+	ErrUnknownVersion   = errors.New("unknown event schema version")
+)
+
+// Upcaster rewrites a payload of one schema version into the next one
+type Upcaster func(payload json.RawMessage) (json.RawMessage, error)
+
+// Registry knows the current schema version of every event type and how to
+// decode older payloads. Register every type before encoding or decoding it.
+type Registry struct {
+	mu    sync.RWMutex
+	types map[string]*registration
+}
+
+type registration struct {
+	aggregateType string
+	version       int
+	newEvent      func() user.DomainEvent
+
+	// upcasters are keyed by the version they upgrade from
+	upcasters map[int]Upcaster
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		types: make(map[string]*registration),
+	}
+}
+
+// Register adds an event type in its current schema version. newEvent returns
+// the pointer the payload is decoded into.
+func (r *Registry) Register(eventType, aggregateType string, version int, newEvent func() user.DomainEvent) {
+	if version < 1 {
+		panic(fmt.Sprintf("events: schema version of %s must be positive", eventType))
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.types[eventType]; ok {
+		panic(fmt.Sprintf("events: %s registered twice", eventType))
+	}
+	r.types[eventType] = &registration{
+		aggregateType: aggregateType,
+		version:       version,
+		newEvent:      newEvent,
+		upcasters:     make(map[int]Upcaster),
+	}
+}
+
+// RegisterUpcaster upgrades payloads of the event type from fromVersion to fromVersion+1
+func (r *Registry) RegisterUpcaster(eventType string, fromVersion int, upcaster Upcaster) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	registered, ok := r.types[eventType]
+	if !ok {
+		panic(fmt.Sprintf("events: upcaster for unregistered %s", eventType))
+	}
+	if fromVersion < 1 || fromVersion >= registered.version {
+		panic(fmt.Sprintf("events: upcaster of %s from version %d is out of range", eventType, fromVersion))
+	}
+	registered.upcasters[fromVersion] = upcaster
+}
+
+// Encode wraps the event in an envelope carrying the metadata of ctx
+func (r *Registry) Encode(ctx context.Context, event user.DomainEvent) (Envelope, error) {
+	registered, err := r.lookup(event.GetEventType())
+	if err != nil {
+		return Envelope{}, err
+	}
+
+	payload, err := json.Marshal(event.GetEventData())
+	if err != nil {
+		return Envelope{}, fmt.Errorf("failed to encode event %s: %w", event.GetEventType(), err)
+	}
+
+	metadata := MetadataFromContext(ctx)
+	return Envelope{
+		EventID:       event.GetEventID(),
+		EventType:     event.GetEventType(),
+		SchemaVersion: registered.version,
+		AggregateType: registered.aggregateType,
+		AggregateID:   event.GetAggregateID(),
+		ActorID:       metadata.ActorID,
+		CorrelationID: metadata.CorrelationID,
+		CausationID:   metadata.CausationID,
+		IPAddress:     metadata.IPAddress,
+		UserAgent:     metadata.UserAgent,
+		OccurredAt:    event.GetOccurredAt(),
+		Payload:       payload,
+	}, nil
+}
+
+// Decode returns the typed event of the envelope, upcasting older payloads first
+func (r *Registry) Decode(envelope Envelope) (user.DomainEvent, error) {
+	registered, err := r.lookup(envelope.EventType)
+	if err != nil {
+		return nil, err
+	}
+	if envelope.SchemaVersion < 1 || envelope.SchemaVersion > registered.version {
+		return nil, fmt.Errorf("%w: %s version %d", ErrUnknownVersion, envelope.EventType, envelope.SchemaVersion)
+	}
+
+	payload := envelope.Payload
+	for version := envelope.SchemaVersion; version < registered.version; version++ {
+		upcaster, ok := registered.upcasters[version]
+		if !ok {
+			return nil, fmt.Errorf("%w: no upcaster for %s version %d", ErrUnknownVersion, envelope.EventType, version)
+		}
+		if payload, err = upcaster(payload); err != nil {
+			return nil, fmt.Errorf("failed to upcast %s from version %d: %w", envelope.EventType, version, err)
+		}
+	}
+
+	event := registered.newEvent()
+	if err := json.Unmarshal(payload, event); err != nil {
+		return nil, fmt.Errorf("failed to decode event %s: %w", envelope.EventType, err)
+	}
+
+	return event, nil
+}
+
+func (r *Registry) lookup(eventType string) (*registration, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	registered, ok := r.types[eventType]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEventType, eventType)
+	}
+	return registered, nil
+}
@@ -0,0 +1,113 @@
+package features
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/application/interfaces"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/feature"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/user"
+)
+
+// Service evaluates flags from the flag repository against the subject's plan
+type Service struct {
+	flagRepo    feature.FlagRepository
+	planRepo    feature.PlanRepository
+	environment string
+	logger      *slog.Logger
+}
+
+func NewService(
+	flagRepo feature.FlagRepository,
+	planRepo feature.PlanRepository,
+	environment string,
+	logger *slog.Logger,
+) interfaces.FeatureService {
+	return &Service{
+		flagRepo:    flagRepo,
+		planRepo:    planRepo,
+		environment: environment,
+		logger:      logger,
+	}
+}
+
+func (s *Service) IsEnabled(ctx context.Context, key string, subject interfaces.FeatureSubject) bool {
+	flags, ok := s.listFlags(ctx)
+	if !ok {
+		return false
+	}
+
+	domainSubject := s.resolveSubject(ctx, subject)
+	for _, flag := range flags {
+		if flag.Key == key {
+			return flag.IsEnabledFor(domainSubject)
+		}
+	}
+
+	return false
+}
+
+func (s *Service) EnabledFeatures(ctx context.Context, subject interfaces.FeatureSubject) []string {
+	enabled := make([]string, 0)
+
+	flags, ok := s.listFlags(ctx)
+	if !ok {
+		return enabled
+	}
+
+	domainSubject := s.resolveSubject(ctx, subject)
+	for _, flag := range flags {
+		if flag.IsEnabledFor(domainSubject) {
+			enabled = append(enabled, flag.Key)
+		}
+	}
+	sort.Strings(enabled)
+
+	return enabled
+}
+
+// listFlags fails closed, a broken flag source disables every feature
+func (s *Service) listFlags(ctx context.Context) ([]feature.Flag, bool) {
+	flags, err := s.flagRepo.ListFlags(ctx)
+	if err != nil {
+		s.logger.Error("Failed to load feature flags",
+			"error", err.Error(),
+		)
+		return nil, false
+	}
+
+	return flags, true
+}
+
+func (s *Service) resolveSubject(ctx context.Context, subject interfaces.FeatureSubject) feature.Subject {
+	plan := feature.PlanFree
+	if subject.UserID != "" {
+		userPlan, err := s.planRepo.GetPlanByUserID(ctx, subject.UserID)
+		if err != nil {
+			s.logger.Warn("Failed to resolve user plan, falling back to free",
+				"user_id", subject.UserID,
+				"error", err.Error(),
+			)
+		} else {
+			plan = userPlan
+		}
+	}
+
+	return feature.Subject{
+		UserID:         subject.UserID,
+		RelationshipID: subject.RelationshipID,
+		Plan:           plan,
+		Environment:    s.environment,
+	}
+}
+
+// SubjectOf builds the subject of a user. The bootstrap and gated routes must both
+// use it, rollouts bucket partners by their relationship.
+func SubjectOf(u *user.User) interfaces.FeatureSubject {
+	subject := interfaces.FeatureSubject{UserID: u.ID}
+	if u.Profile.RelationshipID != nil {
+		subject.RelationshipID = *u.Profile.RelationshipID
+	}
+	return subject
+}
@@ -0,0 +1,31 @@
+package interfaces
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrTooManyAttempts is returned while a key is locked out
+var ErrTooManyAttempts = errors.New("too many failed attempts, try again later")
+
+// AttemptLimit is how many failures a key may have within Window before it is
+// locked out for Lockout
+type AttemptLimit struct {
+	MaxFailures int
+	Window      time.Duration
+	Lockout     time.Duration
+}
+
+// AttemptLimiter counts failed attempts by key, to slow down guessing of codes.
+// Keys name what they count, like "invite_code:ip:203.0.113.7".
+type AttemptLimiter interface {
+	// Check returns ErrTooManyAttempts while key is locked out
+	Check(ctx context.Context, key string) error
+
+	// Fail counts a failed attempt and locks key out once it reaches the limit
+	Fail(ctx context.Context, key string, limit AttemptLimit) error
+
+	// Reset forgets the failures of key, a lockout in force stays
+	Reset(ctx context.Context, key string) error
+}
@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+)
+
+// Cache stores short-lived copies of hot reads. It is never the source of truth,
+// so callers fall back to the repository whenever it fails.
+type Cache interface {
+	// Get reports found as false on a miss or an expired entry
+	Get(ctx context.Context, key string) (value []byte, found bool, err error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+}
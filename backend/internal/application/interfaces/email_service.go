@@ -1,10 +1,41 @@
 package interfaces
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // EmailService interface for email operations
 type EmailService interface {
 	SendWelcomeEmail(ctx context.Context, email, firstName string) error
 	SendEmailVerification(ctx context.Context, email, token string) error
 	SendPasswordResetEmail(ctx context.Context, email, token string) error
+
+	// SendAccountExistsEmail tells the owner that someone tried to sign up with their email
+	SendAccountExistsEmail(ctx context.Context, email string) error
+
+	// SendNewDeviceAlert warns the user about a login from a device never seen before
+	SendNewDeviceAlert(ctx context.Context, email string, alert NewDeviceAlert) error
+
+	// SendRelationshipInvite tells the invitee that someone wants to be their partner
+	SendRelationshipInvite(ctx context.Context, email string, invite RelationshipInviteNotice) error
+}
+
+// NewDeviceAlert describes a login from an unknown device
+type NewDeviceAlert struct {
+	Device    string
+	IPAddress string
+	Location  GeoLocation
+	LoggedAt  time.Time
+
+	// RevokeURL is the "this wasn't me" link that revokes the session
+	RevokeURL string
+}
+
+// RelationshipInviteNotice describes an invite waiting for the invitee's answer
+type RelationshipInviteNotice struct {
+	InviterName     string
+	InviterUsername string
+	Message         string
+	ExpiresAt       time.Time
 }
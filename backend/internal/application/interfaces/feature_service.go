@@ -0,0 +1,18 @@
+package interfaces
+
+import "context"
+
+// FeatureService evaluates feature flags and plan entitlements
+type FeatureService interface {
+	// IsEnabled reports whether the feature is enabled for the subject.
+	IsEnabled(ctx context.Context, key string, subject FeatureSubject) bool
+
+	// EnabledFeatures returns the keys of every feature enabled for the subject.
+	EnabledFeatures(ctx context.Context, subject FeatureSubject) []string
+}
+
+// FeatureSubject identifies who features are evaluated for
+type FeatureSubject struct {
+	UserID         string
+	RelationshipID string
+}
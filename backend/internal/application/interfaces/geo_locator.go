@@ -0,0 +1,13 @@
+package interfaces
+
+// GeoLocator resolves an IP address to a coarse location
+type GeoLocator interface {
+	// Locate returns an empty location when the address is unknown.
+	Locate(ipAddress string) (GeoLocation, error)
+}
+
+// GeoLocation is a country/city level location, never more precise
+type GeoLocation struct {
+	Country string `json:"country,omitempty"`
+	City    string `json:"city,omitempty"`
+}
@@ -0,0 +1,27 @@
+package interfaces
+
+import "errors"
+
+// ErrUnsupportedQRFormat is returned for image formats the renderer cannot produce
+var ErrUnsupportedQRFormat = errors.New("unsupported QR code format")
+
+// QRCodeFormat is an image format a QR code can be rendered in
+type QRCodeFormat string
+
+const (
+	QRCodePNG QRCodeFormat = "png"
+	QRCodeSVG QRCodeFormat = "svg"
+)
+
+// ContentType returns the media type of images in the format
+func (f QRCodeFormat) ContentType() string {
+	if f == QRCodeSVG {
+		return "image/svg+xml"
+	}
+	return "image/png"
+}
+
+// QRCodeRenderer draws content as a scannable QR code image
+type QRCodeRenderer interface {
+	Render(content string, format QRCodeFormat) ([]byte, error)
+}
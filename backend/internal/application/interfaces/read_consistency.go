@@ -0,0 +1,18 @@
+package interfaces
+
+import "context"
+
+type primaryReadsContextKey struct{}
+
+// WithPrimaryReads returns a copy of ctx whose reads skip replicas and caches.
+// Use it for reads that must see a write made just before, by this request or
+// by the one that issued the token being checked.
+func WithPrimaryReads(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryReadsContextKey{}, true)
+}
+
+// ReadsFromPrimary reports whether reads in ctx must come from the primary
+func ReadsFromPrimary(ctx context.Context) bool {
+	primary, _ := ctx.Value(primaryReadsContextKey{}).(bool)
+	return primary
+}
@@ -0,0 +1,26 @@
+package interfaces
+
+import "context"
+
+type requestInfoContextKey struct{}
+
+// RequestInfo describes the client request that triggered the current operation
+type RequestInfo struct {
+	RequestID string
+	IPAddress string
+	UserAgent string
+
+	// ActorID is the authenticated user, empty for anonymous requests
+	ActorID string
+}
+
+// WithRequestInfo returns a copy of ctx carrying the request info
+func WithRequestInfo(ctx context.Context, info RequestInfo) context.Context {
+	return context.WithValue(ctx, requestInfoContextKey{}, info)
+}
+
+// RequestInfoFromContext returns the request info, empty outside of a request
+func RequestInfoFromContext(ctx context.Context) RequestInfo {
+	info, _ := ctx.Value(requestInfoContextKey{}).(RequestInfo)
+	return info
+}
@@ -1,21 +1,66 @@
 package interfaces
 
+import (
+	"strings"
+	"time"
+)
+
 // JWTService interface for token operations
 type JWTService interface {
-	// GenerateAccessToken creates a short-lived access token for the given user ID.
-	GenerateAccessToken(userID string) (string, error)
+	// GenerateAccessToken creates a short-lived access token for the given subject.
+	GenerateAccessToken(subject TokenSubject) (string, error)
+
+	// GenerateRefreshToken creates a long-lived refresh token for the given subject.
+	GenerateRefreshToken(subject TokenSubject) (string, error)
+
+	// GenerateMFAToken creates a token for the MFA challenge opened when the password step passed
+	// on the given device. It expires with the challenge, which is what makes it single-use.
+	GenerateMFAToken(challengeID, userID, deviceFingerprint string, expiresAt time.Time) (string, error)
 
-	// GenerateRefreshToken creates a long-lived refresh token for the given user ID.
-	GenerateRefreshToken(userID string) (string, error)
+	// ValidateMFAToken verifies an MFA token and returns the challenge, user and device fingerprint it was issued for.
+	ValidateMFAToken(token string) (challengeID, userID, deviceFingerprint string, err error)
 
 	// ValidateToken verifies and parses a JWT token string.
 	ValidateToken(token string) (*TokenClaims, error)
 
-	// RefreshAccessToken generates a new access token using a valid refresh token.
-	RefreshAccessToken(refreshToken string) (string, error)
+	// ValidateRefreshToken verifies a refresh token and returns its claims. Callers must
+	// check the session and reload the role before issuing a new access token.
+	ValidateRefreshToken(refreshToken string) (*TokenClaims, error)
 
 	// GetAccessTokenExpiration returns the expiration time in seconds for access tokens.
 	GetAccessTokenExpiration() int64
+
+	// PublicJWKS returns the public keys other services use to verify tokens.
+	PublicJWKS() JSONWebKeySet
+}
+
+// JSONWebKeySet is the document published at /.well-known/jwks.json (RFC 7517)
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// JSONWebKey is a single public verification key
+type JSONWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+
+	// Crv and X describe OKP (Ed25519) keys
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+
+	// N and E describe RSA keys
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+}
+
+// TokenSubject describes who a token is issued to
+type TokenSubject struct {
+	UserID      string
+	SessionID   string
+	Role        string
+	Permissions []string
 }
 
 // TokenClaims represents JWT token claims
@@ -23,6 +68,9 @@ type TokenClaims struct {
 	// UserID is unique identifier of the authenticated user
 	UserID string `json:"user_id"`
 
+	// SessionID identifies the login session the token belongs to
+	SessionID string `json:"sid"`
+
 	// ExpiresAt is the Unix timestamp when the token expires
 	ExpiresAt int64 `json:"exp"`
 
@@ -41,3 +89,18 @@ type TokenClaims struct {
 	// Scope defiens the permissions granted by this token
 	Scope string `json:"scope"`
 }
+
+// Permissions returns the space-separated scope as a list of permissions
+func (c *TokenClaims) Permissions() []string {
+	return strings.Fields(c.Scope)
+}
+
+// HasPermission checks if the token scope grants the given permission
+func (c *TokenClaims) HasPermission(permission string) bool {
+	for _, p := range c.Permissions() {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
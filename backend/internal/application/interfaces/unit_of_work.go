@@ -0,0 +1,62 @@
+package interfaces
+
+import (
+	"context"
+	"sync"
+)
+
+// UnitOfWork runs several repository calls as one atomic change.
+// Repositories called with the context handed to fn join the unit, so every
+// write commits together or not at all. Calling Do again inside fn joins the
+// outer unit. fn may be run more than once when the storage retries a
+// deadlock, it must not have side effects outside the repositories, use
+// AfterCommit for those.
+type UnitOfWork interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type unitContextKey struct{}
+
+// UnitHooks collects what has to happen once a unit of work commits. The
+// UnitOfWork implementations open one per attempt with WithUnitHooks.
+type UnitHooks struct {
+	mu          sync.Mutex
+	afterCommit []func(ctx context.Context)
+}
+
+// WithUnitHooks returns a copy of ctx running inside the unit of work owning hooks
+func WithUnitHooks(ctx context.Context, hooks *UnitHooks) context.Context {
+	return context.WithValue(ctx, unitContextKey{}, hooks)
+}
+
+// InUnitOfWork reports whether ctx runs inside a unit of work
+func InUnitOfWork(ctx context.Context) bool {
+	_, ok := ctx.Value(unitContextKey{}).(*UnitHooks)
+	return ok
+}
+
+// AfterCommit runs fn once the unit of work in ctx commits, and never when it
+// rolls back. Outside of a unit fn runs right away.
+func AfterCommit(ctx context.Context, fn func(ctx context.Context)) {
+	hooks, ok := ctx.Value(unitContextKey{}).(*UnitHooks)
+	if !ok {
+		fn(ctx)
+		return
+	}
+
+	hooks.mu.Lock()
+	defer hooks.mu.Unlock()
+	hooks.afterCommit = append(hooks.afterCommit, fn)
+}
+
+// Committed runs the functions registered with AfterCommit, in order
+func (h *UnitHooks) Committed(ctx context.Context) {
+	h.mu.Lock()
+	afterCommit := h.afterCommit
+	h.afterCommit = nil
+	h.mu.Unlock()
+
+	for _, fn := range afterCommit {
+		fn(ctx)
+	}
+}
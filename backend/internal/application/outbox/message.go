@@ -0,0 +1,49 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/application/events"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/user"
+)
+
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusPublished Status = "published"
+
+	// StatusDead messages exhausted their attempts and need manual attention
+	StatusDead Status = "dead"
+)
+
+// Message is a domain event stored for publishing. EventID stays the same across
+// retries so consumers can deduplicate deliveries.
+type Message struct {
+	events.Envelope
+
+	Status        Status
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+}
+
+// NewMessages encodes domain events for the outbox with the metadata of ctx
+func NewMessages(ctx context.Context, domainEvents ...user.DomainEvent) ([]Message, error) {
+	messages := make([]Message, 0, len(domainEvents))
+	for _, event := range domainEvents {
+		envelope, err := events.Encode(ctx, event)
+		if err != nil {
+			return nil, err
+		}
+
+		messages = append(messages, Message{
+			Envelope:      envelope,
+			Status:        StatusPending,
+			NextAttemptAt: envelope.OccurredAt,
+		})
+	}
+
+	return messages, nil
+}
@@ -0,0 +1,27 @@
+package outbox
+
+import (
+	"context"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/application/interfaces"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/user"
+)
+
+// Publisher hands events to the outbox instead of publishing them directly. Use
+// it for events that are not saved together with an aggregate.
+type Publisher struct {
+	store Store
+}
+
+func NewPublisher(store Store) interfaces.EventPublisher {
+	return &Publisher{store: store}
+}
+
+func (p *Publisher) PublishEvents(ctx context.Context, events ...user.DomainEvent) error {
+	messages, err := NewMessages(ctx, events...)
+	if err != nil {
+		return err
+	}
+
+	return p.store.Append(ctx, messages...)
+}
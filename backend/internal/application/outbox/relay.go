@@ -0,0 +1,162 @@
+package outbox
+
+import (
+	"context"
+	"log/slog"
+	"math/rand/v2"
+	"sync/atomic"
+	"time"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/application/events"
+)
+
+const maxErrorLength = 1024
+
+// RelayOptions tunes how the relay polls and retries
+type RelayOptions struct {
+	PollInterval time.Duration
+	BatchSize    int
+	Lease        time.Duration
+	MaxAttempts  int
+	BaseBackoff  time.Duration
+	MaxBackoff   time.Duration
+}
+
+func DefaultRelayOptions() RelayOptions {
+	return RelayOptions{
+		PollInterval: time.Second,
+		BatchSize:    100,
+		Lease:        30 * time.Second,
+		MaxAttempts:  10,
+		BaseBackoff:  time.Second,
+		MaxBackoff:   5 * time.Minute,
+	}
+}
+
+// Relay publishes stored messages until they succeed or are dead-lettered.
+// Delivery is at least once, consumers deduplicate by event ID.
+type Relay struct {
+	store     Store
+	publisher events.Publisher
+	options   RelayOptions
+	logger    *slog.Logger
+
+	// lastPoll is the Unix nano time the relay last polled the store
+	lastPoll atomic.Int64
+}
+
+func NewRelay(store Store, publisher events.Publisher, options RelayOptions, logger *slog.Logger) *Relay {
+	return &Relay{
+		store:     store,
+		publisher: publisher,
+		options:   options,
+		logger:    logger,
+	}
+}
+
+// Run polls the outbox until the context is cancelled
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.options.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		// Keep draining while full batches come back
+		for ctx.Err() == nil {
+			claimed := r.RelayBatch(ctx)
+			r.lastPoll.Store(time.Now().UnixNano())
+			if claimed < r.options.BatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// LastPoll returns when the relay last polled the store, zero before it runs
+func (r *Relay) LastPoll() time.Time {
+	if nanos := r.lastPoll.Load(); nanos != 0 {
+		return time.Unix(0, nanos)
+	}
+	return time.Time{}
+}
+
+// RelayBatch publishes one batch of due messages and returns how many were claimed
+func (r *Relay) RelayBatch(ctx context.Context) int {
+	messages, err := r.store.Claim(ctx, r.options.BatchSize, r.options.Lease)
+	if err != nil {
+		if ctx.Err() == nil {
+			r.logger.Error("Failed to claim outbox messages", "error", err.Error())
+		}
+		return 0
+	}
+
+	for _, message := range messages {
+		r.relay(ctx, message)
+	}
+
+	return len(messages)
+}
+
+func (r *Relay) relay(ctx context.Context, message Message) {
+	publishErr := r.publisher.Publish(ctx, message.Envelope)
+	if publishErr == nil {
+		if err := r.store.MarkPublished(ctx, message.EventID); err != nil {
+			// The lease expires and the message is published again, consumers deduplicate
+			r.logger.Error("Failed to mark outbox message published",
+				"event_id", message.EventID,
+				"error", err.Error(),
+			)
+		}
+		return
+	}
+
+	message.Attempts++
+	message.LastError = truncate(publishErr.Error(), maxErrorLength)
+	if message.Attempts >= r.options.MaxAttempts {
+		message.Status = StatusDead
+		r.logger.Error("Outbox message dead-lettered",
+			"event_id", message.EventID,
+			"event_type", message.EventType,
+			"attempts", message.Attempts,
+			"error", publishErr.Error(),
+		)
+	} else {
+		message.NextAttemptAt = time.Now().Add(r.backoff(message.Attempts))
+		r.logger.Warn("Failed to publish outbox message",
+			"event_id", message.EventID,
+			"event_type", message.EventType,
+			"attempts", message.Attempts,
+			"next_attempt_at", message.NextAttemptAt,
+			"error", publishErr.Error(),
+		)
+	}
+
+	if err := r.store.MarkFailed(ctx, message); err != nil {
+		r.logger.Error("Failed to record outbox failure",
+			"event_id", message.EventID,
+			"error", err.Error(),
+		)
+	}
+}
+
+// backoff doubles per attempt up to MaxBackoff, with jitter so retries spread out
+func (r *Relay) backoff(attempts int) time.Duration {
+	delay := r.options.BaseBackoff << min(attempts-1, 30)
+	if delay <= 0 || delay > r.options.MaxBackoff {
+		delay = r.options.MaxBackoff
+	}
+
+	return delay/2 + rand.N(delay/2+1)
+}
+
+func truncate(value string, length int) string {
+	if len(value) <= length {
+		return value
+	}
+	return value[:length]
+}
@@ -0,0 +1,23 @@
+package outbox
+
+import (
+	"context"
+	"time"
+)
+
+// Store persists outbox messages. Repositories write the events of an aggregate
+// through it in the same transaction as the aggregate itself.
+type Store interface {
+	// Append stores pending messages, messages with a known event ID are ignored
+	Append(ctx context.Context, messages ...Message) error
+
+	// Claim leases up to limit due pending messages, oldest first, so that only
+	// one relay publishes them until the lease expires
+	Claim(ctx context.Context, limit int, lease time.Duration) ([]Message, error)
+
+	MarkPublished(ctx context.Context, eventID string) error
+
+	// MarkFailed records a failed attempt, the message becomes due again at
+	// NextAttemptAt or is dead-lettered with StatusDead
+	MarkFailed(ctx context.Context, message Message) error
+}
@@ -0,0 +1,76 @@
+package subscribers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/application/interfaces"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/relationship"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/user"
+)
+
+// RelationshipInviteSubscriber emails invitees about the invites they received
+type RelationshipInviteSubscriber struct {
+	userRepo     user.Repository
+	emailService interfaces.EmailService
+	logger       *slog.Logger
+}
+
+func NewRelationshipInviteSubscriber(userRepo user.Repository, emailService interfaces.EmailService, logger *slog.Logger) *RelationshipInviteSubscriber {
+	return &RelationshipInviteSubscriber{
+		userRepo:     userRepo,
+		emailService: emailService,
+		logger:       logger,
+	}
+}
+
+// EventTypes lists the domain events the subscriber handles
+func (s *RelationshipInviteSubscriber) EventTypes() []string {
+	return []string{"relationship.invite_sent"}
+}
+
+func (s *RelationshipInviteSubscriber) Handle(ctx context.Context, event user.DomainEvent) error {
+	sent, ok := event.(*relationship.InviteSentEvent)
+	if !ok {
+		return nil
+	}
+
+	// Code invites are shared by the inviter, there is nobody to email yet
+	if sent.InviteeID == "" {
+		return nil
+	}
+
+	inviter, err := s.userRepo.GetByID(ctx, sent.InviterID)
+	if err != nil {
+		return s.skipDeleted(sent, fmt.Errorf("failed to load inviter: %w", err))
+	}
+	invitee, err := s.userRepo.GetByID(ctx, sent.InviteeID)
+	if err != nil {
+		return s.skipDeleted(sent, fmt.Errorf("failed to load invitee: %w", err))
+	}
+
+	notice := interfaces.RelationshipInviteNotice{
+		InviterName:     inviter.GetFullName(),
+		InviterUsername: inviter.Credentials.Username.String(),
+		Message:         sent.Message,
+		ExpiresAt:       sent.ExpiresAt,
+	}
+	if err := s.emailService.SendRelationshipInvite(ctx, invitee.Credentials.Email.String(), notice); err != nil {
+		return fmt.Errorf("failed to send relationship invite email: %w", err)
+	}
+
+	s.logger.Info("Relationship invite email sent", "invite_id", sent.AggregateID, "invitee_id", sent.InviteeID)
+	return nil
+}
+
+// skipDeleted drops the email when one of the users was deleted since, retrying cannot help
+func (s *RelationshipInviteSubscriber) skipDeleted(sent *relationship.InviteSentEvent, err error) error {
+	if !errors.Is(err, user.ErrNotFound) {
+		return err
+	}
+
+	s.logger.Info("Relationship invite email skipped, user no longer exists", "invite_id", sent.AggregateID)
+	return nil
+}
@@ -0,0 +1,114 @@
+package subscribers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/application/interfaces"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/securitylog"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/user"
+)
+
+// SecurityLogSubscriber records authentication related domain events in the security log
+type SecurityLogSubscriber struct {
+	securityLogRepo securitylog.Repository
+	geoLocator      interfaces.GeoLocator
+	logger          *slog.Logger
+}
+
+func NewSecurityLogSubscriber(
+	securityLogRepo securitylog.Repository,
+	geoLocator interfaces.GeoLocator,
+	logger *slog.Logger,
+) *SecurityLogSubscriber {
+	return &SecurityLogSubscriber{
+		securityLogRepo: securityLogRepo,
+		geoLocator:      geoLocator,
+		logger:          logger,
+	}
+}
+
+// EventTypes lists the domain events the subscriber handles
+func (s *SecurityLogSubscriber) EventTypes() []string {
+	return []string{
+		"user.logged_in",
+		"user.login_failed",
+		"user.mfa_failed",
+		"user.mfa_enabled",
+		"user.mfa_disabled",
+		"user.password_changed",
+		"session.revoked",
+	}
+}
+
+func (s *SecurityLogSubscriber) Handle(ctx context.Context, event user.DomainEvent) error {
+	// Connection details default to the request that raised the event
+	info := interfaces.RequestInfoFromContext(ctx)
+	ipAddress, userAgent := info.IPAddress, info.UserAgent
+	metadata := make(map[string]string)
+
+	var eventType securitylog.EventType
+	switch e := event.(type) {
+	case *user.UserLoggedInEvent:
+		eventType = securitylog.EventLoginSucceeded
+		ipAddress, userAgent = firstNonEmpty(e.IPAddress, ipAddress), firstNonEmpty(e.UserAgent, userAgent)
+	case *user.UserLoginFailedEvent:
+		eventType = securitylog.EventLoginFailed
+		ipAddress, userAgent = firstNonEmpty(e.IPAddress, ipAddress), firstNonEmpty(e.UserAgent, userAgent)
+	case *user.UserMFAFailedEvent:
+		eventType = securitylog.EventMFAFailed
+		ipAddress, userAgent = firstNonEmpty(e.IPAddress, ipAddress), firstNonEmpty(e.UserAgent, userAgent)
+	case *user.UserMFAEnabledEvent:
+		eventType = securitylog.EventMFAEnabled
+	case *user.UserMFADisabledEvent:
+		eventType = securitylog.EventMFADisabled
+	case *user.UserPasswordChangedEvent:
+		eventType = securitylog.EventPasswordChanged
+	case *user.SessionRevokedEvent:
+		eventType = securitylog.EventSessionRevoked
+		metadata["session_id"] = e.SessionID
+		if e.Reason != "" {
+			metadata["reason"] = e.Reason
+		}
+	default:
+		return nil
+	}
+
+	entry, err := securitylog.NewEntry(event.GetAggregateID(), eventType, event.GetOccurredAt())
+	if err != nil {
+		return fmt.Errorf("failed to build security log entry: %w", err)
+	}
+	// One entry per event, a redelivered event maps onto the entry already appended
+	entry.ID = event.GetEventID()
+	entry.IPAddress = ipAddress
+	entry.UserAgent = userAgent
+	entry.RequestID = info.RequestID
+	entry.Metadata = metadata
+
+	// A failed lookup only loses the location, the entry is still recorded
+	if location, err := s.geoLocator.Locate(ipAddress); err != nil {
+		s.logger.Warn("Failed to resolve location for security log",
+			"ip_address", ipAddress,
+			"error", err.Error(),
+		)
+	} else {
+		entry.Country = location.Country
+		entry.City = location.City
+	}
+
+	if err := s.securityLogRepo.Append(ctx, entry); err != nil {
+		return fmt.Errorf("failed to append security log entry: %w", err)
+	}
+
+	return nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,42 @@
+package subscribers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/application/interfaces"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/user"
+)
+
+// WelcomeEmailSubscriber greets new users once their account is created
+type WelcomeEmailSubscriber struct {
+	emailService interfaces.EmailService
+	logger       *slog.Logger
+}
+
+func NewWelcomeEmailSubscriber(emailService interfaces.EmailService, logger *slog.Logger) *WelcomeEmailSubscriber {
+	return &WelcomeEmailSubscriber{
+		emailService: emailService,
+		logger:       logger,
+	}
+}
+
+// EventTypes lists the domain events the subscriber handles
+func (s *WelcomeEmailSubscriber) EventTypes() []string {
+	return []string{"user.created"}
+}
+
+func (s *WelcomeEmailSubscriber) Handle(ctx context.Context, event user.DomainEvent) error {
+	created, ok := event.(*user.UserCreatedEvent)
+	if !ok {
+		return nil
+	}
+
+	if err := s.emailService.SendWelcomeEmail(ctx, created.Email, created.FirstName); err != nil {
+		return fmt.Errorf("failed to send welcome email: %w", err)
+	}
+
+	s.logger.Info("Welcome email sent", "user_id", created.AggregateID)
+	return nil
+}
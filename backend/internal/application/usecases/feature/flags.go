@@ -0,0 +1,83 @@
+package feature
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	dto "github.com/StefanPenchev05/Amora/backend/internal/application/dto/feature"
+	"github.com/StefanPenchev05/Amora/backend/internal/application/interfaces"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/feature"
+)
+
+// ListFlagsCase returns every flag as the feature service sees it
+type ListFlagsCase struct {
+	flagRepo feature.FlagRepository
+	logger   *slog.Logger
+}
+
+func NewListFlagsCase(flagRepo feature.FlagRepository, logger *slog.Logger) *ListFlagsCase {
+	return &ListFlagsCase{
+		flagRepo: flagRepo,
+		logger:   logger,
+	}
+}
+
+func (uc *ListFlagsCase) Execute(ctx context.Context) (*dto.FlagsResponse, error) {
+	// The versions are compared on save, read them where saves are written
+	flags, err := uc.flagRepo.ListFlags(interfaces.WithPrimaryReads(ctx))
+	if err != nil {
+		uc.logger.Error("Failed to list feature flags",
+			"error", err.Error(),
+		)
+		return nil, fmt.Errorf("failed to list feature flags: %w", err)
+	}
+
+	response := dto.NewFlagsResponse(flags)
+	return &response, nil
+}
+
+// SaveFlagCase stores a flag, it overrides the default and file flags with the same key
+type SaveFlagCase struct {
+	flagStore feature.FlagStore
+	logger    *slog.Logger
+}
+
+func NewSaveFlagCase(flagStore feature.FlagStore, logger *slog.Logger) *SaveFlagCase {
+	return &SaveFlagCase{
+		flagStore: flagStore,
+		logger:    logger,
+	}
+}
+
+func (uc *SaveFlagCase) Execute(ctx context.Context, req dto.SaveFlagRequest) (*dto.FlagResponse, error) {
+	flag := req.ToFlag()
+	if err := flag.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", feature.ErrInvalidFlag, err)
+	}
+
+	err := uc.flagStore.SaveFlag(ctx, &flag, req.ExpectedVersion)
+	if errors.Is(err, feature.ErrConcurrentModification) {
+		uc.logger.Info("Feature flag save is stale", "key", flag.Key)
+		return nil, err
+	}
+	if err != nil {
+		uc.logger.Error("Failed to save feature flag",
+			"key", flag.Key,
+			"error", err.Error(),
+		)
+		return nil, fmt.Errorf("failed to save feature flag: %w", err)
+	}
+
+	uc.logger.Info("Feature flag saved",
+		"key", flag.Key,
+		"enabled", flag.Enabled,
+		"rollout_percentage", flag.RolloutPercentage,
+		"version", flag.Version,
+		"actor_id", req.ActorID,
+	)
+
+	response := dto.NewFlagResponse(flag)
+	return &response, nil
+}
@@ -0,0 +1,80 @@
+package relationship
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	dto "github.com/StefanPenchev05/Amora/backend/internal/application/dto/relationship"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/relationship"
+)
+
+// GetRelationshipCase returns a relationship to one of its partners
+type GetRelationshipCase struct {
+	relationshipRepo relationship.Repository
+	logger           *slog.Logger
+}
+
+func NewGetRelationshipCase(relationshipRepo relationship.Repository, logger *slog.Logger) *GetRelationshipCase {
+	return &GetRelationshipCase{
+		relationshipRepo: relationshipRepo,
+		logger:           logger,
+	}
+}
+
+func (uc *GetRelationshipCase) Execute(ctx context.Context, req dto.RelationshipActionRequest) (*dto.RelationshipResponse, error) {
+	rel, err := uc.relationshipRepo.GetByID(ctx, req.RelationshipID)
+	if errors.Is(err, relationship.ErrNotFound) {
+		return nil, err
+	}
+	if err != nil {
+		uc.logger.Error("Failed to load relationship",
+			"relationship_id", req.RelationshipID,
+			"error", err.Error(),
+		)
+		return nil, fmt.Errorf("failed to load relationship: %w", err)
+	}
+	if !rel.CanRead(req.UserID, time.Now()) {
+		return nil, relationship.ErrNotFound
+	}
+
+	response := dto.NewRelationshipResponse(rel)
+	return &response, nil
+}
+
+// ListRelationshipsCase returns the current relationship of a user and the
+// ended ones still in their retention period
+type ListRelationshipsCase struct {
+	relationshipRepo relationship.Repository
+	logger           *slog.Logger
+}
+
+func NewListRelationshipsCase(relationshipRepo relationship.Repository, logger *slog.Logger) *ListRelationshipsCase {
+	return &ListRelationshipsCase{
+		relationshipRepo: relationshipRepo,
+		logger:           logger,
+	}
+}
+
+func (uc *ListRelationshipsCase) Execute(ctx context.Context, userID string) (*dto.RelationshipsResponse, error) {
+	relationships, err := uc.relationshipRepo.ListByPartner(ctx, userID)
+	if err != nil {
+		uc.logger.Error("Failed to list relationships",
+			"user_id", userID,
+			"error", err.Error(),
+		)
+		return nil, fmt.Errorf("failed to list relationships: %w", err)
+	}
+
+	now := time.Now()
+	response := &dto.RelationshipsResponse{Relationships: make([]dto.RelationshipResponse, 0, len(relationships))}
+	for _, rel := range relationships {
+		if rel.CanRead(userID, now) {
+			response.Relationships = append(response.Relationships, dto.NewRelationshipResponse(rel))
+		}
+	}
+
+	return response, nil
+}
@@ -0,0 +1,196 @@
+package relationship
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"time"
+
+	dto "github.com/StefanPenchev05/Amora/backend/internal/application/dto/relationship"
+	"github.com/StefanPenchev05/Amora/backend/internal/application/interfaces"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/relationship"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/user"
+)
+
+// inviteURL returns the link that opens the app on the invite, or the signup
+// page with the code filled in when the app is not installed
+func inviteURL(appURL, code string) string {
+	return appURL + "/invite/" + url.PathEscape(code)
+}
+
+// CreateInviteCodeCase creates an invite shared as a code, for a partner without an account
+type CreateInviteCodeCase struct {
+	userRepo   user.Repository
+	inviteRepo relationship.InviteRepository
+	appURL     string
+	logger     *slog.Logger
+}
+
+func NewCreateInviteCodeCase(userRepo user.Repository, inviteRepo relationship.InviteRepository, appURL string, logger *slog.Logger) *CreateInviteCodeCase {
+	return &CreateInviteCodeCase{
+		userRepo:   userRepo,
+		inviteRepo: inviteRepo,
+		appURL:     appURL,
+		logger:     logger,
+	}
+}
+
+func (uc *CreateInviteCodeCase) Execute(ctx context.Context, req dto.CreateInviteCodeRequest) (*dto.InviteCodeResponse, error) {
+	inviter, err := uc.userRepo.GetByID(ctx, req.InviterID)
+	if err != nil {
+		uc.logger.Error("Failed to load inviter",
+			"user_id", req.InviterID,
+			"error", err.Error(),
+		)
+		return nil, fmt.Errorf("failed to load inviter: %w", err)
+	}
+	if inviter.InRelationship() {
+		return nil, user.ErrInRelationship
+	}
+
+	invite, code, err := relationship.NewCodeInvite(inviter.ID, req.Message)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.inviteRepo.Create(ctx, invite); err != nil {
+		uc.logger.Error("Failed to create invite code",
+			"inviter_id", inviter.ID,
+			"error", err.Error(),
+		)
+		return nil, fmt.Errorf("failed to create invite: %w", err)
+	}
+
+	uc.logger.Info("Relationship invite code created",
+		"invite_id", invite.ID,
+		"inviter_id", inviter.ID,
+	)
+
+	return &dto.InviteCodeResponse{
+		Invite: dto.NewInviteResponse(invite),
+		Code:   code,
+		URL:    inviteURL(uc.appURL, code),
+	}, nil
+}
+
+// RedeemInviteCodeCase lets a signed up user redeem an invite code
+type RedeemInviteCodeCase struct {
+	userRepo      user.Repository
+	partnerLinker *PartnerLinker
+	guard         *InviteCodeGuard
+	unitOfWork    interfaces.UnitOfWork
+	logger        *slog.Logger
+}
+
+func NewRedeemInviteCodeCase(
+	userRepo user.Repository,
+	partnerLinker *PartnerLinker,
+	guard *InviteCodeGuard,
+	unitOfWork interfaces.UnitOfWork,
+	logger *slog.Logger,
+) *RedeemInviteCodeCase {
+	return &RedeemInviteCodeCase{
+		userRepo:      userRepo,
+		partnerLinker: partnerLinker,
+		guard:         guard,
+		unitOfWork:    unitOfWork,
+		logger:        logger,
+	}
+}
+
+func (uc *RedeemInviteCodeCase) Execute(ctx context.Context, req dto.RedeemInviteCodeRequest) (*dto.RelationshipResponse, error) {
+	if err := uc.guard.Check(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+
+	var created *relationship.Relationship
+	err := uc.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		invitee, err := uc.userRepo.GetByID(ctx, req.UserID)
+		if err != nil {
+			return fmt.Errorf("failed to load invitee: %w", err)
+		}
+
+		created, err = uc.partnerLinker.RedeemCode(ctx, req.Code, invitee)
+		return err
+	})
+	if errors.Is(err, relationship.ErrInvalidCode) {
+		uc.guard.Failed(ctx, req.UserID)
+	}
+	if errors.Is(err, relationship.ErrInvalidCode) || errors.Is(err, relationship.ErrSelfInvite) ||
+		errors.Is(err, user.ErrInRelationship) || errors.Is(err, user.ErrConcurrentModification) {
+		uc.logger.Info("Invite code not redeemed",
+			"user_id", req.UserID,
+			"reason", err.Error(),
+		)
+		return nil, err
+	}
+	if err != nil {
+		uc.logger.Error("Failed to redeem invite code",
+			"user_id", req.UserID,
+			"error", err.Error(),
+		)
+		return nil, fmt.Errorf("failed to redeem invite code: %w", err)
+	}
+
+	uc.guard.Redeemed(ctx, req.UserID)
+	uc.logger.Info("Invite code redeemed",
+		"user_id", req.UserID,
+		"relationship_id", created.ID,
+	)
+
+	response := dto.NewRelationshipResponse(created)
+	return &response, nil
+}
+
+// RenderInviteCodeCase draws the invite link of a pending code as a QR code
+type RenderInviteCodeCase struct {
+	inviteRepo relationship.InviteRepository
+	renderer   interfaces.QRCodeRenderer
+	appURL     string
+	logger     *slog.Logger
+}
+
+func NewRenderInviteCodeCase(
+	inviteRepo relationship.InviteRepository,
+	renderer interfaces.QRCodeRenderer,
+	appURL string,
+	logger *slog.Logger,
+) *RenderInviteCodeCase {
+	return &RenderInviteCodeCase{
+		inviteRepo: inviteRepo,
+		renderer:   renderer,
+		appURL:     appURL,
+		logger:     logger,
+	}
+}
+
+func (uc *RenderInviteCodeCase) Execute(ctx context.Context, req dto.InviteCodeQRRequest) ([]byte, error) {
+	invite, err := uc.inviteRepo.GetByCodeHash(ctx, relationship.HashInviteCode(req.Code))
+	if errors.Is(err, relationship.ErrInviteNotFound) {
+		return nil, relationship.ErrInvalidCode
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load invite: %w", err)
+	}
+
+	// Only the inviter shows the code, and only while it can be redeemed
+	if invite.InviterID != req.UserID || invite.InviteeID != "" || !invite.IsPending(time.Now()) {
+		return nil, relationship.ErrInvalidCode
+	}
+
+	image, err := uc.renderer.Render(inviteURL(uc.appURL, relationship.FormatInviteCode(req.Code)), interfaces.QRCodeFormat(req.Format))
+	if errors.Is(err, interfaces.ErrUnsupportedQRFormat) {
+		return nil, err
+	}
+	if err != nil {
+		uc.logger.Error("Failed to render invite QR code",
+			"invite_id", invite.ID,
+			"error", err.Error(),
+		)
+		return nil, fmt.Errorf("failed to render QR code: %w", err)
+	}
+
+	return image, nil
+}
@@ -0,0 +1,95 @@
+package relationship
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/application/interfaces"
+)
+
+// InviteCodeGuardOptions holds the invite code guessing limits of a user and of
+// a client address
+type InviteCodeGuardOptions struct {
+	UserLimit interfaces.AttemptLimit
+	IPLimit   interfaces.AttemptLimit
+}
+
+// DefaultInviteCodeGuardOptions gives an address more room than a user, partners
+// and campus networks share one
+func DefaultInviteCodeGuardOptions() InviteCodeGuardOptions {
+	return InviteCodeGuardOptions{
+		UserLimit: interfaces.AttemptLimit{MaxFailures: 5, Window: 15 * time.Minute, Lockout: time.Hour},
+		IPLimit:   interfaces.AttemptLimit{MaxFailures: 20, Window: 15 * time.Minute, Lockout: time.Hour},
+	}
+}
+
+// InviteCodeGuard slows down guessing of invite codes. Invalid codes count
+// against the user and the client address, and either is locked out for a while
+// after too many of them. A limiter failure is logged and lets the attempt through.
+type InviteCodeGuard struct {
+	limiter interfaces.AttemptLimiter
+	options InviteCodeGuardOptions
+	logger  *slog.Logger
+}
+
+func NewInviteCodeGuard(limiter interfaces.AttemptLimiter, options InviteCodeGuardOptions, logger *slog.Logger) *InviteCodeGuard {
+	return &InviteCodeGuard{
+		limiter: limiter,
+		options: options,
+		logger:  logger,
+	}
+}
+
+// Check returns interfaces.ErrTooManyAttempts while the user or the address of the
+// request in ctx is locked out. Signups pass no user.
+func (g *InviteCodeGuard) Check(ctx context.Context, userID string) error {
+	for _, key := range g.keys(ctx, userID) {
+		err := g.limiter.Check(ctx, key.name)
+		if errors.Is(err, interfaces.ErrTooManyAttempts) {
+			g.logger.Warn("Invite code redemption locked out", "key", key.name)
+			return err
+		}
+		if err != nil {
+			g.logger.Error("Failed to check invite code attempts", "key", key.name, "error", err.Error())
+		}
+	}
+	return nil
+}
+
+// Failed counts an invalid code against the user and the address
+func (g *InviteCodeGuard) Failed(ctx context.Context, userID string) {
+	for _, key := range g.keys(ctx, userID) {
+		if err := g.limiter.Fail(ctx, key.name, key.limit); err != nil {
+			g.logger.Error("Failed to count invalid invite code", "key", key.name, "error", err.Error())
+		}
+	}
+}
+
+// Redeemed forgets the invalid codes of the user, the address keeps its count
+func (g *InviteCodeGuard) Redeemed(ctx context.Context, userID string) {
+	if userID == "" {
+		return
+	}
+	key := "invite_code:user:" + userID
+	if err := g.limiter.Reset(ctx, key); err != nil {
+		g.logger.Error("Failed to reset invite code attempts", "key", key, "error", err.Error())
+	}
+}
+
+type limitedKey struct {
+	name  string
+	limit interfaces.AttemptLimit
+}
+
+func (g *InviteCodeGuard) keys(ctx context.Context, userID string) []limitedKey {
+	keys := make([]limitedKey, 0, 2)
+	if userID != "" {
+		keys = append(keys, limitedKey{name: "invite_code:user:" + userID, limit: g.options.UserLimit})
+	}
+	if ip := interfaces.RequestInfoFromContext(ctx).IPAddress; ip != "" {
+		keys = append(keys, limitedKey{name: "invite_code:ip:" + ip, limit: g.options.IPLimit})
+	}
+	return keys
+}
@@ -0,0 +1,204 @@
+package relationship
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	dto "github.com/StefanPenchev05/Amora/backend/internal/application/dto/relationship"
+	"github.com/StefanPenchev05/Amora/backend/internal/application/interfaces"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/relationship"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/user"
+)
+
+// PauseRelationshipCase makes the relationship read-only until a partner resumes it
+type PauseRelationshipCase struct {
+	lifecycle
+}
+
+func NewPauseRelationshipCase(userRepo user.Repository, relationshipRepo relationship.Repository, unitOfWork interfaces.UnitOfWork, logger *slog.Logger) *PauseRelationshipCase {
+	return &PauseRelationshipCase{newLifecycle(userRepo, relationshipRepo, unitOfWork, logger)}
+}
+
+func (uc *PauseRelationshipCase) Execute(ctx context.Context, req dto.RelationshipActionRequest) (*dto.RelationshipResponse, error) {
+	return uc.change(ctx, req, "pause", (*relationship.Relationship).Pause)
+}
+
+// ResumeRelationshipCase makes a paused relationship active again
+type ResumeRelationshipCase struct {
+	lifecycle
+}
+
+func NewResumeRelationshipCase(userRepo user.Repository, relationshipRepo relationship.Repository, unitOfWork interfaces.UnitOfWork, logger *slog.Logger) *ResumeRelationshipCase {
+	return &ResumeRelationshipCase{newLifecycle(userRepo, relationshipRepo, unitOfWork, logger)}
+}
+
+func (uc *ResumeRelationshipCase) Execute(ctx context.Context, req dto.RelationshipActionRequest) (*dto.RelationshipResponse, error) {
+	return uc.change(ctx, req, "resume", (*relationship.Relationship).Resume)
+}
+
+// EndRelationshipCase asks to end the relationship, or confirms the request to end it
+type EndRelationshipCase struct {
+	lifecycle
+}
+
+func NewEndRelationshipCase(userRepo user.Repository, relationshipRepo relationship.Repository, unitOfWork interfaces.UnitOfWork, logger *slog.Logger) *EndRelationshipCase {
+	return &EndRelationshipCase{newLifecycle(userRepo, relationshipRepo, unitOfWork, logger)}
+}
+
+func (uc *EndRelationshipCase) Execute(ctx context.Context, req dto.RelationshipActionRequest) (*dto.RelationshipResponse, error) {
+	return uc.change(ctx, req, "end", (*relationship.Relationship).End)
+}
+
+// ReconnectRelationshipCase asks to reconnect an ended relationship, or confirms the request of the other partner
+type ReconnectRelationshipCase struct {
+	lifecycle
+}
+
+func NewReconnectRelationshipCase(userRepo user.Repository, relationshipRepo relationship.Repository, unitOfWork interfaces.UnitOfWork, logger *slog.Logger) *ReconnectRelationshipCase {
+	return &ReconnectRelationshipCase{newLifecycle(userRepo, relationshipRepo, unitOfWork, logger)}
+}
+
+func (uc *ReconnectRelationshipCase) Execute(ctx context.Context, req dto.RelationshipActionRequest) (*dto.RelationshipResponse, error) {
+	return uc.change(ctx, req, "reconnect", (*relationship.Relationship).Reconnect)
+}
+
+// WithdrawRequestCase drops the end or reconnect request the user made
+type WithdrawRequestCase struct {
+	lifecycle
+}
+
+func NewWithdrawRequestCase(userRepo user.Repository, relationshipRepo relationship.Repository, unitOfWork interfaces.UnitOfWork, logger *slog.Logger) *WithdrawRequestCase {
+	return &WithdrawRequestCase{newLifecycle(userRepo, relationshipRepo, unitOfWork, logger)}
+}
+
+func (uc *WithdrawRequestCase) Execute(ctx context.Context, req dto.RelationshipActionRequest, kind relationship.RequestKind) (*dto.RelationshipResponse, error) {
+	return uc.change(ctx, req, "withdraw_"+string(kind), func(rel *relationship.Relationship, userID string, now time.Time) error {
+		return rel.Withdraw(userID, kind, now)
+	})
+}
+
+// lifecycle applies status changes of a relationship and keeps the profiles of
+// both partners in step. Profiles point at a relationship until it ends, so
+// ended partners are free to start a new one.
+type lifecycle struct {
+	userRepo         user.Repository
+	relationshipRepo relationship.Repository
+	unitOfWork       interfaces.UnitOfWork
+	logger           *slog.Logger
+}
+
+func newLifecycle(userRepo user.Repository, relationshipRepo relationship.Repository, unitOfWork interfaces.UnitOfWork, logger *slog.Logger) lifecycle {
+	return lifecycle{
+		userRepo:         userRepo,
+		relationshipRepo: relationshipRepo,
+		unitOfWork:       unitOfWork,
+		logger:           logger,
+	}
+}
+
+func (l lifecycle) change(
+	ctx context.Context,
+	req dto.RelationshipActionRequest,
+	action string,
+	apply func(rel *relationship.Relationship, userID string, now time.Time) error,
+) (*dto.RelationshipResponse, error) {
+	var changed *relationship.Relationship
+
+	// The relationship and both profiles change together or not at all
+	err := l.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		rel, err := l.relationshipRepo.GetByID(ctx, req.RelationshipID)
+		if err != nil {
+			return err
+		}
+
+		// Other users and partners past the retention period cannot tell it exists
+		now := time.Now()
+		if !rel.CanRead(req.UserID, now) {
+			return relationship.ErrNotFound
+		}
+
+		wasEnded := rel.Status == relationship.StatusEnded
+		if err := apply(rel, req.UserID, now); err != nil {
+			return err
+		}
+		if err := l.relationshipRepo.Update(ctx, rel); err != nil {
+			return err
+		}
+
+		isEnded := rel.Status == relationship.StatusEnded
+		switch {
+		case isEnded && !wasEnded:
+			err = l.updatePartners(ctx, rel, func(partner *user.User) error {
+				partner.LeaveRelationship(rel.ID)
+				return nil
+			})
+		case wasEnded && !isEnded:
+			err = l.updatePartners(ctx, rel, func(partner *user.User) error {
+				return partner.JoinRelationship(rel.ID)
+			})
+		}
+
+		changed = rel
+		return err
+	})
+	if isLifecycleError(err) {
+		l.logger.Info("Relationship not changed",
+			"relationship_id", req.RelationshipID,
+			"user_id", req.UserID,
+			"action", action,
+			"reason", err.Error(),
+		)
+		return nil, err
+	}
+	if err != nil {
+		l.logger.Error("Failed to change relationship",
+			"relationship_id", req.RelationshipID,
+			"user_id", req.UserID,
+			"action", action,
+			"error", err.Error(),
+		)
+		return nil, fmt.Errorf("failed to change relationship: %w", err)
+	}
+
+	l.logger.Info("Relationship changed",
+		"relationship_id", changed.ID,
+		"user_id", req.UserID,
+		"action", action,
+		"status", string(changed.Status),
+	)
+
+	response := dto.NewRelationshipResponse(changed)
+	return &response, nil
+}
+
+// updatePartners applies the change to the profile of both partners and stores them
+func (l lifecycle) updatePartners(ctx context.Context, rel *relationship.Relationship, apply func(partner *user.User) error) error {
+	for _, partnerID := range []string{rel.PartnerAID, rel.PartnerBID} {
+		partner, err := l.userRepo.GetByID(ctx, partnerID)
+		if err != nil {
+			return fmt.Errorf("failed to load partner: %w", err)
+		}
+		if err := apply(partner); err != nil {
+			return err
+		}
+		if err := l.userRepo.Update(ctx, partner); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isLifecycleError reports whether err is an expected answer about the relationship or its partners
+func isLifecycleError(err error) bool {
+	return errors.Is(err, relationship.ErrNotFound) ||
+		errors.Is(err, relationship.ErrInvalidTransition) ||
+		errors.Is(err, relationship.ErrAwaitingConfirmation) ||
+		errors.Is(err, relationship.ErrNoPendingRequest) ||
+		errors.Is(err, relationship.ErrConcurrentModification) ||
+		errors.Is(err, user.ErrConcurrentModification) ||
+		errors.Is(err, user.ErrInRelationship)
+}
@@ -0,0 +1,49 @@
+package relationship
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	dto "github.com/StefanPenchev05/Amora/backend/internal/application/dto/relationship"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/relationship"
+)
+
+type ListInvitesCase struct {
+	inviteRepo relationship.InviteRepository
+	logger     *slog.Logger
+}
+
+func NewListInvitesCase(inviteRepo relationship.InviteRepository, logger *slog.Logger) *ListInvitesCase {
+	return &ListInvitesCase{
+		inviteRepo: inviteRepo,
+		logger:     logger,
+	}
+}
+
+// Execute returns the pending invites the user sent and received
+func (uc *ListInvitesCase) Execute(ctx context.Context, userID string) (*dto.PendingInvitesResponse, error) {
+	invites, err := uc.inviteRepo.ListPending(ctx, userID, time.Now())
+	if err != nil {
+		uc.logger.Error("Failed to list relationship invites",
+			"user_id", userID,
+			"error", err.Error(),
+		)
+		return nil, fmt.Errorf("failed to list invites: %w", err)
+	}
+
+	response := &dto.PendingInvitesResponse{
+		Sent:     make([]dto.InviteResponse, 0),
+		Received: make([]dto.InviteResponse, 0),
+	}
+	for _, invite := range invites {
+		if invite.InviterID == userID {
+			response.Sent = append(response.Sent, dto.NewInviteResponse(invite))
+		} else {
+			response.Received = append(response.Received, dto.NewInviteResponse(invite))
+		}
+	}
+
+	return response, nil
+}
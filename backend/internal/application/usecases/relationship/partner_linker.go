@@ -0,0 +1,105 @@
+package relationship
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/relationship"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/user"
+)
+
+// PartnerLinker accepts invites, starts their relationship and links both
+// partners to it. Call it inside a unit of work so every change commits together.
+type PartnerLinker struct {
+	userRepo         user.Repository
+	inviteRepo       relationship.InviteRepository
+	relationshipRepo relationship.Repository
+}
+
+func NewPartnerLinker(
+	userRepo user.Repository,
+	inviteRepo relationship.InviteRepository,
+	relationshipRepo relationship.Repository,
+) *PartnerLinker {
+	return &PartnerLinker{
+		userRepo:         userRepo,
+		inviteRepo:       inviteRepo,
+		relationshipRepo: relationshipRepo,
+	}
+}
+
+// Accept answers the invite sent to the invitee
+func (l *PartnerLinker) Accept(ctx context.Context, invite *relationship.Invite, invitee *user.User) (*relationship.Relationship, error) {
+	rel, err := invite.Accept(invitee.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return rel, l.link(ctx, invite, rel, invitee)
+}
+
+// CheckCode returns relationship.ErrInvalidCode unless the code can be redeemed now
+func (l *PartnerLinker) CheckCode(ctx context.Context, code string) error {
+	invite, err := l.inviteRepo.GetByCodeHash(ctx, relationship.HashInviteCode(code))
+	if errors.Is(err, relationship.ErrInviteNotFound) {
+		return relationship.ErrInvalidCode
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load invite: %w", err)
+	}
+	if !invite.IsRedeemable(time.Now()) {
+		return relationship.ErrInvalidCode
+	}
+	return nil
+}
+
+// RedeemCode makes the user the invitee of the code's invite and accepts it
+func (l *PartnerLinker) RedeemCode(ctx context.Context, code string, invitee *user.User) (*relationship.Relationship, error) {
+	invite, err := l.inviteRepo.GetByCodeHash(ctx, relationship.HashInviteCode(code))
+	if errors.Is(err, relationship.ErrInviteNotFound) {
+		return nil, relationship.ErrInvalidCode
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load invite: %w", err)
+	}
+
+	rel, err := invite.Redeem(invitee.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// A code redeemed or cancelled meanwhile is as invalid as a used one
+	err = l.link(ctx, invite, rel, invitee)
+	if errors.Is(err, relationship.ErrInviteNotPending) {
+		return nil, relationship.ErrInvalidCode
+	}
+	return rel, err
+}
+
+func (l *PartnerLinker) link(ctx context.Context, invite *relationship.Invite, rel *relationship.Relationship, invitee *user.User) error {
+	inviter, err := l.userRepo.GetByID(ctx, invite.InviterID)
+	if err != nil {
+		return fmt.Errorf("failed to load inviter: %w", err)
+	}
+
+	// Either may have found a partner since the invite was sent
+	if err := inviter.JoinRelationship(rel.ID); err != nil {
+		return err
+	}
+	if err := invitee.JoinRelationship(rel.ID); err != nil {
+		return err
+	}
+
+	if err := l.relationshipRepo.Create(ctx, rel); err != nil {
+		return err
+	}
+	if err := l.inviteRepo.Update(ctx, invite); err != nil {
+		return err
+	}
+	if err := l.userRepo.Update(ctx, inviter); err != nil {
+		return err
+	}
+	return l.userRepo.Update(ctx, invitee)
+}
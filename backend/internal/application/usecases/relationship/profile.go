@@ -0,0 +1,239 @@
+package relationship
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	dto "github.com/StefanPenchev05/Amora/backend/internal/application/dto/relationship"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/relationship"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/user"
+)
+
+// slugAttempts is how many numbered variants of a generated slug are tried
+const slugAttempts = 10
+
+// GetCurrentRelationshipCase returns the relationship the profile of the user links to
+type GetCurrentRelationshipCase struct {
+	userRepo         user.Repository
+	relationshipRepo relationship.Repository
+	logger           *slog.Logger
+}
+
+func NewGetCurrentRelationshipCase(userRepo user.Repository, relationshipRepo relationship.Repository, logger *slog.Logger) *GetCurrentRelationshipCase {
+	return &GetCurrentRelationshipCase{
+		userRepo:         userRepo,
+		relationshipRepo: relationshipRepo,
+		logger:           logger,
+	}
+}
+
+func (uc *GetCurrentRelationshipCase) Execute(ctx context.Context, userID string) (*dto.RelationshipResponse, error) {
+	rel, err := loadCurrent(ctx, uc.userRepo, uc.relationshipRepo, userID)
+	if errors.Is(err, relationship.ErrNotFound) {
+		return nil, err
+	}
+	if err != nil {
+		uc.logger.Error("Failed to load current relationship",
+			"user_id", userID,
+			"error", err.Error(),
+		)
+		return nil, fmt.Errorf("failed to load relationship: %w", err)
+	}
+
+	response := dto.NewRelationshipResponse(rel)
+	return &response, nil
+}
+
+// UpdateProfileCase lets either partner edit the title, slug, anniversary and
+// pictures of their current relationship
+type UpdateProfileCase struct {
+	userRepo         user.Repository
+	relationshipRepo relationship.Repository
+	logger           *slog.Logger
+}
+
+func NewUpdateProfileCase(userRepo user.Repository, relationshipRepo relationship.Repository, logger *slog.Logger) *UpdateProfileCase {
+	return &UpdateProfileCase{
+		userRepo:         userRepo,
+		relationshipRepo: relationshipRepo,
+		logger:           logger,
+	}
+}
+
+func (uc *UpdateProfileCase) Execute(ctx context.Context, req dto.UpdateProfileRequest) (*dto.RelationshipResponse, error) {
+	rel, err := loadCurrent(ctx, uc.userRepo, uc.relationshipRepo, req.UserID)
+	if errors.Is(err, relationship.ErrNotFound) {
+		return nil, err
+	}
+	if err != nil {
+		uc.logger.Error("Failed to load current relationship",
+			"user_id", req.UserID,
+			"error", err.Error(),
+		)
+		return nil, fmt.Errorf("failed to load relationship: %w", err)
+	}
+
+	if req.ExpectedVersion != nil && *req.ExpectedVersion != rel.Version {
+		uc.logger.Info("Relationship profile update is stale",
+			"relationship_id", rel.ID,
+			"expected_version", *req.ExpectedVersion,
+			"version", rel.Version,
+		)
+		return nil, relationship.ErrConcurrentModification
+	}
+
+	patch := req.ToPatch()
+	if err := uc.resolveSlug(ctx, rel, &patch); err != nil {
+		return nil, err
+	}
+
+	if err := rel.UpdateProfile(req.UserID, patch, time.Now()); err != nil {
+		uc.logger.Warn("Relationship profile validation failed",
+			"relationship_id", rel.ID,
+			"user_id", req.UserID,
+			"error", err.Error(),
+		)
+		return nil, err
+	}
+
+	err = uc.relationshipRepo.Update(ctx, rel)
+	if errors.Is(err, relationship.ErrConcurrentModification) || errors.Is(err, relationship.ErrSlugTaken) {
+		uc.logger.Info("Relationship profile not updated",
+			"relationship_id", rel.ID,
+			"reason", err.Error(),
+		)
+		return nil, err
+	}
+	if err != nil {
+		uc.logger.Error("Failed to update relationship profile",
+			"relationship_id", rel.ID,
+			"error", err.Error(),
+		)
+		return nil, fmt.Errorf("failed to update relationship: %w", err)
+	}
+
+	uc.logger.Info("Relationship profile updated",
+		"relationship_id", rel.ID,
+		"user_id", req.UserID,
+		"version", rel.Version,
+	)
+
+	response := dto.NewRelationshipResponse(rel)
+	return &response, nil
+}
+
+// resolveSlug checks that a chosen slug is free, and generates one from the
+// title when the relationship has none yet
+func (uc *UpdateProfileCase) resolveSlug(ctx context.Context, rel *relationship.Relationship, patch *relationship.ProfilePatch) error {
+	if patch.Slug == nil {
+		if patch.Title == nil || rel.Slug != "" {
+			return nil
+		}
+
+		slug, err := uc.generateSlug(ctx, rel.ID, *patch.Title)
+		if err != nil || slug == "" {
+			return err
+		}
+		patch.Slug = &slug
+		return nil
+	}
+
+	// Invalid slugs are rejected by the profile itself
+	slug := relationship.NormalizeSlug(*patch.Slug)
+	if slug == "" || slug == rel.Slug || relationship.ValidateSlug(slug) != nil {
+		return nil
+	}
+
+	taken, err := uc.relationshipRepo.TakenSlugs(ctx, rel.ID, []string{slug})
+	if err != nil {
+		uc.logger.Error("Failed to check slug",
+			"relationship_id", rel.ID,
+			"error", err.Error(),
+		)
+		return fmt.Errorf("failed to check slug: %w", err)
+	}
+	if len(taken) > 0 {
+		return relationship.ErrSlugTaken
+	}
+
+	return nil
+}
+
+// generateSlug returns the first free variant of the slug of the title, or ""
+// when the title makes no usable slug
+func (uc *UpdateProfileCase) generateSlug(ctx context.Context, relationshipID, title string) (string, error) {
+	base := relationship.SlugFromTitle(title)
+	if base == "" {
+		return "", nil
+	}
+
+	candidates := relationship.SlugCandidates(base, slugAttempts)
+	taken, err := uc.relationshipRepo.TakenSlugs(ctx, relationshipID, candidates)
+	if err != nil {
+		uc.logger.Error("Failed to check slugs",
+			"relationship_id", relationshipID,
+			"error", err.Error(),
+		)
+		return "", fmt.Errorf("failed to check slugs: %w", err)
+	}
+
+	for _, candidate := range candidates {
+		if !slices.Contains(taken, candidate) && !relationship.IsReservedSlug(candidate) {
+			return candidate, nil
+		}
+	}
+
+	// Every variant is taken, the partners can still choose a slug themselves
+	return "", nil
+}
+
+// GetRelationshipBySlugCase finds the relationship of a slug, current or replaced.
+// Callers compare the slug of the response to redirect replaced ones.
+type GetRelationshipBySlugCase struct {
+	relationshipRepo relationship.Repository
+	logger           *slog.Logger
+}
+
+func NewGetRelationshipBySlugCase(relationshipRepo relationship.Repository, logger *slog.Logger) *GetRelationshipBySlugCase {
+	return &GetRelationshipBySlugCase{
+		relationshipRepo: relationshipRepo,
+		logger:           logger,
+	}
+}
+
+func (uc *GetRelationshipBySlugCase) Execute(ctx context.Context, req dto.RelationshipBySlugRequest) (*dto.RelationshipResponse, error) {
+	rel, err := uc.relationshipRepo.GetBySlug(ctx, relationship.NormalizeSlug(req.Slug))
+	if errors.Is(err, relationship.ErrNotFound) {
+		return nil, err
+	}
+	if err != nil {
+		uc.logger.Error("Failed to load relationship by slug",
+			"slug", req.Slug,
+			"error", err.Error(),
+		)
+		return nil, fmt.Errorf("failed to load relationship: %w", err)
+	}
+	if !rel.CanRead(req.UserID, time.Now()) {
+		return nil, relationship.ErrNotFound
+	}
+
+	response := dto.NewRelationshipResponse(rel)
+	return &response, nil
+}
+
+// loadCurrent returns the relationship the profile of the user links to
+func loadCurrent(ctx context.Context, userRepo user.Repository, relationshipRepo relationship.Repository, userID string) (*relationship.Relationship, error) {
+	u, err := userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load user: %w", err)
+	}
+	if !u.InRelationship() {
+		return nil, relationship.ErrNotFound
+	}
+
+	return relationshipRepo.GetByID(ctx, *u.Profile.RelationshipID)
+}
@@ -0,0 +1,166 @@
+package relationship
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	dto "github.com/StefanPenchev05/Amora/backend/internal/application/dto/relationship"
+	"github.com/StefanPenchev05/Amora/backend/internal/application/interfaces"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/relationship"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/user"
+)
+
+// AcceptInviteCase starts the relationship of an invite and links both partners to it
+type AcceptInviteCase struct {
+	userRepo      user.Repository
+	inviteRepo    relationship.InviteRepository
+	partnerLinker *PartnerLinker
+	unitOfWork    interfaces.UnitOfWork
+	logger        *slog.Logger
+}
+
+func NewAcceptInviteCase(
+	userRepo user.Repository,
+	inviteRepo relationship.InviteRepository,
+	partnerLinker *PartnerLinker,
+	unitOfWork interfaces.UnitOfWork,
+	logger *slog.Logger,
+) *AcceptInviteCase {
+	return &AcceptInviteCase{
+		userRepo:      userRepo,
+		inviteRepo:    inviteRepo,
+		partnerLinker: partnerLinker,
+		unitOfWork:    unitOfWork,
+		logger:        logger,
+	}
+}
+
+func (uc *AcceptInviteCase) Execute(ctx context.Context, req dto.InviteActionRequest) (*dto.RelationshipResponse, error) {
+	var created *relationship.Relationship
+
+	// The invite, the relationship and both profiles change together or not at all
+	err := uc.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		invite, err := uc.inviteRepo.GetByID(ctx, req.InviteID)
+		if err != nil {
+			return err
+		}
+
+		invitee, err := uc.userRepo.GetByID(ctx, req.UserID)
+		if err != nil {
+			return fmt.Errorf("failed to load invitee: %w", err)
+		}
+
+		created, err = uc.partnerLinker.Accept(ctx, invite, invitee)
+		return err
+	})
+	if isInviteError(err) || errors.Is(err, user.ErrInRelationship) || errors.Is(err, user.ErrConcurrentModification) {
+		uc.logger.Info("Relationship invite not accepted",
+			"invite_id", req.InviteID,
+			"user_id", req.UserID,
+			"reason", err.Error(),
+		)
+		return nil, err
+	}
+	if err != nil {
+		uc.logger.Error("Failed to accept relationship invite",
+			"invite_id", req.InviteID,
+			"user_id", req.UserID,
+			"error", err.Error(),
+		)
+		return nil, fmt.Errorf("failed to accept invite: %w", err)
+	}
+
+	uc.logger.Info("Relationship invite accepted",
+		"invite_id", req.InviteID,
+		"relationship_id", created.ID,
+	)
+
+	response := dto.NewRelationshipResponse(created)
+	return &response, nil
+}
+
+// DeclineInviteCase answers an invite with no for the invitee
+type DeclineInviteCase struct {
+	inviteRepo relationship.InviteRepository
+	logger     *slog.Logger
+}
+
+func NewDeclineInviteCase(inviteRepo relationship.InviteRepository, logger *slog.Logger) *DeclineInviteCase {
+	return &DeclineInviteCase{
+		inviteRepo: inviteRepo,
+		logger:     logger,
+	}
+}
+
+func (uc *DeclineInviteCase) Execute(ctx context.Context, req dto.InviteActionRequest) (*dto.InviteResponse, error) {
+	return updateInvite(ctx, uc.inviteRepo, uc.logger, req, "declined", (*relationship.Invite).Decline)
+}
+
+// CancelInviteCase withdraws an invite for the inviter
+type CancelInviteCase struct {
+	inviteRepo relationship.InviteRepository
+	logger     *slog.Logger
+}
+
+func NewCancelInviteCase(inviteRepo relationship.InviteRepository, logger *slog.Logger) *CancelInviteCase {
+	return &CancelInviteCase{
+		inviteRepo: inviteRepo,
+		logger:     logger,
+	}
+}
+
+func (uc *CancelInviteCase) Execute(ctx context.Context, req dto.InviteActionRequest) (*dto.InviteResponse, error) {
+	return updateInvite(ctx, uc.inviteRepo, uc.logger, req, "cancelled", (*relationship.Invite).Cancel)
+}
+
+// updateInvite loads the invite, applies the answer of the user and stores it
+func updateInvite(
+	ctx context.Context,
+	inviteRepo relationship.InviteRepository,
+	logger *slog.Logger,
+	req dto.InviteActionRequest,
+	action string,
+	apply func(invite *relationship.Invite, userID string) error,
+) (*dto.InviteResponse, error) {
+	invite, err := inviteRepo.GetByID(ctx, req.InviteID)
+	if err == nil {
+		err = apply(invite, req.UserID)
+	}
+	if isInviteError(err) {
+		return nil, err
+	}
+	if err != nil {
+		logger.Error("Failed to load relationship invite",
+			"invite_id", req.InviteID,
+			"error", err.Error(),
+		)
+		return nil, fmt.Errorf("failed to load invite: %w", err)
+	}
+
+	// Another answer may have been stored since the invite was loaded
+	err = inviteRepo.Update(ctx, invite)
+	if isInviteError(err) {
+		return nil, err
+	}
+	if err != nil {
+		logger.Error("Failed to update relationship invite",
+			"invite_id", req.InviteID,
+			"error", err.Error(),
+		)
+		return nil, fmt.Errorf("failed to update invite: %w", err)
+	}
+
+	logger.Info("Relationship invite "+action, "invite_id", invite.ID, "user_id", req.UserID)
+
+	response := dto.NewInviteResponse(invite)
+	return &response, nil
+}
+
+// isInviteError reports whether err is an expected answer about the invite itself
+func isInviteError(err error) bool {
+	return errors.Is(err, relationship.ErrInviteNotFound) ||
+		errors.Is(err, relationship.ErrInviteNotPending) ||
+		errors.Is(err, relationship.ErrInviteExpired)
+}
@@ -0,0 +1,97 @@
+package relationship
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/relationship"
+)
+
+// RetentionOptions tunes how often and how much the retention sweep deletes
+type RetentionOptions struct {
+	Interval  time.Duration
+	BatchSize int
+}
+
+func DefaultRetentionOptions() RetentionOptions {
+	return RetentionOptions{
+		Interval:  time.Hour,
+		BatchSize: 100,
+	}
+}
+
+// ExpireRelationshipsCase soft-deletes ended relationships once their retention
+// period is over, so they leave every listing and cannot be reconnected
+type ExpireRelationshipsCase struct {
+	relationshipRepo relationship.Repository
+	options          RetentionOptions
+	logger           *slog.Logger
+}
+
+func NewExpireRelationshipsCase(relationshipRepo relationship.Repository, options RetentionOptions, logger *slog.Logger) *ExpireRelationshipsCase {
+	return &ExpireRelationshipsCase{
+		relationshipRepo: relationshipRepo,
+		options:          options,
+		logger:           logger,
+	}
+}
+
+// Run sweeps until the context is cancelled
+func (uc *ExpireRelationshipsCase) Run(ctx context.Context) {
+	ticker := time.NewTicker(uc.options.Interval)
+	defer ticker.Stop()
+
+	for {
+		// Keep sweeping while full batches come back
+		for ctx.Err() == nil {
+			expired, err := uc.Execute(ctx, time.Now())
+			if err != nil || expired < uc.options.BatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Execute deletes one batch of relationships past their retention period at now
+// and returns how many it deleted
+func (uc *ExpireRelationshipsCase) Execute(ctx context.Context, now time.Time) (int, error) {
+	due, err := uc.relationshipRepo.ListExpired(ctx, now.Add(-relationship.RetentionPeriod), uc.options.BatchSize)
+	if err != nil {
+		uc.logger.Error("Failed to list expired relationships", "error", err.Error())
+		return 0, fmt.Errorf("failed to list expired relationships: %w", err)
+	}
+
+	expired := 0
+	for _, rel := range due {
+		if err := rel.Expire(now); err != nil {
+			continue
+		}
+
+		// A partner changing it at the same time is picked up by the next sweep
+		err := uc.relationshipRepo.Update(ctx, rel)
+		if errors.Is(err, relationship.ErrConcurrentModification) {
+			continue
+		}
+		if err != nil {
+			uc.logger.Error("Failed to expire relationship",
+				"relationship_id", rel.ID,
+				"error", err.Error(),
+			)
+			return expired, fmt.Errorf("failed to expire relationship: %w", err)
+		}
+
+		expired++
+		uc.logger.Info("Relationship expired", "relationship_id", rel.ID)
+	}
+
+	return expired, nil
+}
@@ -0,0 +1,135 @@
+package relationship
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	dto "github.com/StefanPenchev05/Amora/backend/internal/application/dto/relationship"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/relationship"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/user"
+)
+
+type SendInviteCase struct {
+	userRepo   user.Repository
+	inviteRepo relationship.InviteRepository
+	logger     *slog.Logger
+}
+
+func NewSendInviteCase(userRepo user.Repository, inviteRepo relationship.InviteRepository, logger *slog.Logger) *SendInviteCase {
+	return &SendInviteCase{
+		userRepo:   userRepo,
+		inviteRepo: inviteRepo,
+		logger:     logger,
+	}
+}
+
+func (uc *SendInviteCase) Execute(ctx context.Context, req dto.SendInviteRequest) (*dto.SendInviteResponse, error) {
+	if (req.Username == "") == (req.Email == "") {
+		return nil, fmt.Errorf("%w: give either a username or an email", relationship.ErrInvalidInvite)
+	}
+
+	inviter, err := uc.userRepo.GetByID(ctx, req.InviterID)
+	if err != nil {
+		uc.logger.Error("Failed to load inviter",
+			"user_id", req.InviterID,
+			"error", err.Error(),
+		)
+		return nil, fmt.Errorf("failed to load inviter: %w", err)
+	}
+	if inviter.InRelationship() {
+		return nil, user.ErrInRelationship
+	}
+
+	if req.Username != "" {
+		invite, err := uc.sendToUsername(ctx, inviter, req)
+		if err != nil {
+			return nil, err
+		}
+		return dto.NewInviteSentResponse(invite), nil
+	}
+
+	// Emails are private, whatever happens to the invitee the answer is the same
+	err = uc.sendToEmail(ctx, inviter, req)
+	switch {
+	case errors.Is(err, user.ErrNotFound), errors.Is(err, user.ErrInRelationship), errors.Is(err, relationship.ErrInvitePending):
+		uc.logger.Info("Relationship invite by email not sent",
+			"inviter_id", inviter.ID,
+			"reason", err.Error(),
+		)
+	case err != nil:
+		return nil, err
+	}
+
+	return dto.NewInviteAcceptedResponse(), nil
+}
+
+func (uc *SendInviteCase) sendToUsername(ctx context.Context, inviter *user.User, req dto.SendInviteRequest) (*relationship.Invite, error) {
+	username, err := user.NewUsername(req.Username)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", relationship.ErrInvalidInvite, err)
+	}
+
+	invitee, err := uc.userRepo.GetByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.send(ctx, inviter, invitee, req.Message)
+}
+
+func (uc *SendInviteCase) sendToEmail(ctx context.Context, inviter *user.User, req dto.SendInviteRequest) error {
+	email, err := user.NewEmail(req.Email)
+	if err != nil {
+		return fmt.Errorf("%w: %v", relationship.ErrInvalidInvite, err)
+	}
+
+	invitee, err := uc.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	_, err = uc.send(ctx, inviter, invitee, req.Message)
+	return err
+}
+
+func (uc *SendInviteCase) send(ctx context.Context, inviter, invitee *user.User, message string) (*relationship.Invite, error) {
+	// Only users without a partner can be invited
+	if invitee.InRelationship() {
+		return nil, user.ErrInRelationship
+	}
+
+	invite, err := relationship.NewInvite(inviter.ID, invitee.ID, message)
+	if err != nil {
+		return nil, err
+	}
+
+	pending, err := uc.inviteRepo.HasPendingBetween(ctx, inviter.ID, invitee.ID, time.Now())
+	if err != nil {
+		uc.logger.Error("Failed to check pending invites",
+			"inviter_id", inviter.ID,
+			"error", err.Error(),
+		)
+		return nil, fmt.Errorf("failed to check pending invites: %w", err)
+	}
+	if pending {
+		return nil, relationship.ErrInvitePending
+	}
+
+	if err := uc.inviteRepo.Create(ctx, invite); err != nil {
+		uc.logger.Error("Failed to create invite",
+			"inviter_id", inviter.ID,
+			"error", err.Error(),
+		)
+		return nil, fmt.Errorf("failed to create invite: %w", err)
+	}
+
+	uc.logger.Info("Relationship invite sent",
+		"invite_id", invite.ID,
+		"inviter_id", inviter.ID,
+	)
+
+	return invite, nil
+}
@@ -0,0 +1,58 @@
+package securitylog
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	dto "github.com/StefanPenchev05/Amora/backend/internal/application/dto/securitylog"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/securitylog"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/user"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 200
+)
+
+type ListSecurityLogCase struct {
+	securityLogRepo securitylog.Repository
+	logger          *slog.Logger
+}
+
+func NewListSecurityLogCase(securityLogRepo securitylog.Repository, logger *slog.Logger) *ListSecurityLogCase {
+	return &ListSecurityLogCase{
+		securityLogRepo: securityLogRepo,
+		logger:          logger,
+	}
+}
+
+func (uc *ListSecurityLogCase) Execute(ctx context.Context, req dto.ListSecurityLogRequest) (*dto.ListSecurityLogResponse, error) {
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultPageSize
+	}
+	if limit > maxPageSize {
+		limit = maxPageSize
+	}
+
+	var before *securitylog.Cursor
+	if req.Before != "" {
+		parsed, err := securitylog.ParseCursor(req.Before)
+		if err != nil {
+			return nil, fmt.Errorf("%w: before must be the next_before of a previous page", user.ErrValidation)
+		}
+		before = parsed
+	}
+
+	entries, err := uc.securityLogRepo.ListByUserID(ctx, req.UserID, before, limit)
+	if err != nil {
+		uc.logger.Error("Failed to list security log",
+			"user_id", req.UserID,
+			"error", err.Error(),
+		)
+		return nil, fmt.Errorf("failed to list security log: %w", err)
+	}
+
+	return dto.NewListSecurityLogResponse(entries, limit), nil
+}
@@ -0,0 +1,108 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	dto "github.com/StefanPenchev05/Amora/backend/internal/application/dto/user"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/session"
+)
+
+type ListDevicesCase struct {
+	deviceRepo session.DeviceRepository
+	logger     *slog.Logger
+}
+
+func NewListDevicesCase(deviceRepo session.DeviceRepository, logger *slog.Logger) *ListDevicesCase {
+	return &ListDevicesCase{
+		deviceRepo: deviceRepo,
+		logger:     logger,
+	}
+}
+
+func (uc *ListDevicesCase) Execute(ctx context.Context, userID string) ([]dto.DeviceResponse, error) {
+	devices, err := uc.deviceRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		uc.logger.Error("Failed to list devices",
+			"user_id", userID,
+			"error", err.Error(),
+		)
+		return nil, fmt.Errorf("failed to list devices: %w", err)
+	}
+
+	response := make([]dto.DeviceResponse, 0, len(devices))
+	for _, device := range devices {
+		response = append(response, dto.NewDeviceResponse(device))
+	}
+
+	return response, nil
+}
+
+// SetDeviceTrustCase marks one of the user's devices as trusted or untrusted.
+// Any device can lose its trust, only the caller's own device can gain it.
+type SetDeviceTrustCase struct {
+	sessionRepo      session.Repository
+	deviceRepo       session.DeviceRepository
+	trustedDeviceTTL time.Duration
+	logger           *slog.Logger
+}
+
+func NewSetDeviceTrustCase(sessionRepo session.Repository, deviceRepo session.DeviceRepository, trustedDeviceTTL time.Duration, logger *slog.Logger) *SetDeviceTrustCase {
+	return &SetDeviceTrustCase{
+		sessionRepo:      sessionRepo,
+		deviceRepo:       deviceRepo,
+		trustedDeviceTTL: trustedDeviceTTL,
+		logger:           logger,
+	}
+}
+
+func (uc *SetDeviceTrustCase) Execute(ctx context.Context, req dto.DeviceTrustRequest, trusted bool) (*dto.DeviceResponse, error) {
+	device, err := uc.deviceRepo.GetByID(ctx, req.DeviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Other users' devices are reported as missing
+	if device.UserID != req.UserID {
+		return nil, session.ErrDeviceNotFound
+	}
+
+	var deviceToken string
+	if trusted {
+		current, err := uc.sessionRepo.GetByID(ctx, req.SessionID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load session: %w", err)
+		}
+		if current.DeviceID != device.ID {
+			return nil, session.ErrNotCurrentDevice
+		}
+
+		deviceToken, err = device.Trust(uc.trustedDeviceTTL)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		device.Untrust()
+	}
+
+	if err := uc.deviceRepo.Update(ctx, device); err != nil {
+		uc.logger.Error("Failed to update device trust",
+			"user_id", req.UserID,
+			"device_id", req.DeviceID,
+			"error", err.Error(),
+		)
+		return nil, fmt.Errorf("failed to update device: %w", err)
+	}
+
+	uc.logger.Info("Device trust updated",
+		"user_id", req.UserID,
+		"device_id", req.DeviceID,
+		"trusted", trusted,
+	)
+
+	response := dto.NewDeviceResponse(device)
+	response.DeviceToken = deviceToken
+	return &response, nil
+}
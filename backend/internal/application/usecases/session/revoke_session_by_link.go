@@ -0,0 +1,72 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	dto "github.com/StefanPenchev05/Amora/backend/internal/application/dto/user"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/session"
+)
+
+// RevokeSessionByLinkCase handles the "this wasn't me" link from a new device alert.
+// It revokes the session and withdraws any trust given to its device.
+type RevokeSessionByLinkCase struct {
+	sessionRepo session.Repository
+	deviceRepo  session.DeviceRepository
+	logger      *slog.Logger
+}
+
+func NewRevokeSessionByLinkCase(
+	sessionRepo session.Repository,
+	deviceRepo session.DeviceRepository,
+	logger *slog.Logger,
+) *RevokeSessionByLinkCase {
+	return &RevokeSessionByLinkCase{
+		sessionRepo: sessionRepo,
+		deviceRepo:  deviceRepo,
+		logger:      logger,
+	}
+}
+
+func (uc *RevokeSessionByLinkCase) Execute(ctx context.Context, req dto.RevokeSessionRequest) error {
+	if req.Token == "" {
+		return session.ErrNotFound
+	}
+
+	found, err := uc.sessionRepo.GetByRevokeTokenHash(ctx, session.HashToken(req.Token))
+	if err != nil {
+		return err
+	}
+
+	// Reporting twice is harmless
+	if err := found.Revoke("reported_by_user"); errors.Is(err, session.ErrAlreadyRevoked) {
+		return nil
+	}
+
+	// The repository stores the revoked event in the outbox with the session
+	if err := uc.sessionRepo.Update(ctx, found); err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+	found.ClearEvents()
+
+	if found.DeviceID != "" {
+		if device, err := uc.deviceRepo.GetByID(ctx, found.DeviceID); err == nil {
+			device.Untrust()
+			if err := uc.deviceRepo.Update(ctx, device); err != nil {
+				uc.logger.Error("Failed to untrust reported device",
+					"device_id", device.ID,
+					"error", err.Error(),
+				)
+			}
+		}
+	}
+
+	uc.logger.Warn("Session revoked from new device alert",
+		"user_id", found.UserID,
+		"session_id", found.ID,
+	)
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package settings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	dto "github.com/StefanPenchev05/Amora/backend/internal/application/dto/settings"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/settings"
+)
+
+type GetSettingsCase struct {
+	settingsRepo settings.Repository
+	logger       *slog.Logger
+}
+
+func NewGetSettingsCase(settingsRepo settings.Repository, logger *slog.Logger) *GetSettingsCase {
+	return &GetSettingsCase{
+		settingsRepo: settingsRepo,
+		logger:       logger,
+	}
+}
+
+func (uc *GetSettingsCase) Execute(ctx context.Context, userID string) (*dto.SettingsResponse, error) {
+	userSettings, err := LoadOrDefault(ctx, uc.settingsRepo, userID)
+	if err != nil {
+		uc.logger.Error("Failed to load user settings",
+			"user_id", userID,
+			"error", err.Error(),
+		)
+		return nil, fmt.Errorf("failed to load settings: %w", err)
+	}
+
+	return dto.NewSettingsResponse(userSettings), nil
+}
+
+// LoadOrDefault returns the stored settings upgraded to the current schema, or the server defaults
+func LoadOrDefault(ctx context.Context, settingsRepo settings.Repository, userID string) (*settings.UserSettings, error) {
+	userSettings, err := settingsRepo.GetByUserID(ctx, userID)
+	if errors.Is(err, settings.ErrNotFound) {
+		return settings.Default(userID), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := userSettings.Upgrade(); err != nil {
+		return nil, err
+	}
+
+	return userSettings, nil
+}
@@ -0,0 +1,71 @@
+package settings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	dto "github.com/StefanPenchev05/Amora/backend/internal/application/dto/settings"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/settings"
+)
+
+type UpdateSettingsCase struct {
+	settingsRepo settings.Repository
+	logger       *slog.Logger
+}
+
+func NewUpdateSettingsCase(settingsRepo settings.Repository, logger *slog.Logger) *UpdateSettingsCase {
+	return &UpdateSettingsCase{
+		settingsRepo: settingsRepo,
+		logger:       logger,
+	}
+}
+
+func (uc *UpdateSettingsCase) Execute(ctx context.Context, req dto.UpdateSettingsRequest) (*dto.SettingsResponse, error) {
+	userSettings, err := LoadOrDefault(ctx, uc.settingsRepo, req.UserID)
+	if err != nil {
+		uc.logger.Error("Failed to load user settings",
+			"user_id", req.UserID,
+			"error", err.Error(),
+		)
+		return nil, fmt.Errorf("failed to load settings: %w", err)
+	}
+
+	if req.ExpectedRevision != nil && *req.ExpectedRevision != userSettings.Revision {
+		uc.logger.Info("User settings update is stale",
+			"user_id", req.UserID,
+			"expected_revision", *req.ExpectedRevision,
+			"revision", userSettings.Revision,
+		)
+		return nil, settings.ErrConcurrentModification
+	}
+
+	if err := userSettings.Apply(req.ToPatch()); err != nil {
+		uc.logger.Warn("User settings validation failed",
+			"user_id", req.UserID,
+			"error", err.Error(),
+		)
+		return nil, err
+	}
+
+	err = uc.settingsRepo.Save(ctx, userSettings)
+	if errors.Is(err, settings.ErrConcurrentModification) {
+		uc.logger.Info("User settings changed while updating", "user_id", req.UserID)
+		return nil, err
+	}
+	if err != nil {
+		uc.logger.Error("Failed to save user settings",
+			"user_id", req.UserID,
+			"error", err.Error(),
+		)
+		return nil, fmt.Errorf("failed to save settings: %w", err)
+	}
+
+	uc.logger.Info("User settings updated",
+		"user_id", req.UserID,
+		"revision", userSettings.Revision,
+	)
+
+	return dto.NewSettingsResponse(userSettings), nil
+}
@@ -5,45 +5,63 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"time"
 
 	dto "github.com/StefanPenchev05/Amora/backend/internal/application/dto/user"
 	"github.com/StefanPenchev05/Amora/backend/internal/application/interfaces"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/session"
 	"github.com/StefanPenchev05/Amora/backend/internal/domain/user"
 )
 
+// failedLoginRecordTimeout bounds recording a wrong password after the response was sent
+const failedLoginRecordTimeout = 30 * time.Second
+
 type AuthenticateUserCase struct {
-	userRepo       user.Repository
-	userService    *user.UserService
-	jwtService     interfaces.JWTService
-	eventPublisher interfaces.EventPublisher
-	logger         *slog.Logger
+	userRepo         user.Repository
+	mfaChallengeRepo session.MFAChallengeRepository
+	sessionIssuer    *SessionIssuer
+	jwtService       interfaces.JWTService
+	eventPublisher   interfaces.EventPublisher
+	logger           *slog.Logger
 }
 
 func NewAuthenticateUserCase(
 	userRepo user.Repository,
-	userService *user.UserService,
+	mfaChallengeRepo session.MFAChallengeRepository,
+	sessionIssuer *SessionIssuer,
 	jwtService interfaces.JWTService,
 	eventPublisher interfaces.EventPublisher,
 	logger *slog.Logger,
 ) *AuthenticateUserCase {
 	return &AuthenticateUserCase{
-		userRepo:       userRepo,
-		userService:    userService,
-		jwtService:     jwtService,
-		eventPublisher: eventPublisher,
-		logger:         logger,
+		userRepo:         userRepo,
+		mfaChallengeRepo: mfaChallengeRepo,
+		sessionIssuer:    sessionIssuer,
+		jwtService:       jwtService,
+		eventPublisher:   eventPublisher,
+		logger:           logger,
 	}
 }
 
 func (uc *AuthenticateUserCase) Execute(ctx context.Context, req dto.AuthenticateUserRequest) (*dto.AuthenticateUserResponse, error) {
 	// Find the user by email or username, if not found return error
 	foundUser, err := uc.findUserByEmailOrUsername(ctx, req.EmailOrUsername)
+	if err != nil && !errors.Is(err, user.ErrNotFound) {
+		uc.logger.Error("Failed to look up user for authentication",
+			"ip_address", req.IPAddress,
+			"error", err.Error(),
+		)
+		return nil, fmt.Errorf("failed to look up user: %w", err)
+	}
 	if err != nil {
+		// Spend the same hashing time as a real verification
+		user.VerifyDummyPassword(req.Password)
+
 		uc.logger.Warn("Authentication failed - user not found",
 			"email_or_username", req.EmailOrUsername,
 			"ip_address", req.IPAddress,
 		)
-		return nil, errors.New("invalid credentials")
+		return nil, user.ErrInvalidCredentials
 	}
 
 	// Verify password
@@ -53,92 +71,87 @@ func (uc *AuthenticateUserCase) Execute(ctx context.Context, req dto.Authenticat
 			"email", foundUser.Credentials.Email.String(),
 			"ip_address", req.IPAddress,
 		)
-		return nil, errors.New("invalid credentials")
-	}
 
-	// Record Login
-	foundUser.RecordLogin(req.IPAddress, req.UserAgent)
-	if err := uc.userRepo.Update(ctx, foundUser); err != nil {
-		uc.logger.Error("Failed to update user login record",
-			"user_id", foundUser.ID,
-			"error", err.Error(),
-		)
-	}
-
-	// Generate JWT tokens
-	accessToken, err := uc.jwtService.GenerateAccessToken(foundUser.ID)
-	if err != nil {
-		uc.logger.Error("Failed to generate access token",
-			"user_id", foundUser.ID,
-			"error", err.Error(),
-		)
-		return nil, fmt.Errorf("failed to generate access token: %w", err)
+		uc.recordFailedLogin(ctx, foundUser, req.IPAddress, req.UserAgent)
+		return nil, user.ErrInvalidCredentials
 	}
 
-	refreshToken, err := uc.jwtService.GenerateRefreshToken(foundUser.ID)
+	fingerprint := session.NewFingerprint(req.UserAgent, req.IPAddress)
+	device, err := uc.sessionIssuer.FindDevice(ctx, foundUser.ID, fingerprint)
 	if err != nil {
-		uc.logger.Error("Failed to generate refresh token",
+		uc.logger.Error("Failed to resolve login device",
 			"user_id", foundUser.ID,
 			"error", err.Error(),
 		)
-		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+		return nil, err
 	}
 
-	events := foundUser.GetEvents()
-	if len(events) > 0 {
-		if err := uc.eventPublisher.PublishEvents(ctx, events...); err != nil {
-			uc.logger.Error("Failed to publish domain events",
+	// Devices that are not trusted must complete the MFA step first
+	if foundUser.Credentials.MfaEnabled && !device.IsTrusted(req.DeviceToken, time.Now()) {
+		challenge, err := session.NewMFAChallenge(foundUser.ID, fingerprint)
+		if err != nil {
+			return nil, err
+		}
+		if err := uc.mfaChallengeRepo.Create(ctx, challenge); err != nil {
+			uc.logger.Error("Failed to open MFA challenge",
 				"user_id", foundUser.ID,
-				"event_count", len(events),
 				"error", err.Error(),
 			)
-		} else {
-			uc.logger.Debug("Successfully published domain events",
-				"user_id", foundUser.ID,
-				"event_count", len(events),
-			)
+			return nil, fmt.Errorf("failed to open MFA challenge: %w", err)
+		}
+
+		mfaToken, err := uc.jwtService.GenerateMFAToken(challenge.ID, foundUser.ID, challenge.Fingerprint, challenge.ExpiresAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate MFA token: %w", err)
 		}
+
+		uc.logger.Info("MFA required to complete login",
+			"user_id", foundUser.ID,
+			"ip_address", req.IPAddress,
+		)
+		return dto.NewMFARequiredResponse(mfaToken), nil
 	}
-	foundUser.ClearEvents()
 
-	// Build response
-	userProfile := dto.NewUserProfile(foundUser)
-	bootstrap := dto.NewAuthBootstrap(foundUser)
-
-	// Get token expiration from JWT service
-	expiresIn := uc.jwtService.GetAccessTokenExpiration()
-
-	uc.logger.Info("User authenticated successfully",
-		"user_id", foundUser.ID,
-		"email", foundUser.Credentials.Email.String(),
-		"ip_address", req.IPAddress,
-		"user_agent", req.UserAgent,
-	)
-
-	return &dto.AuthenticateUserResponse{
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
-		TokenType:    "Bearer",
-		ExpiresIn:    int(expiresIn),
-		User:         userProfile,
-		Bootstrap:    bootstrap,
-	}, nil
+	return uc.sessionIssuer.Issue(ctx, foundUser, device, req.IPAddress, req.UserAgent)
 }
 
+// findUserByEmailOrUsername returns user.ErrNotFound when no account matches,
+// any other error is a failed lookup and must not be answered as bad credentials.
+// Credentials are never cached and a replica may still hold a replaced password,
+// so the account is read from the primary.
 func (uc *AuthenticateUserCase) findUserByEmailOrUsername(ctx context.Context, emailOrUsername string) (*user.User, error) {
+	ctx = interfaces.WithPrimaryReads(ctx)
+
 	// Try to find by email
 	if email, emailErr := user.NewEmail(emailOrUsername); emailErr == nil {
-		if foundUser, err := uc.userRepo.GetByEmail(ctx, email); err == nil {
-			return foundUser, nil
+		foundUser, err := uc.userRepo.GetByEmail(ctx, email)
+		if err == nil || !errors.Is(err, user.ErrNotFound) {
+			return foundUser, err
 		}
 	}
 
 	// If not found by email, try the username
 	if username, usernameErr := user.NewUsername(emailOrUsername); usernameErr == nil {
-		if foundUser, err := uc.userRepo.GetByUsername(ctx, username); err == nil {
-			return foundUser, nil
+		foundUser, err := uc.userRepo.GetByUsername(ctx, username)
+		if err == nil || !errors.Is(err, user.ErrNotFound) {
+			return foundUser, err
 		}
 	}
 
-	return nil, errors.New("user not found")
+	return nil, user.ErrNotFound
+}
+
+// recordFailedLogin adds the attempt to the owner's security log in the background.
+// Unknown accounts skip it, so waiting for the write would reveal which accounts exist.
+func (uc *AuthenticateUserCase) recordFailedLogin(ctx context.Context, foundUser *user.User, ipAddress, userAgent string) {
+	foundUser.RecordFailedLogin(ipAddress, userAgent)
+	events := foundUser.GetEvents()
+	foundUser.ClearEvents()
+
+	go func() {
+		recordCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), failedLoginRecordTimeout)
+		defer cancel()
+
+		publishEvents(recordCtx, uc.eventPublisher, uc.logger, foundUser.ID, events)
+	}()
 }
@@ -0,0 +1,131 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/application/authz"
+	dto "github.com/StefanPenchev05/Amora/backend/internal/application/dto/user"
+	"github.com/StefanPenchev05/Amora/backend/internal/application/interfaces"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/user"
+)
+
+// ChangeRoleCase lets an administrator promote or demote a user. The new role is
+// picked up by the next token refresh.
+type ChangeRoleCase struct {
+	userRepo user.Repository
+	logger   *slog.Logger
+}
+
+func NewChangeRoleCase(userRepo user.Repository, logger *slog.Logger) *ChangeRoleCase {
+	return &ChangeRoleCase{
+		userRepo: userRepo,
+		logger:   logger,
+	}
+}
+
+func (uc *ChangeRoleCase) Execute(ctx context.Context, req dto.ChangeRoleRequest) (*dto.ManagedUserResponse, error) {
+	role, err := user.NewRole(req.Role)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", user.ErrValidation, err)
+	}
+
+	// The token may predate a demotion, check the actor's current role
+	actor, err := uc.userRepo.GetByID(ctx, req.ActorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load actor: %w", err)
+	}
+	if err := authz.RequireUser(actor, user.PermissionAdminUsers); err != nil {
+		uc.logger.Warn("Role change rejected",
+			"actor_id", req.ActorID,
+			"user_id", req.UserID,
+		)
+		return nil, err
+	}
+
+	// The target is saved again, it needs its credentials
+	target, err := uc.userRepo.GetByID(interfaces.WithPrimaryReads(ctx), req.UserID)
+	if errors.Is(err, user.ErrNotFound) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load user: %w", err)
+	}
+
+	if req.ExpectedVersion != nil && *req.ExpectedVersion != target.Version {
+		uc.logger.Info("Role change is stale",
+			"user_id", target.ID,
+			"expected_version", *req.ExpectedVersion,
+			"version", target.Version,
+		)
+		return nil, user.ErrConcurrentModification
+	}
+
+	previous := target.Role
+	if err := target.ChangeRole(role); err != nil {
+		return nil, fmt.Errorf("%w: %v", user.ErrValidation, err)
+	}
+
+	err = uc.userRepo.Update(ctx, target)
+	if errors.Is(err, user.ErrConcurrentModification) {
+		return nil, err
+	}
+	if err != nil {
+		uc.logger.Error("Failed to change user role",
+			"user_id", req.UserID,
+			"error", err.Error(),
+		)
+		return nil, fmt.Errorf("failed to change role: %w", err)
+	}
+
+	uc.logger.Info("User role changed",
+		"actor_id", req.ActorID,
+		"user_id", target.ID,
+		"previous_role", previous.String(),
+		"role", role.String(),
+	)
+
+	response := dto.NewManagedUserResponse(target)
+	return &response, nil
+}
+
+// GetUserCase returns a user to an administrator, with the version a change must name
+type GetUserCase struct {
+	userRepo user.Repository
+	logger   *slog.Logger
+}
+
+func NewGetUserCase(userRepo user.Repository, logger *slog.Logger) *GetUserCase {
+	return &GetUserCase{
+		userRepo: userRepo,
+		logger:   logger,
+	}
+}
+
+func (uc *GetUserCase) Execute(ctx context.Context, actorID, userID string) (*dto.ManagedUserResponse, error) {
+	actor, err := uc.userRepo.GetByID(ctx, actorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load actor: %w", err)
+	}
+	if err := authz.RequireUser(actor, user.PermissionAdminUsers); err != nil {
+		return nil, err
+	}
+
+	// The version is compared on change, read it where changes are written
+	target, err := uc.userRepo.GetByID(interfaces.WithPrimaryReads(ctx), userID)
+	if errors.Is(err, user.ErrNotFound) {
+		return nil, err
+	}
+	if err != nil {
+		uc.logger.Error("Failed to load user",
+			"user_id", userID,
+			"error", err.Error(),
+		)
+		return nil, fmt.Errorf("failed to load user: %w", err)
+	}
+
+	response := dto.NewManagedUserResponse(target)
+	return &response, nil
+}
@@ -0,0 +1,65 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	dto "github.com/StefanPenchev05/Amora/backend/internal/application/dto/user"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/user"
+)
+
+// takenSuggestionCount is how many alternatives are offered for a taken username
+const takenSuggestionCount = 3
+
+type CheckUsernameCase struct {
+	userService *user.UserService
+	logger      *slog.Logger
+}
+
+func NewCheckUsernameCase(userService *user.UserService, logger *slog.Logger) *CheckUsernameCase {
+	return &CheckUsernameCase{
+		userService: userService,
+		logger:      logger,
+	}
+}
+
+func (uc *CheckUsernameCase) Execute(ctx context.Context, req dto.CheckUsernameRequest) (*dto.UsernameAvailabilityResponse, error) {
+	username, err := user.NewUsername(req.Username)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", user.ErrValidation, err)
+	}
+
+	available, err := uc.userService.IsUsernameAvailable(ctx, username.String())
+	if err != nil {
+		uc.logger.Error("Failed to check username availability",
+			"username", username.String(),
+			"error", err.Error(),
+		)
+		return nil, fmt.Errorf("failed to check username: %w", err)
+	}
+
+	response := &dto.UsernameAvailabilityResponse{
+		Username:  username.String(),
+		Available: available,
+	}
+	if available {
+		return response, nil
+	}
+
+	// Suggest from the names when given, otherwise from the taken username itself
+	firstName, lastName := req.FirstName, req.LastName
+	if firstName == "" && lastName == "" {
+		firstName = username.String()
+	}
+
+	suggestions, err := uc.userService.SuggestUsernames(ctx, firstName, lastName, takenSuggestionCount)
+	if err != nil {
+		// The answer is still useful without alternatives
+		uc.logger.Warn("Failed to suggest usernames", "error", err.Error())
+		return response, nil
+	}
+	response.Suggestions = suggestions
+
+	return response, nil
+}
@@ -2,93 +2,177 @@ package user
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"time"
 
 	dto "github.com/StefanPenchev05/Amora/backend/internal/application/dto/user"
 	"github.com/StefanPenchev05/Amora/backend/internal/application/interfaces"
+	relationshipCase "github.com/StefanPenchev05/Amora/backend/internal/application/usecases/relationship"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/relationship"
 	"github.com/StefanPenchev05/Amora/backend/internal/domain/user"
 )
 
+// accountExistsEmailTimeout bounds the notice sent to an existing owner
+const accountExistsEmailTimeout = 30 * time.Second
+
 type CreateUserCase struct {
-	userRepo       user.Repository
-	userService    *user.UserService
-	eventPublisher interfaces.EventPublisher
-	logger         *slog.Logger
+	userRepo      user.Repository
+	userService   *user.UserService
+	partnerLinker *relationshipCase.PartnerLinker
+	codeGuard     *relationshipCase.InviteCodeGuard
+	unitOfWork    interfaces.UnitOfWork
+	emailService  interfaces.EmailService
+	logger        *slog.Logger
 }
 
 func NewCreateUserCase(
 	userRepo user.Repository,
 	userService *user.UserService,
-	eventPublisher interfaces.EventPublisher,
+	partnerLinker *relationshipCase.PartnerLinker,
+	codeGuard *relationshipCase.InviteCodeGuard,
+	unitOfWork interfaces.UnitOfWork,
+	emailService interfaces.EmailService,
 	logger *slog.Logger,
 ) *CreateUserCase {
 	return &CreateUserCase{
-		userRepo:       userRepo,
-		userService:    userService,
-		eventPublisher: eventPublisher,
-		logger:         logger,
+		userRepo:      userRepo,
+		userService:   userService,
+		partnerLinker: partnerLinker,
+		codeGuard:     codeGuard,
+		unitOfWork:    unitOfWork,
+		emailService:  emailService,
+		logger:        logger,
 	}
 }
 
 func (uc *CreateUserCase) Execute(ctx context.Context, req dto.CreateUserRequest) (*dto.CreateUserResponse, error) {
-	if err := uc.userService.ValidateUserForCreation(ctx, req.Email, req.Username, req.Password); err != nil {
+	// The code is checked before the email, a bad one is answered the same
+	// whether or not the email is registered
+	if req.InviteCode != "" {
+		if err := uc.checkInviteCode(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+
+	err := uc.userService.ValidateUserForCreation(ctx, req.Email, req.Username, req.Password)
+	if errors.Is(err, user.ErrEmailTaken) {
+		return uc.handleExistingEmail(ctx, req)
+	}
+	if err != nil {
 		uc.logger.Warn("User creation validation failed",
 			"email", req.Email,
 			"username", req.Username,
 			"error", err.Error(),
 		)
-		return nil, fmt.Errorf("validation failed: %w", err)
+		return nil, fmt.Errorf("failed to validate user: %w", err)
 	}
 
 	newUser, err := user.NewUser(req.Email, req.Username, req.FirstName, req.LastName, req.Password)
 	if err != nil {
-		uc.logger.Error("Failed to create user entity",
+		uc.logger.Warn("Failed to create user entity",
 			"email", req.Email,
 			"username", req.Username,
 			"error", err.Error(),
 		)
-		return nil, fmt.Errorf("failed to create user: %w", err)
+		return nil, fmt.Errorf("%w: %v", user.ErrValidation, err)
 	}
 
-	if err := uc.userRepo.Create(ctx, newUser); err != nil {
+	// The repository stores the user and its events in the outbox atomically,
+	// an invite code links the couple in the same unit or fails the signup
+	err = uc.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		// A retried unit starts again from the new user, so change a copy
+		candidate := *newUser
+		if err := uc.userRepo.Create(ctx, &candidate); err != nil {
+			return fmt.Errorf("failed to save user: %w", err)
+		}
+		candidate.ClearEvents()
+
+		if req.InviteCode == "" {
+			return nil
+		}
+		_, err := uc.partnerLinker.RedeemCode(ctx, req.InviteCode, &candidate)
+		return err
+	})
+	if errors.Is(err, relationship.ErrInvalidCode) {
+		uc.codeGuard.Failed(ctx, "")
+	}
+	if errors.Is(err, relationship.ErrInvalidCode) || errors.Is(err, user.ErrInRelationship) {
+		uc.logger.Info("Signup invite code not redeemed",
+			"email", req.Email,
+			"reason", err.Error(),
+		)
+		return nil, relationship.ErrInvalidCode
+	}
+	if err != nil {
 		uc.logger.Error("Failed to save user to repository",
 			"user_id", newUser.ID,
 			"email", req.Email,
 			"error", err.Error(),
 		)
-		return nil, fmt.Errorf("failed to save user: %w", err)
+		return nil, err
 	}
 
-	events := newUser.GetEvents()
-	if len(events) > 0 {
-		if err := uc.eventPublisher.PublishEvents(ctx, events...); err != nil {
-			uc.logger.Error("Failed to publish domain events",
-				"user_id", newUser.ID,
-				"event_count", len(events),
-				"error", err.Error(),
-			)
-		} else {
-			uc.logger.Debug("Successfully published domain events",
-				"user_id", newUser.ID,
-				"event_count", len(events),
-			)
-		}
-	}
-	newUser.ClearEvents()
-
 	uc.logger.Info("User created successfully",
 		"user_id", newUser.ID,
 		"email", newUser.Credentials.Email.String(),
 		"username", newUser.Credentials.Username.String(),
 	)
 
-	return &dto.CreateUserResponse{
-		ID:        newUser.ID,
-		Email:     newUser.Credentials.Email.String(),
-		Username:  newUser.Credentials.Username.String(),
-		FirstName: newUser.Profile.FirstName,
-		LastName:  newUser.Profile.LastName,
-		CreatedAt: newUser.CreatedAt.Format("2006-01-02T15:04:05Z"),
-	}, nil
+	return dto.NewCreateUserResponse(), nil
+}
+
+// checkInviteCode refuses a code that cannot be redeemed. Signups guessing codes
+// are limited by address, there is no user yet.
+func (uc *CreateUserCase) checkInviteCode(ctx context.Context, req dto.CreateUserRequest) error {
+	if err := uc.codeGuard.Check(ctx, ""); err != nil {
+		return err
+	}
+
+	err := uc.partnerLinker.CheckCode(ctx, req.InviteCode)
+	if errors.Is(err, relationship.ErrInvalidCode) {
+		uc.codeGuard.Failed(ctx, "")
+		uc.logger.Info("Signup invite code not redeemed",
+			"email", req.Email,
+			"reason", err.Error(),
+		)
+		return err
+	}
+	if err != nil {
+		uc.logger.Error("Failed to check signup invite code",
+			"email", req.Email,
+			"error", err.Error(),
+		)
+		return err
+	}
+	return nil
+}
+
+// handleExistingEmail answers exactly like a successful signup and tells the
+// owner by email instead, so the response never reveals the account exists
+func (uc *CreateUserCase) handleExistingEmail(ctx context.Context, req dto.CreateUserRequest) (*dto.CreateUserResponse, error) {
+	// Run the same validation and hashing as creating the user, then discard it
+	if _, err := user.NewUser(req.Email, req.Username, req.FirstName, req.LastName, req.Password); err != nil {
+		return nil, fmt.Errorf("%w: %v", user.ErrValidation, err)
+	}
+
+	uc.logger.Warn("Signup attempted with a registered email",
+		"email", req.Email,
+	)
+
+	// Send in the background so the email provider latency is not observable
+	go func() {
+		sendCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), accountExistsEmailTimeout)
+		defer cancel()
+
+		if err := uc.emailService.SendAccountExistsEmail(sendCtx, req.Email); err != nil {
+			uc.logger.Error("Failed to send account exists email",
+				"email", req.Email,
+				"error", err.Error(),
+			)
+		}
+	}()
+
+	return dto.NewCreateUserResponse(), nil
 }
@@ -0,0 +1,89 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/application/authz"
+	dto "github.com/StefanPenchev05/Amora/backend/internal/application/dto/user"
+	"github.com/StefanPenchev05/Amora/backend/internal/application/interfaces"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/session"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/user"
+)
+
+// RefreshTokenCase issues a new access token for a refresh token. The session must
+// still be active and the role is reloaded, so revoked sessions and demoted users
+// stop receiving tokens with their old permissions.
+type RefreshTokenCase struct {
+	userRepo    user.Repository
+	sessionRepo session.Repository
+	jwtService  interfaces.JWTService
+	logger      *slog.Logger
+}
+
+func NewRefreshTokenCase(
+	userRepo user.Repository,
+	sessionRepo session.Repository,
+	jwtService interfaces.JWTService,
+	logger *slog.Logger,
+) *RefreshTokenCase {
+	return &RefreshTokenCase{
+		userRepo:    userRepo,
+		sessionRepo: sessionRepo,
+		jwtService:  jwtService,
+		logger:      logger,
+	}
+}
+
+func (uc *RefreshTokenCase) Execute(ctx context.Context, req dto.RefreshTokenRequest) (*dto.AuthenticateUserResponse, error) {
+	claims, err := uc.jwtService.ValidateRefreshToken(req.RefreshToken)
+	if err != nil || claims.SessionID == "" {
+		return nil, user.ErrInvalidCredentials
+	}
+
+	// A replica may not have seen the session revoked yet
+	activeSession, err := uc.sessionRepo.GetByID(interfaces.WithPrimaryReads(ctx), claims.SessionID)
+	if errors.Is(err, session.ErrNotFound) {
+		return nil, user.ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load session: %w", err)
+	}
+	if !activeSession.IsActive() || activeSession.UserID != claims.UserID {
+		uc.logger.Warn("Refresh rejected for a revoked session",
+			"user_id", claims.UserID,
+			"session_id", claims.SessionID,
+		)
+		return nil, user.ErrInvalidCredentials
+	}
+
+	foundUser, err := uc.userRepo.GetByID(ctx, claims.UserID)
+	if errors.Is(err, user.ErrNotFound) {
+		return nil, user.ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load user: %w", err)
+	}
+
+	accessToken, err := uc.jwtService.GenerateAccessToken(interfaces.TokenSubject{
+		UserID:      foundUser.ID,
+		SessionID:   activeSession.ID,
+		Role:        foundUser.Role.String(),
+		Permissions: authz.PermissionStrings(foundUser.Permissions()),
+	})
+	if err != nil {
+		uc.logger.Error("Failed to generate access token",
+			"user_id", foundUser.ID,
+			"error", err.Error(),
+		)
+		return nil, fmt.Errorf("failed to generate access token: %w", err)
+	}
+
+	return &dto.AuthenticateUserResponse{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int(uc.jwtService.GetAccessTokenExpiration()),
+	}, nil
+}
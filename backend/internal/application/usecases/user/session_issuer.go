@@ -0,0 +1,274 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"time"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/application/authz"
+	dto "github.com/StefanPenchev05/Amora/backend/internal/application/dto/user"
+	"github.com/StefanPenchev05/Amora/backend/internal/application/features"
+	"github.com/StefanPenchev05/Amora/backend/internal/application/interfaces"
+	settingsCase "github.com/StefanPenchev05/Amora/backend/internal/application/usecases/settings"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/session"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/settings"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/user"
+)
+
+// newDeviceAlertTimeout bounds the alert sent after a login from an unknown device
+const newDeviceAlertTimeout = 30 * time.Second
+
+// SessionIssuer completes a login once every authentication step has passed.
+// It is shared by the password and the MFA login steps.
+type SessionIssuer struct {
+	userRepo       user.Repository
+	settingsRepo   settings.Repository
+	sessionRepo    session.Repository
+	deviceRepo     session.DeviceRepository
+	jwtService     interfaces.JWTService
+	featureService interfaces.FeatureService
+	emailService   interfaces.EmailService
+	geoLocator     interfaces.GeoLocator
+	appURL         string
+	logger         *slog.Logger
+}
+
+func NewSessionIssuer(
+	userRepo user.Repository,
+	settingsRepo settings.Repository,
+	sessionRepo session.Repository,
+	deviceRepo session.DeviceRepository,
+	jwtService interfaces.JWTService,
+	featureService interfaces.FeatureService,
+	emailService interfaces.EmailService,
+	geoLocator interfaces.GeoLocator,
+	appURL string,
+	logger *slog.Logger,
+) *SessionIssuer {
+	return &SessionIssuer{
+		userRepo:       userRepo,
+		settingsRepo:   settingsRepo,
+		sessionRepo:    sessionRepo,
+		deviceRepo:     deviceRepo,
+		jwtService:     jwtService,
+		featureService: featureService,
+		emailService:   emailService,
+		geoLocator:     geoLocator,
+		appURL:         appURL,
+		logger:         logger,
+	}
+}
+
+// FindDevice returns the known device matching the fingerprint, or a new unsaved one
+func (si *SessionIssuer) FindDevice(ctx context.Context, userID string, fingerprint session.Fingerprint) (*session.Device, error) {
+	device, err := si.deviceRepo.GetByFingerprint(ctx, userID, fingerprint.Hash())
+	if errors.Is(err, session.ErrDeviceNotFound) {
+		return session.NewDevice(userID, fingerprint)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up device: %w", err)
+	}
+
+	return device, nil
+}
+
+// Issue starts a session on the device and returns the tokens and bootstrap data
+func (si *SessionIssuer) Issue(ctx context.Context, foundUser *user.User, device *session.Device, ipAddress, userAgent string) (*dto.AuthenticateUserResponse, error) {
+	// Remember the device, alerting only when the user already had other devices
+	knownDevices, err := si.deviceRepo.ListByUserID(ctx, foundUser.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list devices: %w", err)
+	}
+	isNewDevice := !containsDevice(knownDevices, device.ID)
+	alertNewDevice := isNewDevice && len(knownDevices) > 0
+	if isNewDevice {
+		if err := si.deviceRepo.Create(ctx, device); err != nil {
+			return nil, fmt.Errorf("failed to save device: %w", err)
+		}
+	} else {
+		device.MarkSeen()
+		if err := si.deviceRepo.Update(ctx, device); err != nil {
+			si.logger.Error("Failed to update device last seen",
+				"user_id", foundUser.ID,
+				"device_id", device.ID,
+				"error", err.Error(),
+			)
+		}
+	}
+
+	newSession, revokeToken, err := session.NewSession(foundUser.ID, device.ID, userAgent, ipAddress)
+	if err != nil {
+		return nil, err
+	}
+	if err := si.sessionRepo.Create(ctx, newSession); err != nil {
+		return nil, fmt.Errorf("failed to save session: %w", err)
+	}
+
+	// Record Login, the repository stores the login event in the outbox with the user
+	if err := si.recordLogin(ctx, foundUser, ipAddress, userAgent); err != nil {
+		si.logger.Error("Failed to update user login record",
+			"user_id", foundUser.ID,
+			"error", err.Error(),
+		)
+	}
+	foundUser.ClearEvents()
+
+	// Generate JWT tokens scoped to the session and the user's role
+	subject := interfaces.TokenSubject{
+		UserID:      foundUser.ID,
+		SessionID:   newSession.ID,
+		Role:        foundUser.Role.String(),
+		Permissions: authz.PermissionStrings(foundUser.Permissions()),
+	}
+
+	accessToken, err := si.jwtService.GenerateAccessToken(subject)
+	if err != nil {
+		si.logger.Error("Failed to generate access token",
+			"user_id", foundUser.ID,
+			"error", err.Error(),
+		)
+		return nil, fmt.Errorf("failed to generate access token: %w", err)
+	}
+
+	refreshToken, err := si.jwtService.GenerateRefreshToken(subject)
+	if err != nil {
+		si.logger.Error("Failed to generate refresh token",
+			"user_id", foundUser.ID,
+			"error", err.Error(),
+		)
+		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+
+	if alertNewDevice {
+		si.sendNewDeviceAlert(ctx, foundUser, device, ipAddress, revokeToken)
+	}
+
+	// Build response
+	userProfile := dto.NewUserProfile(foundUser)
+	enabledFeatures := si.featureService.EnabledFeatures(ctx, features.SubjectOf(foundUser))
+	bootstrap := dto.NewAuthBootstrap(foundUser, enabledFeatures, si.loadSettings(ctx, foundUser.ID))
+
+	// Get token expiration from JWT service
+	expiresIn := si.jwtService.GetAccessTokenExpiration()
+
+	si.logger.Info("User authenticated successfully",
+		"user_id", foundUser.ID,
+		"session_id", newSession.ID,
+		"device_id", device.ID,
+		"new_device", isNewDevice,
+		"ip_address", ipAddress,
+		"user_agent", userAgent,
+	)
+
+	return &dto.AuthenticateUserResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(expiresIn),
+		User:         &userProfile,
+		Bootstrap:    bootstrap,
+	}, nil
+}
+
+// sendNewDeviceAlert emails the user in the background with a link revoking the new session
+func (si *SessionIssuer) sendNewDeviceAlert(ctx context.Context, foundUser *user.User, device *session.Device, ipAddress, revokeToken string) {
+	location, err := si.geoLocator.Locate(ipAddress)
+	if err != nil {
+		si.logger.Warn("Failed to resolve location for new device alert",
+			"ip_address", ipAddress,
+			"error", err.Error(),
+		)
+	}
+
+	alert := interfaces.NewDeviceAlert{
+		Device:    device.UserAgentFamily,
+		IPAddress: ipAddress,
+		Location:  location,
+		LoggedAt:  time.Now(),
+		RevokeURL: si.appURL + "/security/not-me?token=" + url.QueryEscape(revokeToken),
+	}
+	email := foundUser.Credentials.Email.String()
+
+	go func() {
+		sendCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), newDeviceAlertTimeout)
+		defer cancel()
+
+		if err := si.emailService.SendNewDeviceAlert(sendCtx, email, alert); err != nil {
+			si.logger.Error("Failed to send new device alert",
+				"user_id", foundUser.ID,
+				"device_id", device.ID,
+				"error", err.Error(),
+			)
+		}
+	}()
+}
+
+// recordLogin stores the login on the user. When a parallel login or edit saved the
+// user first, the login is recorded once more on a fresh copy read from the primary,
+// a replica or the cache could still return the version that just lost.
+func (si *SessionIssuer) recordLogin(ctx context.Context, foundUser *user.User, ipAddress, userAgent string) error {
+	foundUser.RecordLogin(ipAddress, userAgent)
+	err := si.userRepo.Update(ctx, foundUser)
+	if !errors.Is(err, user.ErrConcurrentModification) {
+		return err
+	}
+
+	fresh, err := si.userRepo.GetByID(interfaces.WithPrimaryReads(ctx), foundUser.ID)
+	if err != nil {
+		return err
+	}
+	fresh.RecordLogin(ipAddress, userAgent)
+	if err := si.userRepo.Update(ctx, fresh); err != nil {
+		return err
+	}
+
+	*foundUser = *fresh
+	return nil
+}
+
+// loadSettings never fails the login, broken settings fall back to the defaults
+func (si *SessionIssuer) loadSettings(ctx context.Context, userID string) *settings.UserSettings {
+	userSettings, err := settingsCase.LoadOrDefault(ctx, si.settingsRepo, userID)
+	if err != nil {
+		si.logger.Error("Failed to load user settings for bootstrap",
+			"user_id", userID,
+			"error", err.Error(),
+		)
+		return settings.Default(userID)
+	}
+
+	return userSettings
+}
+
+// publishEvents logs publishing failures, for events that are not saved with an aggregate
+func publishEvents(ctx context.Context, eventPublisher interfaces.EventPublisher, logger *slog.Logger, userID string, events []user.DomainEvent) {
+	if len(events) == 0 {
+		return
+	}
+
+	if err := eventPublisher.PublishEvents(ctx, events...); err != nil {
+		logger.Error("Failed to publish domain events",
+			"user_id", userID,
+			"event_count", len(events),
+			"error", err.Error(),
+		)
+	} else {
+		logger.Debug("Successfully published domain events",
+			"user_id", userID,
+			"event_count", len(events),
+		)
+	}
+}
+
+// containsDevice reports whether the device was already saved, new devices get their ID before it
+func containsDevice(devices []*session.Device, deviceID string) bool {
+	for _, device := range devices {
+		if device.ID == deviceID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	dto "github.com/StefanPenchev05/Amora/backend/internal/application/dto/user"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/user"
+)
+
+const (
+	// defaultSuggestionCount is how many usernames are suggested when none is asked for
+	defaultSuggestionCount = 5
+
+	// maxSuggestionCount bounds the suggestions of one request
+	maxSuggestionCount = 10
+)
+
+type SuggestUsernamesCase struct {
+	userService *user.UserService
+	logger      *slog.Logger
+}
+
+func NewSuggestUsernamesCase(userService *user.UserService, logger *slog.Logger) *SuggestUsernamesCase {
+	return &SuggestUsernamesCase{
+		userService: userService,
+		logger:      logger,
+	}
+}
+
+func (uc *SuggestUsernamesCase) Execute(ctx context.Context, req dto.SuggestUsernamesRequest) (*dto.UsernameSuggestionsResponse, error) {
+	count := req.Count
+	if count <= 0 {
+		count = defaultSuggestionCount
+	}
+	count = min(count, maxSuggestionCount)
+
+	suggestions, err := uc.userService.SuggestUsernames(ctx, req.FirstName, req.LastName, count)
+	if err != nil {
+		uc.logger.Error("Failed to suggest usernames", "error", err.Error())
+		return nil, fmt.Errorf("failed to suggest usernames: %w", err)
+	}
+
+	return &dto.UsernameSuggestionsResponse{Suggestions: suggestions}, nil
+}
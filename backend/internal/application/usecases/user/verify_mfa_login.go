@@ -0,0 +1,146 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	dto "github.com/StefanPenchev05/Amora/backend/internal/application/dto/user"
+	"github.com/StefanPenchev05/Amora/backend/internal/application/interfaces"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/session"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/user"
+)
+
+// VerifyMFALoginCase completes a login that required the MFA step. Each MFA token
+// answers one challenge, which closes on success or after too many wrong codes.
+type VerifyMFALoginCase struct {
+	userRepo         user.Repository
+	mfaChallengeRepo session.MFAChallengeRepository
+	sessionIssuer    *SessionIssuer
+	jwtService       interfaces.JWTService
+	eventPublisher   interfaces.EventPublisher
+	trustedDeviceTTL time.Duration
+	logger           *slog.Logger
+}
+
+func NewVerifyMFALoginCase(
+	userRepo user.Repository,
+	mfaChallengeRepo session.MFAChallengeRepository,
+	sessionIssuer *SessionIssuer,
+	jwtService interfaces.JWTService,
+	eventPublisher interfaces.EventPublisher,
+	trustedDeviceTTL time.Duration,
+	logger *slog.Logger,
+) *VerifyMFALoginCase {
+	return &VerifyMFALoginCase{
+		userRepo:         userRepo,
+		mfaChallengeRepo: mfaChallengeRepo,
+		sessionIssuer:    sessionIssuer,
+		jwtService:       jwtService,
+		eventPublisher:   eventPublisher,
+		trustedDeviceTTL: trustedDeviceTTL,
+		logger:           logger,
+	}
+}
+
+func (uc *VerifyMFALoginCase) Execute(ctx context.Context, req dto.VerifyMFARequest) (*dto.AuthenticateUserResponse, error) {
+	challengeID, userID, deviceFingerprint, err := uc.jwtService.ValidateMFAToken(req.MFAToken)
+	if err != nil {
+		return nil, user.ErrInvalidCredentials
+	}
+
+	// The MFA step must come from the device that passed the password step
+	fingerprint := session.NewFingerprint(req.UserAgent, req.IPAddress)
+	if fingerprint.Hash() != deviceFingerprint {
+		uc.logger.Warn("MFA verification from a different device",
+			"user_id", userID,
+			"ip_address", req.IPAddress,
+		)
+		return nil, user.ErrInvalidCredentials
+	}
+
+	// The challenge was opened moments ago and the last accepted step may have been
+	// saved by the previous request, a replica can lag behind both
+	ctx = interfaces.WithPrimaryReads(ctx)
+
+	challenge, err := uc.mfaChallengeRepo.GetByID(ctx, challengeID)
+	if err != nil && !errors.Is(err, session.ErrChallengeNotFound) {
+		return nil, fmt.Errorf("failed to load MFA challenge: %w", err)
+	}
+	if err != nil || challenge.UserID != userID || !challenge.IsOpen(time.Now()) {
+		uc.logger.Warn("MFA verification failed - challenge is not open",
+			"user_id", userID,
+			"challenge_id", challengeID,
+			"ip_address", req.IPAddress,
+		)
+		return nil, user.ErrInvalidCredentials
+	}
+
+	foundUser, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, user.ErrInvalidCredentials
+	}
+
+	if !foundUser.VerifyMFACode(req.Code, time.Now()) {
+		uc.logger.Warn("MFA verification failed - invalid code",
+			"user_id", userID,
+			"challenge_id", challengeID,
+			"ip_address", req.IPAddress,
+		)
+		uc.recordFailedMFA(ctx, foundUser, challengeID, req.IPAddress, req.UserAgent)
+		return nil, user.ErrInvalidCredentials
+	}
+
+	// Keep the accepted time step first, a second request with the same code then fails
+	if err := uc.userRepo.Update(ctx, foundUser); err != nil {
+		if errors.Is(err, user.ErrConcurrentModification) {
+			return nil, user.ErrInvalidCredentials
+		}
+		return nil, fmt.Errorf("failed to save MFA step: %w", err)
+	}
+
+	if err := uc.mfaChallengeRepo.Complete(ctx, challengeID, time.Now()); err != nil {
+		if errors.Is(err, session.ErrChallengeClosed) {
+			return nil, user.ErrInvalidCredentials
+		}
+		return nil, err
+	}
+
+	device, err := uc.sessionIssuer.FindDevice(ctx, foundUser.ID, fingerprint)
+	if err != nil {
+		return nil, err
+	}
+
+	var deviceToken string
+	if req.TrustDevice {
+		deviceToken, err = device.Trust(uc.trustedDeviceTTL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to trust device: %w", err)
+		}
+	}
+
+	response, err := uc.sessionIssuer.Issue(ctx, foundUser, device, req.IPAddress, req.UserAgent)
+	if err != nil {
+		return nil, err
+	}
+	response.DeviceToken = deviceToken
+	return response, nil
+}
+
+// recordFailedMFA counts the wrong code against the challenge and adds it to the security log
+func (uc *VerifyMFALoginCase) recordFailedMFA(ctx context.Context, foundUser *user.User, challengeID, ipAddress, userAgent string) {
+	if err := uc.mfaChallengeRepo.RecordFailure(ctx, challengeID); err != nil {
+		uc.logger.Error("Failed to count MFA failure",
+			"user_id", foundUser.ID,
+			"challenge_id", challengeID,
+			"error", err.Error(),
+		)
+	}
+
+	foundUser.RecordFailedMFA(ipAddress, userAgent)
+	events := foundUser.GetEvents()
+	foundUser.ClearEvents()
+	publishEvents(ctx, uc.eventPublisher, uc.logger, foundUser.ID, events)
+}
@@ -2,13 +2,22 @@ package config
 
 import (
 	"fmt"
+	"net"
+	"net/url"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
 )
 
+// Storage backends selected with STORAGE
+const (
+	StorageMySQL  = "mysql"
+	StorageMemory = "memory"
+)
+
 // DBConfig holds database configurations
 type DBConfig struct {
 	User     string
@@ -17,6 +26,18 @@ type DBConfig struct {
 	Port     string
 	Name     string
 	DSN      string
+
+	// Pool limits, applied to the primary and to every replica
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+
+	// ReplicaDSNs are read replicas sharing the primary credentials, reads stay on the primary when empty
+	ReplicaDSNs []string
+
+	// MigrateOnBoot applies pending migrations before the server starts
+	MigrateOnBoot bool
 }
 
 // JWTConfig holds JWT-related configuration
@@ -24,6 +45,47 @@ type JWTConfig struct {
 	Secret     string
 	AccessTTL  time.Duration
 	RefreshTTL time.Duration
+
+	// SigningKeyFile is a PEM Ed25519 or RSA private key, when set tokens are signed with it instead of Secret
+	SigningKeyFile string
+
+	// VerificationKeyFiles are PEM keys still accepted for verification, e.g. the previous signing key
+	VerificationKeyFiles []string
+
+	// LegacyHS256Until keeps accepting tokens signed with Secret, and tokens without a kid,
+	// until the given time after switching to a signing key file. Unset, they are refused.
+	LegacyHS256Until *time.Time
+}
+
+// FeaturesConfig holds feature flag configuration
+type FeaturesConfig struct {
+	FlagsFile string
+}
+
+// GeoIPConfig holds the local GeoIP database used for coarse locations
+type GeoIPConfig struct {
+	DatabasePath string
+}
+
+// EventsConfig holds the external message broker, events stay in process when NATSURL is empty
+type EventsConfig struct {
+	NATSURL string
+	Stream  string
+}
+
+// CacheConfig holds the cache of hot reads, kept in process when RedisURL is empty
+type CacheConfig struct {
+	RedisURL string
+	TTL      time.Duration
+
+	// Size bounds the entries of the in-process cache
+	Size int
+}
+
+// SecurityConfig holds account security configuration
+type SecurityConfig struct {
+	// TrustedDeviceTTL is how long a trusted device may skip the MFA step
+	TrustedDeviceTTL time.Duration
 }
 
 // ServerConfig holds server configuration
@@ -32,14 +94,25 @@ type ServerConfig struct {
 	ReadTimeout  time.Duration
 	WriteTimeout time.Duration
 	IdleTimeout  time.Duration
+
+	// TrustedProxies are the networks whose X-Forwarded-For and X-Real-IP headers
+	// name the client, requests from anywhere else are keyed on their peer address
+	TrustedProxies []*net.IPNet
 }
 
 // Config represents the application configuration
 type Config struct {
 	Environment string
+	AppURL      string
+	Storage     string
 	Server      ServerConfig
 	Database    DBConfig
 	JWT         JWTConfig
+	Features    FeaturesConfig
+	GeoIP       GeoIPConfig
+	Events      EventsConfig
+	Cache       CacheConfig
+	Security    SecurityConfig
 	Debug       bool
 }
 
@@ -56,19 +129,16 @@ func (e ConfigError) Error() string {
 func Load() (*Config, error) {
 	config := &Config{}
 
-	// If a local env file exists (useful for local development), load it.
-	if _, err := os.Stat(".env.local"); err == nil {
-		if err := godotenv.Load(".env.local"); err != nil {
-			return nil, &ConfigError{
-				Field:   "env_file",
-				Message: fmt.Sprintf("failed to load .env.local: %v", err),
-			}
-		}
+	if err := loadEnvFile(); err != nil {
+		return nil, err
 	}
 
 	// Environment
 	config.Environment = getEnvWithDefualt("APP_ENV", "development")
 
+	// Public URL of the client app, used to build links in emails
+	config.AppURL = getEnvWithDefualt("APP_URL", "http://localhost:3000")
+
 	// Server Config
 	config.Server.Port = getEnvWithDefualt("PORT", "8080")
 
@@ -90,9 +160,23 @@ func Load() (*Config, error) {
 	}
 	config.Server.IdleTimeout = idleTimeout
 
+	// Reverse proxies as CIDRs or single addresses, none are trusted by default
+	for _, proxy := range splitList(os.Getenv("TRUSTED_PROXIES")) {
+		network, err := parseNetwork(proxy)
+		if err != nil {
+			return nil, ConfigError{Field: "TRUSTED_PROXIES", Message: err.Error()}
+		}
+		config.Server.TrustedProxies = append(config.Server.TrustedProxies, network)
+	}
+
+	// Storage, the memory backend needs no database and keeps nothing across restarts
+	config.Storage = getEnvWithDefualt("STORAGE", StorageMySQL)
+
 	// Database Config
-	if err := loadDatabaseConfig(&config.Database); err != nil {
-		return nil, err
+	if config.Storage == StorageMySQL {
+		if err := loadDatabaseConfig(&config.Database); err != nil {
+			return nil, err
+		}
 	}
 
 	// JWT Config
@@ -100,6 +184,32 @@ func Load() (*Config, error) {
 		return nil, err
 	}
 
+	// Feature flags
+	config.Features.FlagsFile = os.Getenv("FEATURE_FLAGS_FILE")
+
+	// GeoIP database, lookups are disabled when unset
+	config.GeoIP.DatabasePath = os.Getenv("GEOIP_DB_PATH")
+
+	// Message broker for other processes consuming domain events
+	config.Events.NATSURL = os.Getenv("NATS_URL")
+	config.Events.Stream = getEnvWithDefualt("NATS_STREAM", "AMORA_EVENTS")
+
+	// Cache of hot reads, shared between instances through Redis
+	config.Cache.RedisURL = os.Getenv("REDIS_URL")
+	if config.Cache.TTL, err = parseDuration("CACHE_TTL", "1m"); err != nil {
+		return nil, err
+	}
+	if config.Cache.Size, err = parseInt("CACHE_SIZE", 10000); err != nil {
+		return nil, err
+	}
+
+	// Security
+	trustedDeviceTTL, err := parseDuration("TRUSTED_DEVICE_TTL", "720h")
+	if err != nil {
+		return nil, err
+	}
+	config.Security.TrustedDeviceTTL = trustedDeviceTTL
+
 	// Debug mode
 	config.Debug = getEnvAsBool("DEBUG", false)
 
@@ -111,6 +221,34 @@ func Load() (*Config, error) {
 	return config, nil
 }
 
+// LoadDatabase loads only the database configuration, for tools like cmd/migrate
+func LoadDatabase() (*DBConfig, error) {
+	if err := loadEnvFile(); err != nil {
+		return nil, err
+	}
+
+	dbConfig := &DBConfig{}
+	if err := loadDatabaseConfig(dbConfig); err != nil {
+		return nil, err
+	}
+
+	return dbConfig, nil
+}
+
+// loadEnvFile loads a local env file if one exists (useful for local development)
+func loadEnvFile() error {
+	if _, err := os.Stat(".env.local"); err == nil {
+		if err := godotenv.Load(".env.local"); err != nil {
+			return &ConfigError{
+				Field:   "env_file",
+				Message: fmt.Sprintf("failed to load .env.local: %v", err),
+			}
+		}
+	}
+
+	return nil
+}
+
 func loadDatabaseConfig(dbConfig *DBConfig) error {
 	var missing []string
 
@@ -138,13 +276,51 @@ func loadDatabaseConfig(dbConfig *DBConfig) error {
 		}
 	}
 
+	dbConfig.MigrateOnBoot = getEnvAsBool("DB_MIGRATE_ON_BOOT", false)
+
+	// Pool
+	var err error
+	if dbConfig.MaxOpenConns, err = parseInt("DB_MAX_OPEN_CONNS", 25); err != nil {
+		return err
+	}
+	if dbConfig.MaxIdleConns, err = parseInt("DB_MAX_IDLE_CONNS", 10); err != nil {
+		return err
+	}
+	if dbConfig.ConnMaxLifetime, err = parseDuration("DB_CONN_MAX_LIFETIME", "30m"); err != nil {
+		return err
+	}
+	if dbConfig.ConnMaxIdleTime, err = parseDuration("DB_CONN_MAX_IDLE_TIME", "5m"); err != nil {
+		return err
+	}
+	if dbConfig.MaxOpenConns < 1 {
+		return ConfigError{Field: "DB_MAX_OPEN_CONNS", Message: "must be positive"}
+	}
+	if dbConfig.MaxIdleConns < 0 || dbConfig.MaxIdleConns > dbConfig.MaxOpenConns {
+		return ConfigError{Field: "DB_MAX_IDLE_CONNS", Message: "must be between 0 and DB_MAX_OPEN_CONNS"}
+	}
+
 	// Build DSN
-	dbConfig.DSN = fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
-		dbConfig.User, dbConfig.Password, dbConfig.Host, dbConfig.Port, dbConfig.Name)
+	dbConfig.DSN = buildDSN(dbConfig, dbConfig.Host, dbConfig.Port)
+
+	// Read replicas as host or host:port, the port defaults to the primary port
+	for _, replica := range splitList(os.Getenv("DB_REPLICA_HOSTS")) {
+		host, port, err := net.SplitHostPort(replica)
+		if err != nil {
+			host, port = replica, dbConfig.Port
+		}
+		dbConfig.ReplicaDSNs = append(dbConfig.ReplicaDSNs, buildDSN(dbConfig, host, port))
+	}
 
 	return nil
 }
 
+// buildDSN connects in UTC, both for the driver parsing DATETIME values and for
+// the session so NOW() and CURRENT_TIMESTAMP match what the application writes
+func buildDSN(dbConfig *DBConfig, host, port string) string {
+	return fmt.Sprintf("%s:%s@tcp(%s)/%s?charset=utf8mb4&parseTime=True&loc=UTC&time_zone=%s",
+		dbConfig.User, dbConfig.Password, net.JoinHostPort(host, port), dbConfig.Name, url.QueryEscape("'+00:00'"))
+}
+
 func loadJWTConfig(jwtConfig *JWTConfig) error {
 	ttlAccessDuration, err := parseDuration("ACCESS_TTL", "15m")
 	if err != nil {
@@ -158,21 +334,36 @@ func loadJWTConfig(jwtConfig *JWTConfig) error {
 	}
 	jwtConfig.RefreshTTL = ttlRefreshDuration
 
+	jwtConfig.SigningKeyFile = os.Getenv("JWT_SIGNING_KEY_FILE")
+	jwtConfig.VerificationKeyFiles = splitList(os.Getenv("JWT_VERIFICATION_KEY_FILES"))
+
 	jwtConfig.Secret = os.Getenv("JWT_SECRET")
-	if jwtConfig.Secret == "" {
+	if jwtConfig.Secret == "" && jwtConfig.SigningKeyFile == "" {
 		return &ConfigError{
 			Field:   "JWT_SECRET",
-			Message: "JWT secret is required",
+			Message: "JWT secret is required when no JWT_SIGNING_KEY_FILE is set",
 		}
 	}
 
-	if len(jwtConfig.Secret) < 32 {
+	if jwtConfig.Secret != "" && len(jwtConfig.Secret) < 32 {
 		return ConfigError{
 			Field:   "JWT_SECRET",
 			Message: "JWT secret must be at least 32 characters long",
 		}
 	}
 
+	legacyUntil, err := parseTime("JWT_LEGACY_HS256_UNTIL")
+	if err != nil {
+		return err
+	}
+	if legacyUntil != nil && (jwtConfig.SigningKeyFile == "" || jwtConfig.Secret == "") {
+		return ConfigError{
+			Field:   "JWT_LEGACY_HS256_UNTIL",
+			Message: "legacy HS256 tokens can only be accepted with both JWT_SIGNING_KEY_FILE and JWT_SECRET set",
+		}
+	}
+	jwtConfig.LegacyHS256Until = legacyUntil
+
 	return nil
 }
 
@@ -187,6 +378,18 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	// Validate storage
+	validStorages := []string{StorageMySQL, StorageMemory}
+	if !contains(validStorages, c.Storage) {
+		return ConfigError{
+			Field:   "STORAGE",
+			Message: fmt.Sprintf("invalid storage: %s (must be one of: %v)", c.Storage, validStorages),
+		}
+	}
+	if c.UsesMemoryStorage() && c.IsProduction() {
+		return ConfigError{Field: "STORAGE", Message: "memory storage is not allowed in production"}
+	}
+
 	// Validate server port
 	if port, err := strconv.Atoi(c.Server.Port); err != nil || port < 1 || port > 65535 {
 		return ConfigError{
@@ -207,6 +410,17 @@ func (c *Config) Validate() error {
 		return ConfigError{Field: "SERVER_IDLE_TIMEOUT", Message: mustBePositive}
 	}
 
+	if c.Cache.TTL <= 0 {
+		return ConfigError{Field: "CACHE_TTL", Message: mustBePositive}
+	}
+	if c.Cache.Size <= 0 {
+		return ConfigError{Field: "CACHE_SIZE", Message: mustBePositive}
+	}
+
+	if c.Security.TrustedDeviceTTL <= 0 {
+		return ConfigError{Field: "TRUSTED_DEVICE_TTL", Message: mustBePositive}
+	}
+
 	// Validate JWT TTL values
 	if c.JWT.AccessTTL <= 0 {
 		return ConfigError{Field: "ACCESS_TTL", Message: "access token TTL must be positive"}
@@ -234,6 +448,23 @@ func getEnvAsBool(key string, defaultValue bool) bool {
 	return defaultValue
 }
 
+func parseInt(key string, defaultValue int) (int, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, &ConfigError{
+			Field:   key,
+			Message: fmt.Sprintf("invalid number: %s", value),
+		}
+	}
+
+	return parsed, nil
+}
+
 func parseDuration(key, defaultValue string) (time.Duration, error) {
 	value := getEnvWithDefualt(key, defaultValue)
 	duration, err := time.ParseDuration(value)
@@ -247,11 +478,35 @@ func parseDuration(key, defaultValue string) (time.Duration, error) {
 	return duration, nil
 }
 
+// parseTime reads an RFC 3339 timestamp or a date, unset returns nil
+func parseTime(key string) (*time.Time, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return nil, nil
+	}
+
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return &parsed, nil
+		}
+	}
+
+	return nil, &ConfigError{
+		Field:   key,
+		Message: fmt.Sprintf("invalid time, expected RFC 3339 or YYYY-MM-DD: %s", value),
+	}
+}
+
 // IsDevelopment returns true if the environment is development
 func (c *Config) IsDevelopment() bool {
 	return c.Environment == "development"
 }
 
+// UsesMemoryStorage returns true if repositories are kept in memory
+func (c *Config) UsesMemoryStorage() bool {
+	return c.Storage == StorageMemory
+}
+
 // IsProduction returns true if the environment is production
 func (c *Config) IsProduction() bool {
 	return c.Environment == "production"
@@ -265,6 +520,39 @@ func getEnvWithDefualt(key, defualtValue string) string {
 	return defualtValue
 }
 
+// parseNetwork reads a CIDR, a single address is a network of one
+func parseNetwork(value string) (*net.IPNet, error) {
+	if !strings.Contains(value, "/") {
+		ip := net.ParseIP(value)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid address %q", value)
+		}
+		if ip4 := ip.To4(); ip4 != nil {
+			ip = ip4
+		}
+		bits := 8 * len(ip)
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+	}
+
+	_, network, err := net.ParseCIDR(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid network %q", value)
+	}
+	return network, nil
+}
+
+// splitList splits a comma-separated environment value, skipping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
 // contains checks if slice contains a value
 func contains(slice []string, item string) bool {
 	for _, s := range slice {
@@ -0,0 +1,107 @@
+package feature
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"regexp"
+)
+
+var keyRegex = regexp.MustCompile(`^[a-z0-9_.]+$`)
+
+var (
+	// ErrInvalidFlag is returned when a flag definition is rejected
+	ErrInvalidFlag = errors.New("invalid feature flag")
+
+	// ErrConcurrentModification is returned when a stored flag changed since it was read
+	ErrConcurrentModification = errors.New("feature flag was modified concurrently")
+)
+
+// Flag describes a feature and who it is rolled out to
+type Flag struct {
+	Key         string `json:"key"`
+	Description string `json:"description,omitempty"`
+	Enabled     bool   `json:"enabled"`
+
+	// Environments restricts the flag to the listed environments, empty means all
+	Environments []string `json:"environments,omitempty"`
+
+	// UserIDs and RelationshipIDs always receive the flag
+	UserIDs         []string `json:"user_ids,omitempty"`
+	RelationshipIDs []string `json:"relationship_ids,omitempty"`
+
+	// RolloutPercentage (0-100) of the remaining subjects receive the flag
+	RolloutPercentage int `json:"rollout_percentage"`
+
+	// RequiredEntitlement limits the flag to plans granting it
+	RequiredEntitlement Entitlement `json:"required_entitlement,omitempty"`
+
+	// Version counts the saves of a stored flag, 0 for flags only in the defaults or flags file
+	Version int64 `json:"-"`
+}
+
+// Subject is who a flag is evaluated for
+type Subject struct {
+	UserID         string
+	RelationshipID string
+	Plan           Plan
+	Environment    string
+}
+
+func (f Flag) Validate() error {
+	if !keyRegex.MatchString(f.Key) {
+		return errors.New("flag key can only contain lowercase letters, numbers, dots and underscores")
+	}
+	if f.RolloutPercentage < 0 || f.RolloutPercentage > 100 {
+		return errors.New("rollout percentage must be between 0 and 100")
+	}
+	if f.RequiredEntitlement != "" && !f.RequiredEntitlement.IsValid() {
+		return errors.New("required entitlement is not granted by any plan")
+	}
+	return nil
+}
+
+// IsEnabledFor evaluates the flag for the subject
+func (f Flag) IsEnabledFor(subject Subject) bool {
+	if !f.Enabled {
+		return false
+	}
+
+	if len(f.Environments) > 0 && !contains(f.Environments, subject.Environment) {
+		return false
+	}
+
+	if f.RequiredEntitlement != "" && !subject.Plan.Grants(f.RequiredEntitlement) {
+		return false
+	}
+
+	if subject.UserID != "" && contains(f.UserIDs, subject.UserID) {
+		return true
+	}
+	if subject.RelationshipID != "" && contains(f.RelationshipIDs, subject.RelationshipID) {
+		return true
+	}
+
+	return f.bucket(subject) < f.RolloutPercentage
+}
+
+// bucket places the subject in a stable 0-99 bucket for this flag.
+// Partners share the relationship bucket so both see the same features.
+func (f Flag) bucket(subject Subject) int {
+	id := subject.RelationshipID
+	if id == "" {
+		id = subject.UserID
+	}
+
+	sum := sha256.Sum256([]byte(f.Key + ":" + id))
+	return int(binary.BigEndian.Uint32(sum[:4]) % 100)
+}
+
+func contains(slice []string, item string) bool {
+	for _, s := range slice {
+		if s == item {
+			return true
+		}
+	}
+	return false
+}
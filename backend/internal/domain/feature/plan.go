@@ -0,0 +1,77 @@
+package feature
+
+import (
+	"errors"
+	"strings"
+)
+
+type Plan string
+
+const (
+	PlanFree    Plan = "free"
+	PlanPremium Plan = "premium"
+)
+
+// Entitlement is a capability granted by a plan
+type Entitlement string
+
+const (
+	EntitlementAdvancedAnalytics Entitlement = "advanced_analytics"
+	EntitlementPrioritySupport   Entitlement = "priority_support"
+)
+
+// planEntitlements maps each plan to the entitlements it grants
+var planEntitlements = map[Plan][]Entitlement{
+	PlanFree: {},
+	PlanPremium: {
+		EntitlementAdvancedAnalytics,
+		EntitlementPrioritySupport,
+	},
+}
+
+func NewPlan(plan string) (Plan, error) {
+	p := Plan(strings.ToLower(strings.TrimSpace(plan)))
+	if !p.IsValid() {
+		return "", errors.New("invalid plan")
+	}
+
+	return p, nil
+}
+
+func (p Plan) String() string {
+	return string(p)
+}
+
+func (p Plan) IsValid() bool {
+	_, ok := planEntitlements[p]
+	return ok
+}
+
+// Grants checks if the plan includes the entitlement
+func (p Plan) Grants(entitlement Entitlement) bool {
+	for _, e := range planEntitlements[p] {
+		if e == entitlement {
+			return true
+		}
+	}
+	return false
+}
+
+// Entitlements returns a copy of the entitlements granted by the plan
+func (p Plan) Entitlements() []Entitlement {
+	entitlements := make([]Entitlement, len(planEntitlements[p]))
+	copy(entitlements, planEntitlements[p])
+	return entitlements
+}
+
+// IsValid checks that some plan grants the entitlement
+func (e Entitlement) IsValid() bool {
+	for _, entitlements := range planEntitlements {
+		for _, granted := range entitlements {
+			if granted == e {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,23 @@
+package feature
+
+import "context"
+
+// FlagRepository provides feature flag definitions
+type FlagRepository interface {
+	ListFlags(ctx context.Context) ([]Flag, error)
+}
+
+// FlagStore manages the flags stored on top of the defaults and flags file
+type FlagStore interface {
+	FlagRepository
+
+	// SaveFlag creates the flag or replaces the stored flag with the same key and
+	// sets its new version. A non-nil expectedVersion must match the stored version,
+	// 0 while the key is not stored, or ErrConcurrentModification is returned.
+	SaveFlag(ctx context.Context, flag *Flag, expectedVersion *int64) error
+}
+
+// PlanRepository resolves the plan a user is subscribed to
+type PlanRepository interface {
+	GetPlanByUserID(ctx context.Context, userID string) (Plan, error)
+}
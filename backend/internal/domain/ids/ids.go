@@ -0,0 +1,42 @@
+package ids
+
+import (
+	"sync/atomic"
+
+	"github.com/google/uuid"
+)
+
+// Generator creates the IDs of aggregates and events when they are constructed
+type Generator interface {
+	NewID() string
+}
+
+var generator atomic.Pointer[Generator]
+
+func init() {
+	SetGenerator(UUIDv7Generator{})
+}
+
+// New returns an ID from the configured generator
+func New() string {
+	return (*generator.Load()).NewID()
+}
+
+// SetGenerator replaces the generator, e.g. with a deterministic one
+func SetGenerator(g Generator) {
+	generator.Store(&g)
+}
+
+// UUIDv7Generator creates time-ordered UUIDs, so new rows append to the end of indexes
+type UUIDv7Generator struct{}
+
+func (UUIDv7Generator) NewID() string {
+	// NewV7 only fails when the system random source does
+	return uuid.Must(uuid.NewV7()).String()
+}
+
+// IsValid reports whether the value is an ID in the format New returns
+func IsValid(value string) bool {
+	_, err := uuid.Parse(value)
+	return err == nil
+}
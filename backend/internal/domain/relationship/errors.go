@@ -0,0 +1,44 @@
+package relationship
+
+import "errors"
+
+// Typed errors returned by the relationship bounded context
+var (
+	// ErrNotFound is returned by repositories when no relationship matches
+	ErrNotFound = errors.New("relationship not found")
+
+	// ErrInviteNotFound is also returned for invites of other users, so their existence is not revealed
+	ErrInviteNotFound = errors.New("invite not found")
+
+	// ErrInvitePending is returned when the two users already have an open invite
+	ErrInvitePending = errors.New("an invite between these users is already pending")
+
+	// ErrInviteNotPending is returned when the invite was already answered or cancelled
+	ErrInviteNotPending = errors.New("invite is no longer pending")
+
+	// ErrInvalidCode is returned for unknown, used and expired invite codes alike
+	ErrInvalidCode = errors.New("invite code is invalid or has expired")
+
+	// ErrInvalidTransition is returned when the status of the relationship does not allow the change
+	ErrInvalidTransition = errors.New("relationship cannot make this change in its current status")
+
+	// ErrAwaitingConfirmation is returned when the partner who made a request tries to confirm it too early
+	ErrAwaitingConfirmation = errors.New("waiting for your partner to confirm")
+
+	// ErrNoPendingRequest is returned when there is no request of the user to withdraw
+	ErrNoPendingRequest = errors.New("no pending request to withdraw")
+
+	// ErrReadOnly is returned for changes to a paused or ended relationship
+	ErrReadOnly = errors.New("relationship is read-only")
+
+	// ErrSlugTaken is returned for slugs another relationship uses now or used before
+	ErrSlugTaken = errors.New("slug is already taken")
+
+	ErrInvalidProfile         = errors.New("invalid relationship profile")
+	ErrReservedSlug           = errors.New("slug is reserved")
+	ErrConcurrentModification = errors.New("relationship was modified concurrently")
+
+	ErrInviteExpired = errors.New("invite has expired")
+	ErrSelfInvite    = errors.New("you cannot invite yourself")
+	ErrInvalidInvite = errors.New("invalid invite")
+)
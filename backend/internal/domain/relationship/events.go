@@ -0,0 +1,257 @@
+package relationship
+
+import (
+	"time"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/ids"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/user"
+)
+
+// InviteSentEvent - fired when a user invites another to be their partner
+type InviteSentEvent struct {
+	user.BaseEvent
+	InviterID string    `json:"inviter_id"`
+	InviteeID string    `json:"invitee_id,omitempty"`
+	Message   string    `json:"message,omitempty"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func NewInviteSentEvent(inviteID, inviterID, inviteeID, message string, expiresAt time.Time) *InviteSentEvent {
+	return &InviteSentEvent{
+		BaseEvent: newBaseEvent("relationship.invite_sent", inviteID),
+		InviterID: inviterID,
+		InviteeID: inviteeID,
+		Message:   message,
+		ExpiresAt: expiresAt,
+	}
+}
+
+func (e InviteSentEvent) GetEventData() interface{} { return e }
+
+// InviteAcceptedEvent - fired when the invitee accepts, together with RelationshipCreatedEvent
+type InviteAcceptedEvent struct {
+	user.BaseEvent
+	InviterID      string `json:"inviter_id"`
+	InviteeID      string `json:"invitee_id"`
+	RelationshipID string `json:"relationship_id"`
+}
+
+func NewInviteAcceptedEvent(inviteID, inviterID, inviteeID, relationshipID string) *InviteAcceptedEvent {
+	return &InviteAcceptedEvent{
+		BaseEvent:      newBaseEvent("relationship.invite_accepted", inviteID),
+		InviterID:      inviterID,
+		InviteeID:      inviteeID,
+		RelationshipID: relationshipID,
+	}
+}
+
+func (e InviteAcceptedEvent) GetEventData() interface{} { return e }
+
+// InviteDeclinedEvent - fired when the invitee declines
+type InviteDeclinedEvent struct {
+	user.BaseEvent
+	InviterID string `json:"inviter_id"`
+	InviteeID string `json:"invitee_id"`
+}
+
+func NewInviteDeclinedEvent(inviteID, inviterID, inviteeID string) *InviteDeclinedEvent {
+	return &InviteDeclinedEvent{
+		BaseEvent: newBaseEvent("relationship.invite_declined", inviteID),
+		InviterID: inviterID,
+		InviteeID: inviteeID,
+	}
+}
+
+func (e InviteDeclinedEvent) GetEventData() interface{} { return e }
+
+// InviteCancelledEvent - fired when the inviter withdraws the invite
+type InviteCancelledEvent struct {
+	user.BaseEvent
+	InviterID string `json:"inviter_id"`
+	InviteeID string `json:"invitee_id"`
+}
+
+func NewInviteCancelledEvent(inviteID, inviterID, inviteeID string) *InviteCancelledEvent {
+	return &InviteCancelledEvent{
+		BaseEvent: newBaseEvent("relationship.invite_cancelled", inviteID),
+		InviterID: inviterID,
+		InviteeID: inviteeID,
+	}
+}
+
+func (e InviteCancelledEvent) GetEventData() interface{} { return e }
+
+// RelationshipCreatedEvent - fired when two users become partners
+type RelationshipCreatedEvent struct {
+	user.BaseEvent
+	PartnerAID string `json:"partner_a_id"`
+	PartnerBID string `json:"partner_b_id"`
+}
+
+func NewRelationshipCreatedEvent(relationshipID, partnerAID, partnerBID string) *RelationshipCreatedEvent {
+	return &RelationshipCreatedEvent{
+		BaseEvent:  newBaseEvent("relationship.created", relationshipID),
+		PartnerAID: partnerAID,
+		PartnerBID: partnerBID,
+	}
+}
+
+func (e RelationshipCreatedEvent) GetEventData() interface{} { return e }
+
+// RelationshipPausedEvent - fired when a partner pauses the relationship
+type RelationshipPausedEvent struct {
+	user.BaseEvent
+	PausedBy string `json:"paused_by"`
+}
+
+func NewRelationshipPausedEvent(relationshipID, pausedBy string) *RelationshipPausedEvent {
+	return &RelationshipPausedEvent{
+		BaseEvent: newBaseEvent("relationship.paused", relationshipID),
+		PausedBy:  pausedBy,
+	}
+}
+
+func (e RelationshipPausedEvent) GetEventData() interface{} { return e }
+
+// RelationshipResumedEvent - fired when a partner resumes a paused relationship
+type RelationshipResumedEvent struct {
+	user.BaseEvent
+	ResumedBy string `json:"resumed_by"`
+}
+
+func NewRelationshipResumedEvent(relationshipID, resumedBy string) *RelationshipResumedEvent {
+	return &RelationshipResumedEvent{
+		BaseEvent: newBaseEvent("relationship.resumed", relationshipID),
+		ResumedBy: resumedBy,
+	}
+}
+
+func (e RelationshipResumedEvent) GetEventData() interface{} { return e }
+
+// RelationshipEndRequestedEvent - fired when a partner asks to end the relationship
+type RelationshipEndRequestedEvent struct {
+	user.BaseEvent
+	RequestedBy   string    `json:"requested_by"`
+	ConfirmableAt time.Time `json:"confirmable_at"`
+}
+
+func NewRelationshipEndRequestedEvent(relationshipID, requestedBy string, confirmableAt time.Time) *RelationshipEndRequestedEvent {
+	return &RelationshipEndRequestedEvent{
+		BaseEvent:     newBaseEvent("relationship.end_requested", relationshipID),
+		RequestedBy:   requestedBy,
+		ConfirmableAt: confirmableAt,
+	}
+}
+
+func (e RelationshipEndRequestedEvent) GetEventData() interface{} { return e }
+
+// RelationshipEndedEvent - fired when the request to end is confirmed
+type RelationshipEndedEvent struct {
+	user.BaseEvent
+	RequestedBy   string    `json:"requested_by"`
+	ConfirmedBy   string    `json:"confirmed_by"`
+	ReadableUntil time.Time `json:"readable_until"`
+}
+
+func NewRelationshipEndedEvent(relationshipID, requestedBy, confirmedBy string, readableUntil time.Time) *RelationshipEndedEvent {
+	return &RelationshipEndedEvent{
+		BaseEvent:     newBaseEvent("relationship.ended", relationshipID),
+		RequestedBy:   requestedBy,
+		ConfirmedBy:   confirmedBy,
+		ReadableUntil: readableUntil,
+	}
+}
+
+func (e RelationshipEndedEvent) GetEventData() interface{} { return e }
+
+// RelationshipReconnectRequestedEvent - fired when a partner asks to reconnect an ended relationship
+type RelationshipReconnectRequestedEvent struct {
+	user.BaseEvent
+	RequestedBy string `json:"requested_by"`
+}
+
+func NewRelationshipReconnectRequestedEvent(relationshipID, requestedBy string) *RelationshipReconnectRequestedEvent {
+	return &RelationshipReconnectRequestedEvent{
+		BaseEvent:   newBaseEvent("relationship.reconnect_requested", relationshipID),
+		RequestedBy: requestedBy,
+	}
+}
+
+func (e RelationshipReconnectRequestedEvent) GetEventData() interface{} { return e }
+
+// RelationshipReconnectedEvent - fired when the other partner confirms reconnecting
+type RelationshipReconnectedEvent struct {
+	user.BaseEvent
+	RequestedBy string `json:"requested_by"`
+	ConfirmedBy string `json:"confirmed_by"`
+}
+
+func NewRelationshipReconnectedEvent(relationshipID, requestedBy, confirmedBy string) *RelationshipReconnectedEvent {
+	return &RelationshipReconnectedEvent{
+		BaseEvent:   newBaseEvent("relationship.reconnected", relationshipID),
+		RequestedBy: requestedBy,
+		ConfirmedBy: confirmedBy,
+	}
+}
+
+func (e RelationshipReconnectedEvent) GetEventData() interface{} { return e }
+
+// RelationshipExpiredEvent - fired when an ended relationship is deleted after its retention period
+type RelationshipExpiredEvent struct {
+	user.BaseEvent
+	PartnerAID string `json:"partner_a_id"`
+	PartnerBID string `json:"partner_b_id"`
+}
+
+func NewRelationshipExpiredEvent(relationshipID, partnerAID, partnerBID string) *RelationshipExpiredEvent {
+	return &RelationshipExpiredEvent{
+		BaseEvent:  newBaseEvent("relationship.expired", relationshipID),
+		PartnerAID: partnerAID,
+		PartnerBID: partnerBID,
+	}
+}
+
+func (e RelationshipExpiredEvent) GetEventData() interface{} { return e }
+
+// RelationshipRequestWithdrawnEvent - fired when a partner withdraws their request to end or reconnect
+type RelationshipRequestWithdrawnEvent struct {
+	user.BaseEvent
+	Request     string `json:"request"`
+	WithdrawnBy string `json:"withdrawn_by"`
+}
+
+func NewRelationshipRequestWithdrawnEvent(relationshipID, request, withdrawnBy string) *RelationshipRequestWithdrawnEvent {
+	return &RelationshipRequestWithdrawnEvent{
+		BaseEvent:   newBaseEvent("relationship.request_withdrawn", relationshipID),
+		Request:     request,
+		WithdrawnBy: withdrawnBy,
+	}
+}
+
+func (e RelationshipRequestWithdrawnEvent) GetEventData() interface{} { return e }
+
+// RelationshipProfileUpdatedEvent - fired when a partner edits the profile the couple shares
+type RelationshipProfileUpdatedEvent struct {
+	user.BaseEvent
+	UpdatedBy string   `json:"updated_by"`
+	Fields    []string `json:"fields"`
+}
+
+func NewRelationshipProfileUpdatedEvent(relationshipID, updatedBy string, fields []string) *RelationshipProfileUpdatedEvent {
+	return &RelationshipProfileUpdatedEvent{
+		BaseEvent: newBaseEvent("relationship.profile_updated", relationshipID),
+		UpdatedBy: updatedBy,
+		Fields:    fields,
+	}
+}
+
+func (e RelationshipProfileUpdatedEvent) GetEventData() interface{} { return e }
+
+func newBaseEvent(eventType, aggregateID string) user.BaseEvent {
+	return user.BaseEvent{
+		EventID:     ids.New(),
+		EventType:   eventType,
+		AggregateID: aggregateID,
+		OccurredAt:  time.Now(),
+	}
+}
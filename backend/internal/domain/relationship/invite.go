@@ -0,0 +1,175 @@
+package relationship
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/ids"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/user"
+)
+
+const (
+	// InviteTTL is how long an invite can be answered
+	InviteTTL = 14 * 24 * time.Hour
+
+	maxMessageLength = 255
+)
+
+type InviteStatus string
+
+const (
+	InviteSent      InviteStatus = "sent"
+	InviteAccepted  InviteStatus = "accepted"
+	InviteDeclined  InviteStatus = "declined"
+	InviteExpired   InviteStatus = "expired"
+	InviteCancelled InviteStatus = "cancelled"
+)
+
+// Invite is the aggregate root of one user asking another to become partners
+type Invite struct {
+	ID        string
+	InviterID string
+
+	// InviteeID is empty for a code invite until someone redeems the code
+	InviteeID string
+
+	// CodeHash is set on invites shared as a code, only its hash is stored
+	CodeHash string
+
+	Status      InviteStatus
+	Message     string
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+	RespondedAt *time.Time
+
+	events []user.DomainEvent
+}
+
+// NewInvite creates a pending invite from inviter to invitee
+func NewInvite(inviterID, inviteeID, message string) (*Invite, error) {
+	if inviterID == "" || inviteeID == "" {
+		return nil, fmt.Errorf("%w: inviter and invitee are required", ErrInvalidInvite)
+	}
+	if inviterID == inviteeID {
+		return nil, ErrSelfInvite
+	}
+
+	return newInvite(inviterID, inviteeID, "", message, InviteTTL)
+}
+
+func newInvite(inviterID, inviteeID, codeHash, message string, ttl time.Duration) (*Invite, error) {
+	message = strings.TrimSpace(message)
+	if len(message) > maxMessageLength {
+		return nil, fmt.Errorf("%w: message must be at most %d characters", ErrInvalidInvite, maxMessageLength)
+	}
+
+	now := time.Now()
+	invite := &Invite{
+		ID:        ids.New(),
+		InviterID: inviterID,
+		InviteeID: inviteeID,
+		CodeHash:  codeHash,
+		Status:    InviteSent,
+		Message:   message,
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+		events:    make([]user.DomainEvent, 0),
+	}
+
+	invite.raiseEvent(NewInviteSentEvent(invite.ID, inviterID, inviteeID, message, invite.ExpiresAt))
+	return invite, nil
+}
+
+// CurrentStatus reports the status at now, unanswered invites past their
+// expiry are expired even though the stored status still says sent
+func (i *Invite) CurrentStatus(now time.Time) InviteStatus {
+	if i.Status == InviteSent && !now.Before(i.ExpiresAt) {
+		return InviteExpired
+	}
+	return i.Status
+}
+
+// IsPending reports whether the invite can still be answered
+func (i *Invite) IsPending(now time.Time) bool {
+	return i.CurrentStatus(now) == InviteSent
+}
+
+// IsCodeInvite reports whether the invite was shared as a code
+func (i *Invite) IsCodeInvite() bool {
+	return i.CodeHash != ""
+}
+
+// Involves reports whether the user sent or received the invite
+func (i *Invite) Involves(userID string) bool {
+	return i.InviterID == userID || i.InviteeID == userID
+}
+
+// Accept answers the invite for the invitee and starts the relationship.
+// Linking both partners to it is left to the caller, which owns the users.
+func (i *Invite) Accept(userID string) (*Relationship, error) {
+	if err := i.respond(userID, i.InviteeID, InviteAccepted); err != nil {
+		return nil, err
+	}
+
+	relationship, err := NewRelationship(i.InviterID, i.InviteeID, i.InviterID)
+	if err != nil {
+		return nil, err
+	}
+
+	i.raiseEvent(NewInviteAcceptedEvent(i.ID, i.InviterID, i.InviteeID, relationship.ID))
+	return relationship, nil
+}
+
+// Decline answers the invite for the invitee
+func (i *Invite) Decline(userID string) error {
+	if err := i.respond(userID, i.InviteeID, InviteDeclined); err != nil {
+		return err
+	}
+
+	i.raiseEvent(NewInviteDeclinedEvent(i.ID, i.InviterID, i.InviteeID))
+	return nil
+}
+
+// Cancel withdraws the invite for the inviter
+func (i *Invite) Cancel(userID string) error {
+	if err := i.respond(userID, i.InviterID, InviteCancelled); err != nil {
+		return err
+	}
+
+	i.raiseEvent(NewInviteCancelledEvent(i.ID, i.InviterID, i.InviteeID))
+	return nil
+}
+
+// respond moves a pending invite to status when userID is the allowed user
+func (i *Invite) respond(userID, allowedID string, status InviteStatus) error {
+	// The other side of the invite is told it does not exist, like strangers are
+	if userID != allowedID {
+		return ErrInviteNotFound
+	}
+
+	now := time.Now()
+	switch i.CurrentStatus(now) {
+	case InviteSent:
+	case InviteExpired:
+		return ErrInviteExpired
+	default:
+		return ErrInviteNotPending
+	}
+
+	i.Status = status
+	i.RespondedAt = &now
+	return nil
+}
+
+func (i *Invite) raiseEvent(event user.DomainEvent) {
+	i.events = append(i.events, event)
+}
+
+func (i *Invite) GetEvents() []user.DomainEvent {
+	return i.events
+}
+
+func (i *Invite) ClearEvents() {
+	i.events = make([]user.DomainEvent, 0)
+}
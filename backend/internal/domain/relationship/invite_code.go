@@ -0,0 +1,98 @@
+package relationship
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// InviteCodeTTL is how long a shared invite code can be redeemed
+	InviteCodeTTL = 7 * 24 * time.Hour
+
+	inviteCodeLength = 8
+)
+
+// inviteCodeAlphabet is Crockford's base32, it has no I, L, O or U so codes
+// read aloud or copied by hand are hard to get wrong
+const inviteCodeAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// NewCodeInvite creates an invite anyone can redeem with the returned code, for
+// partners who have no account yet. Only the hash of the code is kept.
+func NewCodeInvite(inviterID, message string) (*Invite, string, error) {
+	if inviterID == "" {
+		return nil, "", fmt.Errorf("%w: inviter is required", ErrInvalidInvite)
+	}
+
+	code, err := generateInviteCode()
+	if err != nil {
+		return nil, "", err
+	}
+
+	invite, err := newInvite(inviterID, "", HashInviteCode(code), message, InviteCodeTTL)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return invite, FormatInviteCode(code), nil
+}
+
+// IsRedeemable reports whether the invite is a code nobody used yet that can
+// still be answered at now
+func (i *Invite) IsRedeemable(now time.Time) bool {
+	return i.IsCodeInvite() && i.InviteeID == "" && i.IsPending(now)
+}
+
+// Redeem makes the user the invitee of a code invite and accepts it. A used,
+// expired or cancelled code is reported as invalid, like an unknown one.
+func (i *Invite) Redeem(userID string) (*Relationship, error) {
+	if !i.IsRedeemable(time.Now()) {
+		return nil, ErrInvalidCode
+	}
+	if userID == i.InviterID {
+		return nil, ErrSelfInvite
+	}
+
+	i.InviteeID = userID
+	return i.Accept(userID)
+}
+
+// NormalizeInviteCode returns the canonical form of a typed code. Separators and
+// case are ignored and the letters Crockford's base32 leaves out read as digits.
+func NormalizeInviteCode(code string) string {
+	replacer := strings.NewReplacer("-", "", " ", "", "O", "0", "I", "1", "L", "1")
+	return replacer.Replace(strings.ToUpper(strings.TrimSpace(code)))
+}
+
+// FormatInviteCode splits the code in two groups for reading, e.g. 7K3M-Q9TX
+func FormatInviteCode(code string) string {
+	code = NormalizeInviteCode(code)
+	if len(code) != inviteCodeLength {
+		return code
+	}
+	return code[:inviteCodeLength/2] + "-" + code[inviteCodeLength/2:]
+}
+
+// HashInviteCode returns the stored form of an invite code
+func HashInviteCode(code string) string {
+	sum := sha256.Sum256([]byte(NormalizeInviteCode(code)))
+	return hex.EncodeToString(sum[:])
+}
+
+func generateInviteCode() (string, error) {
+	bytes := make([]byte, inviteCodeLength)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", errors.New("failed to generate invite code")
+	}
+
+	// 256 is a multiple of 32, so every character is equally likely
+	code := make([]byte, inviteCodeLength)
+	for i, b := range bytes {
+		code[i] = inviteCodeAlphabet[int(b)%len(inviteCodeAlphabet)]
+	}
+	return string(code), nil
+}
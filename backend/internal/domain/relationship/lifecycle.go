@@ -0,0 +1,175 @@
+package relationship
+
+import "time"
+
+const (
+	// EndConfirmationDelay is how long the partner who asked to end must wait
+	// before confirming alone, the other partner can confirm right away
+	EndConfirmationDelay = 24 * time.Hour
+
+	// RetentionPeriod is how long both partners can still read an ended
+	// relationship, and reconnect it
+	RetentionPeriod = 30 * 24 * time.Hour
+)
+
+// RequestKind is a change that needs a partner to confirm it
+type RequestKind string
+
+const (
+	RequestEnd       RequestKind = "end"
+	RequestReconnect RequestKind = "reconnect"
+)
+
+// Request is a change asked for by one partner and waiting for confirmation
+type Request struct {
+	Kind        RequestKind
+	RequestedBy string
+	RequestedAt time.Time
+}
+
+// ConfirmableAt returns when the partner who made the request can confirm it alone.
+// Reconnecting always needs the other partner.
+func (q *Request) ConfirmableAt() *time.Time {
+	if q.Kind != RequestEnd {
+		return nil
+	}
+	confirmableAt := q.RequestedAt.Add(EndConfirmationDelay)
+	return &confirmableAt
+}
+
+// ReadableUntil returns when the partners lose access to the ended relationship
+func (r *Relationship) ReadableUntil() *time.Time {
+	if r.EndedAt == nil {
+		return nil
+	}
+	readableUntil := r.EndedAt.Add(RetentionPeriod)
+	return &readableUntil
+}
+
+// CanRead reports whether the user may see the relationship at now. Ended
+// relationships stay readable for the retention period.
+func (r *Relationship) CanRead(userID string, now time.Time) bool {
+	if !r.HasPartner(userID) || r.DeletedAt != nil {
+		return false
+	}
+	if r.Status != StatusEnded {
+		return true
+	}
+	return now.Before(*r.ReadableUntil())
+}
+
+// CanWrite reports whether the user may change what the couple shares. Paused
+// and ended relationships are read-only.
+func (r *Relationship) CanWrite(userID string) bool {
+	return r.HasPartner(userID) && r.Status == StatusActive
+}
+
+// Pause makes an active relationship read-only until either partner resumes it
+func (r *Relationship) Pause(userID string, now time.Time) error {
+	if r.Status != StatusActive {
+		return ErrInvalidTransition
+	}
+
+	r.Status = StatusPaused
+	r.UpdatedAt = now
+	r.raiseEvent(NewRelationshipPausedEvent(r.ID, userID))
+	return nil
+}
+
+// Resume makes a paused relationship active again
+func (r *Relationship) Resume(userID string, now time.Time) error {
+	if r.Status != StatusPaused {
+		return ErrInvalidTransition
+	}
+
+	r.Status = StatusActive
+	r.UpdatedAt = now
+	r.raiseEvent(NewRelationshipResumedEvent(r.ID, userID))
+	return nil
+}
+
+// End asks to end the relationship, or confirms the request already made. The
+// other partner confirms right away, the partner who asked only after
+// EndConfirmationDelay, so nobody can be held in a relationship.
+func (r *Relationship) End(userID string, now time.Time) error {
+	if r.Status == StatusEnded {
+		return ErrInvalidTransition
+	}
+
+	if r.Pending == nil {
+		r.request(RequestEnd, userID, now)
+		r.raiseEvent(NewRelationshipEndRequestedEvent(r.ID, userID, *r.Pending.ConfirmableAt()))
+		return nil
+	}
+	if r.Pending.RequestedBy == userID && now.Before(*r.Pending.ConfirmableAt()) {
+		return ErrAwaitingConfirmation
+	}
+
+	requestedBy := r.Pending.RequestedBy
+	r.Status = StatusEnded
+	r.EndedAt = &now
+	r.Pending = nil
+	r.UpdatedAt = now
+	r.raiseEvent(NewRelationshipEndedEvent(r.ID, requestedBy, userID, *r.ReadableUntil()))
+	return nil
+}
+
+// Reconnect asks to make an ended relationship active again, or confirms the
+// request of the other partner. It is only possible during the retention period.
+func (r *Relationship) Reconnect(userID string, now time.Time) error {
+	if r.Status != StatusEnded || !now.Before(*r.ReadableUntil()) {
+		return ErrInvalidTransition
+	}
+
+	if r.Pending == nil {
+		r.request(RequestReconnect, userID, now)
+		r.raiseEvent(NewRelationshipReconnectRequestedEvent(r.ID, userID))
+		return nil
+	}
+	if r.Pending.RequestedBy == userID {
+		return ErrAwaitingConfirmation
+	}
+
+	requestedBy := r.Pending.RequestedBy
+	r.Status = StatusActive
+	r.EndedAt = nil
+	r.Pending = nil
+	r.UpdatedAt = now
+	r.raiseEvent(NewRelationshipReconnectedEvent(r.ID, requestedBy, userID))
+	return nil
+}
+
+// Expire soft-deletes an ended relationship once its retention period is over.
+// Nobody can read or reconnect it from then on.
+func (r *Relationship) Expire(now time.Time) error {
+	if r.Status != StatusEnded || r.DeletedAt != nil || now.Before(*r.ReadableUntil()) {
+		return ErrInvalidTransition
+	}
+
+	r.DeletedAt = &now
+	r.Pending = nil
+	r.UpdatedAt = now
+	r.raiseEvent(NewRelationshipExpiredEvent(r.ID, r.PartnerAID, r.PartnerBID))
+	return nil
+}
+
+// Withdraw drops the pending request of the given kind the user made
+func (r *Relationship) Withdraw(userID string, kind RequestKind, now time.Time) error {
+	if r.Pending == nil || r.Pending.Kind != kind || r.Pending.RequestedBy != userID {
+		return ErrNoPendingRequest
+	}
+
+	r.Pending = nil
+	r.UpdatedAt = now
+	r.raiseEvent(NewRelationshipRequestWithdrawnEvent(r.ID, string(kind), userID))
+	return nil
+}
+
+func (r *Relationship) request(kind RequestKind, userID string, now time.Time) {
+	r.Pending = &Request{
+		Kind:        kind,
+		RequestedBy: userID,
+		RequestedAt: now,
+	}
+	r.UpdatedAt = now
+}
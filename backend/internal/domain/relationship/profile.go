@@ -0,0 +1,217 @@
+package relationship
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/ids"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	maxTitleLength = 100
+	minSlugLength  = 3
+	maxSlugLength  = 60
+
+	// AnniversaryLayout is the format of anniversary dates, they have no time or zone
+	AnniversaryLayout = "2006-01-02"
+)
+
+var (
+	slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+	// earliestAnniversary rejects dates that can only be typos
+	earliestAnniversary = time.Date(1900, time.January, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// reservedSlugs name pages of the app, or could pass for an official account
+var reservedSlugs = map[string]struct{}{
+	"about": {}, "account": {}, "admin": {}, "amora": {}, "api": {}, "app": {},
+	"assets": {}, "auth": {}, "blog": {}, "by-slug": {}, "contact": {}, "edit": {},
+	"help": {}, "home": {}, "invite": {}, "invites": {}, "invite-codes": {}, "legal": {},
+	"login": {}, "logout": {}, "me": {}, "new": {}, "privacy": {}, "register": {},
+	"relationship": {}, "relationships": {}, "root": {}, "security": {}, "settings": {},
+	"signup": {}, "static": {}, "staff": {}, "support": {}, "system": {}, "terms": {},
+	"well-known": {}, "www": {},
+}
+
+// ProfilePatch holds a partial profile update. Nil fields are left unchanged,
+// empty strings clear them.
+type ProfilePatch struct {
+	Title         *string
+	Slug          *string
+	AnniversaryAt *string
+	AvatarPhotoID *string
+	BannerPhotoID *string
+}
+
+// UpdateProfile validates and applies the patch of one of the partners, the
+// relationship is untouched on error
+func (r *Relationship) UpdateProfile(userID string, patch ProfilePatch, now time.Time) error {
+	if !r.HasPartner(userID) {
+		return ErrNotFound
+	}
+	if !r.CanWrite(userID) {
+		return ErrReadOnly
+	}
+
+	updated := *r
+	changed := make([]string, 0)
+
+	if patch.Title != nil {
+		title := strings.TrimSpace(*patch.Title)
+		if utf8.RuneCountInString(title) > maxTitleLength {
+			return fmt.Errorf("%w: title too long (max %d characters)", ErrInvalidProfile, maxTitleLength)
+		}
+		updated.Title = title
+		changed = append(changed, "title")
+	}
+
+	if patch.Slug != nil {
+		slug := NormalizeSlug(*patch.Slug)
+		if slug != "" {
+			if err := ValidateSlug(slug); err != nil {
+				return err
+			}
+		}
+		updated.Slug = slug
+		changed = append(changed, "slug")
+	}
+
+	if patch.AnniversaryAt != nil {
+		anniversaryAt, err := r.parseAnniversary(*patch.AnniversaryAt)
+		if err != nil {
+			return err
+		}
+		updated.AnniversaryAt = anniversaryAt
+		changed = append(changed, "anniversary_at")
+	}
+
+	if patch.AvatarPhotoID != nil {
+		if err := validatePhotoID(*patch.AvatarPhotoID); err != nil {
+			return err
+		}
+		updated.AvatarPhotoID = *patch.AvatarPhotoID
+		changed = append(changed, "avatar_photo_id")
+	}
+
+	if patch.BannerPhotoID != nil {
+		if err := validatePhotoID(*patch.BannerPhotoID); err != nil {
+			return err
+		}
+		updated.BannerPhotoID = *patch.BannerPhotoID
+		changed = append(changed, "banner_photo_id")
+	}
+
+	updated.UpdatedAt = now
+	*r = updated
+	r.raiseEvent(NewRelationshipProfileUpdatedEvent(r.ID, userID, changed))
+	return nil
+}
+
+// parseAnniversary reads a date the couple celebrates. It cannot be after the
+// relationship started in the app, the couple was together by then.
+func (r *Relationship) parseAnniversary(value string) (*time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+
+	anniversaryAt, err := time.Parse(AnniversaryLayout, value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: anniversary must be a date like 2024-02-14", ErrInvalidProfile)
+	}
+	if anniversaryAt.Before(earliestAnniversary) {
+		return nil, fmt.Errorf("%w: anniversary is too far in the past", ErrInvalidProfile)
+	}
+
+	startedOn := r.StartedAt.UTC().Truncate(24 * time.Hour)
+	if anniversaryAt.After(startedOn) {
+		return nil, fmt.Errorf("%w: anniversary cannot be after the relationship started on %s",
+			ErrInvalidProfile, startedOn.Format(AnniversaryLayout))
+	}
+
+	return &anniversaryAt, nil
+}
+
+func validatePhotoID(photoID string) error {
+	if photoID != "" && !ids.IsValid(photoID) {
+		return fmt.Errorf("%w: photo id is invalid", ErrInvalidProfile)
+	}
+	return nil
+}
+
+// NormalizeSlug returns the slug as stored, slugs are matched without case
+func NormalizeSlug(slug string) string {
+	return strings.ToLower(strings.TrimSpace(slug))
+}
+
+// ValidateSlug checks a normalized slug
+func ValidateSlug(slug string) error {
+	if len(slug) < minSlugLength || len(slug) > maxSlugLength {
+		return fmt.Errorf("%w: slug must be between %d and %d characters", ErrInvalidProfile, minSlugLength, maxSlugLength)
+	}
+	if !slugPattern.MatchString(slug) {
+		return fmt.Errorf("%w: slug can only contain lowercase letters, numbers and single hyphens", ErrInvalidProfile)
+	}
+	if IsReservedSlug(slug) {
+		return ErrReservedSlug
+	}
+	return nil
+}
+
+// IsReservedSlug reports whether the slug is kept for the app
+func IsReservedSlug(slug string) bool {
+	_, reserved := reservedSlugs[slug]
+	return reserved
+}
+
+// SlugFromTitle turns a title into a slug, dropping accents and punctuation.
+// It returns "" when too little of the title is left.
+func SlugFromTitle(title string) string {
+	var b strings.Builder
+	separate := false
+	for _, r := range norm.NFD.String(strings.ToLower(title)) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			// Accents are split off the letter they belong to
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			if separate && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			separate = false
+			b.WriteRune(r)
+		default:
+			separate = true
+		}
+	}
+
+	slug := truncateSlug(b.String(), maxSlugLength)
+	if len(slug) < minSlugLength {
+		return ""
+	}
+	return slug
+}
+
+// SlugCandidates returns the slug followed by numbered variants to try when it is taken
+func SlugCandidates(slug string, count int) []string {
+	candidates := make([]string, 0, count)
+	candidates = append(candidates, slug)
+	for n := 2; len(candidates) < count; n++ {
+		suffix := "-" + strconv.Itoa(n)
+		candidates = append(candidates, truncateSlug(slug, maxSlugLength-len(suffix))+suffix)
+	}
+	return candidates
+}
+
+func truncateSlug(slug string, length int) string {
+	if len(slug) > length {
+		slug = slug[:length]
+	}
+	return strings.TrimRight(slug, "-")
+}
@@ -0,0 +1,120 @@
+package relationship
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/ids"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/user"
+)
+
+type Status string
+
+const (
+	StatusActive Status = "active"
+	StatusPaused Status = "paused"
+	StatusEnded  Status = "ended"
+)
+
+type Visibility string
+
+const (
+	VisibilityPrivate Visibility = "private"
+	VisibilityFriends Visibility = "friends"
+	VisibilityPublic  Visibility = "public"
+)
+
+// Relationship is the aggregate root shared by two partners
+type Relationship struct {
+	ID string
+
+	// PartnerAID sorts before PartnerBID, so a couple is stored the same way
+	// whoever invited whom
+	PartnerAID string
+	PartnerBID string
+
+	Status     Status
+	Visibility Visibility
+
+	// Profile shared by both partners, empty values are unset
+	Title         string
+	Slug          string
+	AnniversaryAt *time.Time
+	AvatarPhotoID string
+	BannerPhotoID string
+
+	CreatedBy string
+	StartedAt time.Time
+	EndedAt   *time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	// DeletedAt is set once the retention period of an ended relationship is over
+	DeletedAt *time.Time
+
+	// Pending is the end or reconnect one partner asked for, nil when nothing waits for confirmation
+	Pending *Request
+
+	// Version is the stored version the relationship was loaded at, 0 until created.
+	// Update only succeeds while the stored version still matches.
+	Version int64
+
+	events []user.DomainEvent
+}
+
+// NewRelationship starts an active relationship between the two users
+func NewRelationship(partnerID, otherPartnerID, createdBy string) (*Relationship, error) {
+	if partnerID == "" || otherPartnerID == "" {
+		return nil, fmt.Errorf("%w: both partners are required", ErrInvalidInvite)
+	}
+	if partnerID == otherPartnerID {
+		return nil, ErrSelfInvite
+	}
+
+	partnerA, partnerB := partnerID, otherPartnerID
+	if partnerB < partnerA {
+		partnerA, partnerB = partnerB, partnerA
+	}
+
+	now := time.Now()
+	relationship := &Relationship{
+		ID:         ids.New(),
+		PartnerAID: partnerA,
+		PartnerBID: partnerB,
+		Status:     StatusActive,
+		Visibility: VisibilityPrivate,
+		CreatedBy:  createdBy,
+		StartedAt:  now,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+		events:     make([]user.DomainEvent, 0),
+	}
+
+	relationship.raiseEvent(NewRelationshipCreatedEvent(relationship.ID, partnerA, partnerB))
+	return relationship, nil
+}
+
+// HasPartner reports whether the user is one of the partners
+func (r *Relationship) HasPartner(userID string) bool {
+	return r.PartnerAID == userID || r.PartnerBID == userID
+}
+
+// PartnerOf returns the other partner of the user
+func (r *Relationship) PartnerOf(userID string) string {
+	if r.PartnerAID == userID {
+		return r.PartnerBID
+	}
+	return r.PartnerAID
+}
+
+func (r *Relationship) raiseEvent(event user.DomainEvent) {
+	r.events = append(r.events, event)
+}
+
+func (r *Relationship) GetEvents() []user.DomainEvent {
+	return r.events
+}
+
+func (r *Relationship) ClearEvents() {
+	r.events = make([]user.DomainEvent, 0)
+}
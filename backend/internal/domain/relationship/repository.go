@@ -0,0 +1,45 @@
+package relationship
+
+import (
+	"context"
+	"time"
+)
+
+type Repository interface {
+	Create(ctx context.Context, relationship *Relationship) error
+	GetByID(ctx context.Context, id string) (*Relationship, error)
+
+	// Update stores the relationship if it still has the version it was loaded at.
+	// A replaced slug is kept in the history of the relationship.
+	Update(ctx context.Context, relationship *Relationship) error
+
+	// GetBySlug returns the relationship using the slug now or before, compare its
+	// Slug to tell them apart
+	GetBySlug(ctx context.Context, slug string) (*Relationship, error)
+
+	// TakenSlugs returns the slugs other relationships use now or used before
+	TakenSlugs(ctx context.Context, relationshipID string, slugs []string) ([]string, error)
+
+	// ListByPartner returns the relationships the user is or was a partner in, newest first
+	ListByPartner(ctx context.Context, userID string) ([]*Relationship, error)
+
+	// ListExpired returns up to limit ended relationships not deleted yet that
+	// ended before endedBefore, oldest first
+	ListExpired(ctx context.Context, endedBefore time.Time, limit int) ([]*Relationship, error)
+}
+
+type InviteRepository interface {
+	Create(ctx context.Context, invite *Invite) error
+	GetByID(ctx context.Context, id string) (*Invite, error)
+	GetByCodeHash(ctx context.Context, codeHash string) (*Invite, error)
+
+	// Update stores the answer to an invite that is still pending in storage and
+	// returns ErrInviteNotPending once another answer or a cancel got there first
+	Update(ctx context.Context, invite *Invite) error
+
+	// ListPending returns the invites the user sent or received that are still pending at now, newest first
+	ListPending(ctx context.Context, userID string, now time.Time) ([]*Invite, error)
+
+	// HasPendingBetween reports whether either user has a pending invite to the other at now
+	HasPendingBetween(ctx context.Context, userID, otherUserID string, now time.Time) (bool, error)
+}
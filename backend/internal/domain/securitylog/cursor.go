@@ -0,0 +1,57 @@
+package securitylog
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/ids"
+)
+
+var ErrInvalidCursor = errors.New("invalid security log cursor")
+
+// Cursor points at the last entry of a page. Entries sharing its time are told
+// apart by ID, so none is skipped when a page ends in the middle of a tie.
+type Cursor struct {
+	OccurredAt time.Time
+	ID         string
+}
+
+// CursorOf returns the cursor of the page ending at the entry
+func CursorOf(entry *Entry) Cursor {
+	return Cursor{OccurredAt: entry.OccurredAt, ID: entry.ID}
+}
+
+// String encodes the cursor as an opaque token for clients
+func (c Cursor) String() string {
+	raw := c.OccurredAt.UTC().Format(time.RFC3339Nano) + "|" + c.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ParseCursor decodes a token created by Cursor.String
+func ParseCursor(token string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	occurredAt, id, found := strings.Cut(string(raw), "|")
+	if !found || !ids.IsValid(id) {
+		return nil, ErrInvalidCursor
+	}
+	parsed, err := time.Parse(time.RFC3339Nano, occurredAt)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return &Cursor{OccurredAt: parsed, ID: id}, nil
+}
+
+// IsAfter reports whether the entry comes after the cursor in newest first order
+func (c Cursor) IsAfter(entry *Entry) bool {
+	if entry.OccurredAt.Equal(c.OccurredAt) {
+		return entry.ID < c.ID
+	}
+	return entry.OccurredAt.Before(c.OccurredAt)
+}
@@ -0,0 +1,54 @@
+package securitylog
+
+import (
+	"errors"
+	"time"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/ids"
+)
+
+type EventType string
+
+const (
+	EventLoginSucceeded  EventType = "login_succeeded"
+	EventLoginFailed     EventType = "login_failed"
+	EventMFAFailed       EventType = "mfa_failed"
+	EventMFAEnabled      EventType = "mfa_enabled"
+	EventMFADisabled     EventType = "mfa_disabled"
+	EventPasswordChanged EventType = "password_changed"
+	EventSessionRevoked  EventType = "session_revoked"
+)
+
+// Entry is a single append-only record of security relevant account activity
+type Entry struct {
+	ID        string
+	UserID    string
+	EventType EventType
+	IPAddress string
+	UserAgent string
+	RequestID string
+
+	// Coarse location resolved from the IP address
+	Country string
+	City    string
+
+	Metadata   map[string]string
+	OccurredAt time.Time
+}
+
+func NewEntry(userID string, eventType EventType, occurredAt time.Time) (*Entry, error) {
+	if userID == "" {
+		return nil, errors.New("user ID is required")
+	}
+	if eventType == "" {
+		return nil, errors.New("event type is required")
+	}
+
+	return &Entry{
+		ID:         ids.New(),
+		UserID:     userID,
+		EventType:  eventType,
+		Metadata:   make(map[string]string),
+		OccurredAt: occurredAt,
+	}, nil
+}
@@ -0,0 +1,13 @@
+package securitylog
+
+import "context"
+
+// Repository is append-only, entries can never be changed or removed
+type Repository interface {
+	// Append records the entry once, appending an ID that already exists is a no-op
+	// so redelivered events do not log twice
+	Append(ctx context.Context, entry *Entry) error
+
+	// ListByUserID returns the newest entries first, optionally only those after the cursor
+	ListByUserID(ctx context.Context, userID string, before *Cursor, limit int) ([]*Entry, error)
+}
@@ -0,0 +1,83 @@
+package session
+
+import (
+	"crypto/subtle"
+	"errors"
+	"time"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/ids"
+)
+
+// Device is a client the user has logged in from
+type Device struct {
+	ID              string
+	UserID          string
+	Fingerprint     string
+	UserAgentFamily string
+	IPBlock         string
+	FirstSeenAt     time.Time
+	LastSeenAt      time.Time
+
+	// TrustedUntil lets the device skip the MFA step until it passes, but only
+	// for a client presenting the trust token whose hash is TrustTokenHash.
+	// The fingerprint alone is easy to copy, it merely finds the device.
+	TrustedUntil   *time.Time
+	TrustTokenHash string
+}
+
+func NewDevice(userID string, fingerprint Fingerprint) (*Device, error) {
+	if userID == "" {
+		return nil, errors.New("user ID is required")
+	}
+
+	now := time.Now()
+	return &Device{
+		ID:              ids.New(),
+		UserID:          userID,
+		Fingerprint:     fingerprint.Hash(),
+		UserAgentFamily: fingerprint.UserAgentFamily,
+		IPBlock:         fingerprint.IPBlock,
+		FirstSeenAt:     now,
+		LastSeenAt:      now,
+	}, nil
+}
+
+// HasTrust checks if the device is still trusted at the given time, whoever presents it
+func (d *Device) HasTrust(at time.Time) bool {
+	return d.TrustedUntil != nil && at.Before(*d.TrustedUntil) && d.TrustTokenHash != ""
+}
+
+// IsTrusted checks if the client presenting the trust token may skip the MFA step at the given time
+func (d *Device) IsTrusted(trustToken string, at time.Time) bool {
+	if !d.HasTrust(at) || trustToken == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(HashToken(trustToken)), []byte(d.TrustTokenHash)) == 1
+}
+
+// Trust lets the device skip the MFA step for the given period and returns the
+// plain trust token the client must present, only its hash is stored
+func (d *Device) Trust(period time.Duration) (string, error) {
+	if period <= 0 {
+		return "", errors.New("trust period must be positive")
+	}
+
+	trustToken, err := generateToken()
+	if err != nil {
+		return "", err
+	}
+
+	trustedUntil := time.Now().Add(period)
+	d.TrustedUntil = &trustedUntil
+	d.TrustTokenHash = HashToken(trustToken)
+	return trustToken, nil
+}
+
+func (d *Device) Untrust() {
+	d.TrustedUntil = nil
+	d.TrustTokenHash = ""
+}
+
+func (d *Device) MarkSeen() {
+	d.LastSeenAt = time.Now()
+}
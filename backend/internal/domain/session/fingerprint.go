@@ -0,0 +1,84 @@
+package session
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"strings"
+)
+
+// Fingerprint identifies a device coarsely by its user agent family and network block,
+// so browser updates and DHCP changes inside the same network keep the same device
+type Fingerprint struct {
+	UserAgentFamily string
+	IPBlock         string
+}
+
+func NewFingerprint(userAgent, ipAddress string) Fingerprint {
+	return Fingerprint{
+		UserAgentFamily: userAgentFamily(userAgent),
+		IPBlock:         ipBlock(ipAddress),
+	}
+}
+
+// Hash returns the stable identifier stored for the device
+func (f Fingerprint) Hash() string {
+	sum := sha256.Sum256([]byte(f.UserAgentFamily + "|" + f.IPBlock))
+	return hex.EncodeToString(sum[:])
+}
+
+// String returns a human readable description used in alerts
+func (f Fingerprint) String() string {
+	return f.UserAgentFamily + " from " + f.IPBlock
+}
+
+// userAgentFamily reduces a user agent to "<client> on <os>"
+func userAgentFamily(userAgent string) string {
+	ua := strings.ToLower(userAgent)
+
+	client := "Unknown client"
+	switch {
+	case strings.Contains(ua, "okhttp"), strings.Contains(ua, "cfnetwork"), strings.Contains(ua, "expo"):
+		client = "Amora app"
+	case strings.Contains(ua, "edg/"):
+		client = "Edge"
+	case strings.Contains(ua, "opr/"), strings.Contains(ua, "opera"):
+		client = "Opera"
+	case strings.Contains(ua, "firefox/"):
+		client = "Firefox"
+	case strings.Contains(ua, "chrome/"), strings.Contains(ua, "crios/"):
+		client = "Chrome"
+	case strings.Contains(ua, "safari/"):
+		client = "Safari"
+	}
+
+	os := "unknown OS"
+	switch {
+	case strings.Contains(ua, "iphone"), strings.Contains(ua, "ipad"), strings.Contains(ua, "ios"), strings.Contains(ua, "darwin"):
+		os = "iOS"
+	case strings.Contains(ua, "android"):
+		os = "Android"
+	case strings.Contains(ua, "windows"):
+		os = "Windows"
+	case strings.Contains(ua, "mac os"), strings.Contains(ua, "macintosh"):
+		os = "macOS"
+	case strings.Contains(ua, "linux"):
+		os = "Linux"
+	}
+
+	return client + " on " + os
+}
+
+// ipBlock masks the address to its /24 (IPv4) or /48 (IPv6) network
+func ipBlock(ipAddress string) string {
+	ip := net.ParseIP(ipAddress)
+	if ip == nil {
+		return "unknown network"
+	}
+
+	if v4 := ip.To4(); v4 != nil {
+		return (&net.IPNet{IP: v4.Mask(net.CIDRMask(24, 32)), Mask: net.CIDRMask(24, 32)}).String()
+	}
+
+	return (&net.IPNet{IP: ip.Mask(net.CIDRMask(48, 128)), Mask: net.CIDRMask(48, 128)}).String()
+}
@@ -0,0 +1,50 @@
+package session
+
+import (
+	"errors"
+	"time"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/ids"
+)
+
+const (
+	// MFAChallengeTTL bounds the time between the password and the MFA step
+	MFAChallengeTTL = 5 * time.Minute
+
+	// MaxMFAAttempts wrong codes burn a challenge, the login has to start over
+	MaxMFAAttempts = 5
+)
+
+var (
+	ErrChallengeNotFound = errors.New("MFA challenge not found")
+	ErrChallengeClosed   = errors.New("MFA challenge was already used, burnt or expired")
+)
+
+// MFAChallenge is the pending MFA step of a login that passed the password step.
+// It is answered at most once, so the MFA token handed out for it is single-use.
+type MFAChallenge struct {
+	ID             string
+	UserID         string
+	Fingerprint    string
+	FailedAttempts int
+	ExpiresAt      time.Time
+	CompletedAt    *time.Time
+}
+
+func NewMFAChallenge(userID string, fingerprint Fingerprint) (*MFAChallenge, error) {
+	if userID == "" {
+		return nil, errors.New("user ID is required")
+	}
+
+	return &MFAChallenge{
+		ID:          ids.New(),
+		UserID:      userID,
+		Fingerprint: fingerprint.Hash(),
+		ExpiresAt:   time.Now().Add(MFAChallengeTTL),
+	}, nil
+}
+
+// IsOpen checks if the challenge may still be answered at the given time
+func (c *MFAChallenge) IsOpen(at time.Time) bool {
+	return c.CompletedAt == nil && c.FailedAttempts < MaxMFAAttempts && at.Before(c.ExpiresAt)
+}
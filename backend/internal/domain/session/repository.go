@@ -0,0 +1,34 @@
+package session
+
+import (
+	"context"
+	"time"
+)
+
+type Repository interface {
+	Create(ctx context.Context, session *Session) error
+	GetByID(ctx context.Context, id string) (*Session, error)
+	GetByRevokeTokenHash(ctx context.Context, tokenHash string) (*Session, error)
+	Update(ctx context.Context, session *Session) error
+}
+
+type DeviceRepository interface {
+	Create(ctx context.Context, device *Device) error
+	GetByID(ctx context.Context, id string) (*Device, error)
+	GetByFingerprint(ctx context.Context, userID, fingerprint string) (*Device, error)
+	ListByUserID(ctx context.Context, userID string) ([]*Device, error)
+	Update(ctx context.Context, device *Device) error
+}
+
+// MFAChallengeRepository changes challenges with conditional updates, so two
+// requests answering the same challenge cannot both complete it
+type MFAChallengeRepository interface {
+	Create(ctx context.Context, challenge *MFAChallenge) error
+	GetByID(ctx context.Context, id string) (*MFAChallenge, error)
+
+	// RecordFailure counts a wrong code, the challenge closes at MaxMFAAttempts
+	RecordFailure(ctx context.Context, id string) error
+
+	// Complete closes an open challenge, ErrChallengeClosed when it was not open
+	Complete(ctx context.Context, id string, at time.Time) error
+}
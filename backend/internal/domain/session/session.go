@@ -0,0 +1,109 @@
+package session
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/ids"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/user"
+)
+
+var (
+	ErrNotFound       = errors.New("session not found")
+	ErrDeviceNotFound = errors.New("device not found")
+	ErrAlreadyRevoked = errors.New("session is already revoked")
+
+	// ErrNotCurrentDevice is returned when trusting a device other than the caller's,
+	// its trust token could never reach it
+	ErrNotCurrentDevice = errors.New("only the device in use can be trusted")
+)
+
+// Session is a single login of a user on a device
+type Session struct {
+	ID           string
+	UserID       string
+	DeviceID     string
+	UserAgent    string
+	IPAddress    string
+	CreatedAt    time.Time
+	LastUsedAt   time.Time
+	RevokedAt    *time.Time
+	TokenVersion int
+
+	// RevokeTokenHash backs the "this wasn't me" link sent in new device alerts
+	RevokeTokenHash string
+
+	events []user.DomainEvent
+}
+
+// NewSession starts a session and returns the plain revoke token, only its hash is stored
+func NewSession(userID, deviceID, userAgent, ipAddress string) (*Session, string, error) {
+	if userID == "" {
+		return nil, "", errors.New("user ID is required")
+	}
+
+	revokeToken, err := generateToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	now := time.Now()
+	return &Session{
+		ID:              ids.New(),
+		UserID:          userID,
+		DeviceID:        deviceID,
+		UserAgent:       userAgent,
+		IPAddress:       ipAddress,
+		CreatedAt:       now,
+		LastUsedAt:      now,
+		RevokeTokenHash: HashToken(revokeToken),
+		events:          make([]user.DomainEvent, 0),
+	}, revokeToken, nil
+}
+
+func (s *Session) IsActive() bool {
+	return s.RevokedAt == nil
+}
+
+// Revoke ends the session, tokens issued for it stop being accepted
+func (s *Session) Revoke(reason string) error {
+	if !s.IsActive() {
+		return ErrAlreadyRevoked
+	}
+
+	now := time.Now()
+	s.RevokedAt = &now
+	s.TokenVersion++
+	s.raiseEvent(user.NewSessionRevokedEvent(s.UserID, s.ID, reason))
+	return nil
+}
+
+func (s *Session) raiseEvent(event user.DomainEvent) {
+	s.events = append(s.events, event)
+}
+
+func (s *Session) GetEvents() []user.DomainEvent {
+	return s.events
+}
+
+func (s *Session) ClearEvents() {
+	s.events = make([]user.DomainEvent, 0)
+}
+
+// HashToken returns the stored form of a revoke or device trust token
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func generateToken() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", errors.New("failed to generate session token")
+	}
+	return base64.RawURLEncoding.EncodeToString(bytes), nil
+}
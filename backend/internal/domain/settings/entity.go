@@ -0,0 +1,197 @@
+package settings
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// CurrentSchemaVersion is bumped whenever the settings layout changes
+const CurrentSchemaVersion = 1
+
+var (
+	ErrNotFound        = errors.New("settings not found")
+	ErrInvalidSettings = errors.New("invalid settings")
+
+	// ErrConcurrentModification is returned when the settings changed since they were loaded
+	ErrConcurrentModification = errors.New("settings were modified concurrently")
+)
+
+type Theme string
+
+const (
+	ThemeAuto  Theme = "auto"
+	ThemeLight Theme = "light"
+	ThemeDark  Theme = "dark"
+)
+
+type WeekStart string
+
+const (
+	WeekStartMonday WeekStart = "monday"
+	WeekStartSunday WeekStart = "sunday"
+)
+
+type Units string
+
+const (
+	UnitsMetric   Units = "metric"
+	UnitsImperial Units = "imperial"
+)
+
+type Visibility string
+
+const (
+	VisibilityPrivate Visibility = "private"
+	VisibilityFriends Visibility = "friends"
+	VisibilityPublic  Visibility = "public"
+)
+
+// Notifications holds per-category notification toggles
+type Notifications struct {
+	Enabled         bool `json:"enabled"`
+	Posts           bool `json:"posts"`
+	Calendar        bool `json:"calendar"`
+	Notes           bool `json:"notes"`
+	Mood            bool `json:"mood"`
+	PartnerActivity bool `json:"partner_activity"`
+}
+
+// Privacy holds defaults applied to newly created content
+type Privacy struct {
+	DefaultPostVisibility Visibility `json:"default_post_visibility"`
+	ShowOnlineStatus      bool       `json:"show_online_status"`
+}
+
+// UserSettings is the aggregate root for per-user app settings
+type UserSettings struct {
+	UserID        string        `json:"-"`
+	SchemaVersion int           `json:"schema_version"`
+	Theme         Theme         `json:"theme"`
+	Notifications Notifications `json:"notifications"`
+	WeekStart     WeekStart     `json:"week_start"`
+	Units         Units         `json:"units"`
+	Privacy       Privacy       `json:"privacy"`
+
+	// Revision increases on every change so devices can detect stale copies
+	Revision  int64     `json:"-"`
+	UpdatedAt time.Time `json:"-"`
+}
+
+// Default returns the server defaults for a user without stored settings
+func Default(userID string) *UserSettings {
+	return &UserSettings{
+		UserID:        userID,
+		SchemaVersion: CurrentSchemaVersion,
+		Theme:         ThemeAuto,
+		Notifications: Notifications{
+			Enabled:         true,
+			Posts:           true,
+			Calendar:        true,
+			Notes:           true,
+			Mood:            true,
+			PartnerActivity: true,
+		},
+		WeekStart: WeekStartMonday,
+		Units:     UnitsMetric,
+		Privacy: Privacy{
+			DefaultPostVisibility: VisibilityPrivate,
+			ShowOnlineStatus:      true,
+		},
+	}
+}
+
+// Patch holds a partial update, nil fields are left unchanged
+type Patch struct {
+	Theme                 *string
+	NotificationsEnabled  *bool
+	NotifyPosts           *bool
+	NotifyCalendar        *bool
+	NotifyNotes           *bool
+	NotifyMood            *bool
+	NotifyPartnerActivity *bool
+	WeekStart             *string
+	Units                 *string
+	DefaultPostVisibility *string
+	ShowOnlineStatus      *bool
+}
+
+// Apply validates and applies the patch, the settings are untouched on error
+func (s *UserSettings) Apply(patch Patch) error {
+	updated := *s
+
+	if patch.Theme != nil {
+		updated.Theme = Theme(*patch.Theme)
+	}
+	if patch.WeekStart != nil {
+		updated.WeekStart = WeekStart(*patch.WeekStart)
+	}
+	if patch.Units != nil {
+		updated.Units = Units(*patch.Units)
+	}
+	if patch.DefaultPostVisibility != nil {
+		updated.Privacy.DefaultPostVisibility = Visibility(*patch.DefaultPostVisibility)
+	}
+	setBool(&updated.Notifications.Enabled, patch.NotificationsEnabled)
+	setBool(&updated.Notifications.Posts, patch.NotifyPosts)
+	setBool(&updated.Notifications.Calendar, patch.NotifyCalendar)
+	setBool(&updated.Notifications.Notes, patch.NotifyNotes)
+	setBool(&updated.Notifications.Mood, patch.NotifyMood)
+	setBool(&updated.Notifications.PartnerActivity, patch.NotifyPartnerActivity)
+	setBool(&updated.Privacy.ShowOnlineStatus, patch.ShowOnlineStatus)
+
+	if err := updated.Validate(); err != nil {
+		return err
+	}
+
+	updated.Revision++
+	updated.UpdatedAt = time.Now()
+	*s = updated
+	return nil
+}
+
+// Validate checks every enumerated value
+func (s *UserSettings) Validate() error {
+	switch s.Theme {
+	case ThemeAuto, ThemeLight, ThemeDark:
+	default:
+		return fmt.Errorf("%w: theme must be one of auto, light, dark", ErrInvalidSettings)
+	}
+
+	switch s.WeekStart {
+	case WeekStartMonday, WeekStartSunday:
+	default:
+		return fmt.Errorf("%w: week start must be monday or sunday", ErrInvalidSettings)
+	}
+
+	switch s.Units {
+	case UnitsMetric, UnitsImperial:
+	default:
+		return fmt.Errorf("%w: units must be metric or imperial", ErrInvalidSettings)
+	}
+
+	switch s.Privacy.DefaultPostVisibility {
+	case VisibilityPrivate, VisibilityFriends, VisibilityPublic:
+	default:
+		return fmt.Errorf("%w: default post visibility must be one of private, friends, public", ErrInvalidSettings)
+	}
+
+	return nil
+}
+
+// Upgrade migrates settings stored with an older schema to the current one.
+// Fields added after the stored version keep their default values.
+func (s *UserSettings) Upgrade() error {
+	if s.SchemaVersion > CurrentSchemaVersion {
+		return fmt.Errorf("%w: unknown schema version %d", ErrInvalidSettings, s.SchemaVersion)
+	}
+
+	s.SchemaVersion = CurrentSchemaVersion
+	return nil
+}
+
+func setBool(target *bool, value *bool) {
+	if value != nil {
+		*target = *value
+	}
+}
@@ -0,0 +1,11 @@
+package settings
+
+import "context"
+
+type Repository interface {
+	// GetByUserID returns ErrNotFound when the user has never saved settings
+	GetByUserID(ctx context.Context, userID string) (*UserSettings, error)
+	// Save stores settings changed by Apply. It returns ErrConcurrentModification
+	// when another change was saved since the settings were loaded.
+	Save(ctx context.Context, settings *UserSettings) error
+}
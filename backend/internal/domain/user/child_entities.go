@@ -12,6 +12,9 @@ type Credentials struct {
 	MfaEnabled    bool
 	MfaSecret     *string
 	LastLoginAt   *time.Time
+
+	// MfaLastStep is the TOTP time step of the last accepted code
+	MfaLastStep int64
 }
 
 type Profile struct {
@@ -53,3 +56,29 @@ func (u *User) UpdateProfile(firstName, lastName, bio string, gender Gender) err
 
 	return nil
 }
+
+// InRelationship reports whether the user already has a partner
+func (u *User) InRelationship() bool {
+	return u.Profile.RelationshipID != nil
+}
+
+// JoinRelationship links the profile to the relationship the user is a partner in
+func (u *User) JoinRelationship(relationshipID string) error {
+	if u.InRelationship() {
+		return ErrInRelationship
+	}
+
+	u.Profile.RelationshipID = &relationshipID
+	u.UpdatedAt = time.Now()
+	return nil
+}
+
+// LeaveRelationship unlinks the profile from the relationship, if it is the one linked
+func (u *User) LeaveRelationship(relationshipID string) {
+	if u.Profile.RelationshipID == nil || *u.Profile.RelationshipID != relationshipID {
+		return
+	}
+
+	u.Profile.RelationshipID = nil
+	u.UpdatedAt = time.Now()
+}
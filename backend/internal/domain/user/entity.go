@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"time"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/ids"
 )
 
 const (
@@ -14,9 +16,14 @@ const (
 // User is aggregate root for the user bounded context
 type User struct {
 	ID        string
+	Role      Role
 	CreatedAt time.Time
 	UpdatedAt time.Time
 
+	// Version is the stored version the user was loaded at, 0 until created.
+	// Update only succeeds while the stored version still matches.
+	Version int64
+
 	Credentials Credentials
 	Profile     Profile
 
@@ -50,7 +57,8 @@ func NewUser(email, username, firstName, lastName, password string) (*User, erro
 
 	now := time.Now()
 	user := &User{
-		// ID will be set by the database/repository layer
+		ID:        ids.New(),
+		Role:      RoleMember,
 		CreatedAt: now,
 		UpdatedAt: now,
 		Credentials: Credentials{
@@ -70,7 +78,7 @@ func NewUser(email, username, firstName, lastName, password string) (*User, erro
 	}
 
 	// Raise domain event
-	user.raiseEvent(NewUserCreatedEvent("", email, username, firstName, lastName))
+	user.raiseEvent(NewUserCreatedEvent(user.ID, email, username, firstName, lastName))
 
 	return user, nil
 }
@@ -89,6 +97,36 @@ func (u *User) ChangePassword(currentPassword, newPassword string) error {
 
 	u.Credentials.PasswordHash = newHash
 	u.UpdatedAt = time.Now()
+	u.raiseEvent(NewUserPasswordChangedEvent(u.ID))
+	return nil
+}
+
+// EnableMFA turns on two-factor authentication with the given secret
+func (u *User) EnableMFA(secret string) error {
+	if secret == "" {
+		return errors.New("MFA secret is required")
+	}
+	if u.Credentials.MfaEnabled {
+		return errors.New("MFA is already enabled")
+	}
+
+	u.Credentials.MfaEnabled = true
+	u.Credentials.MfaSecret = &secret
+	u.UpdatedAt = time.Now()
+	u.raiseEvent(NewUserMFAEnabledEvent(u.ID))
+	return nil
+}
+
+// DisableMFA turns off two-factor authentication and drops the secret
+func (u *User) DisableMFA() error {
+	if !u.Credentials.MfaEnabled {
+		return errors.New("MFA is not enabled")
+	}
+
+	u.Credentials.MfaEnabled = false
+	u.Credentials.MfaSecret = nil
+	u.UpdatedAt = time.Now()
+	u.raiseEvent(NewUserMFADisabledEvent(u.ID))
 	return nil
 }
 
@@ -102,6 +140,36 @@ func (u *User) IsEmailVerified() bool {
 	return u.Credentials.EmailVerified
 }
 
+// ChangeRole assigns a new role to the user
+func (u *User) ChangeRole(role Role) error {
+	if !role.IsValid() {
+		return errors.New("invalid role")
+	}
+	if u.Role == role {
+		return nil
+	}
+
+	previous := u.Role
+	u.Role = role
+	u.UpdatedAt = time.Now()
+	u.raiseEvent(NewUserRoleChangedEvent(u.ID, previous.String(), role.String()))
+	return nil
+}
+
+func (u *User) IsAdmin() bool {
+	return u.Role == RoleAdmin
+}
+
+// HasPermission checks if the user's role grants the permission
+func (u *User) HasPermission(permission Permission) bool {
+	return u.Role.HasPermission(permission)
+}
+
+// Permissions returns every permission granted by the user's role
+func (u *User) Permissions() []Permission {
+	return u.Role.Permissions()
+}
+
 func (u *User) GetFullName() string {
 	return fmt.Sprintf("%s %s", u.Profile.FirstName, u.Profile.LastName)
 }
@@ -114,6 +182,16 @@ func (u *User) RecordLogin(ipAddress, userAgent string) {
 	u.raiseEvent(NewUserLoggedInEvent(u.ID, u.Credentials.Email.String(), u.Credentials.Username.String(), ipAddress, userAgent))
 }
 
+// RecordFailedLogin records a wrong password attempt against the account
+func (u *User) RecordFailedLogin(ipAddress, userAgent string) {
+	u.raiseEvent(NewUserLoginFailedEvent(u.ID, ipAddress, userAgent))
+}
+
+// RecordFailedMFA records a wrong code in the MFA step of a login
+func (u *User) RecordFailedMFA(ipAddress, userAgent string) {
+	u.raiseEvent(NewUserMFAFailedEvent(u.ID, ipAddress, userAgent))
+}
+
 func (u *User) raiseEvent(event DomainEvent) {
 	u.events = append(u.events, event)
 }
@@ -0,0 +1,31 @@
+package user
+
+import "errors"
+
+// Typed errors returned by the user bounded context. Callers must map them
+// carefully so responses never reveal whether an account exists.
+var (
+	// ErrInvalidCredentials is returned for an unknown account and a wrong password alike
+	ErrInvalidCredentials = errors.New("invalid credentials")
+
+	// ErrEmailTaken must never reach a client, signup answers neutrally instead
+	ErrEmailTaken = errors.New("email is already registered")
+
+	// ErrUsernameTaken is safe to expose, usernames are public
+	ErrUsernameTaken = errors.New("username is already taken")
+
+	// ErrNotFound is returned by repositories when no user matches
+	ErrNotFound = errors.New("user not found")
+
+	// ErrConflict is returned by repositories when the email or username is already stored
+	ErrConflict = errors.New("user already exists")
+
+	// ErrConcurrentModification is returned by Update when the user changed since it was loaded
+	ErrConcurrentModification = errors.New("user was modified concurrently")
+
+	// ErrInRelationship is returned when a user already has a partner
+	ErrInRelationship = errors.New("user is already in a relationship")
+
+	ErrWeakPassword = errors.New("weak password")
+	ErrValidation   = errors.New("validation failed")
+)
@@ -1,8 +1,9 @@
 package user
 
 import (
-	"fmt"
 	"time"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/ids"
 )
 
 type DomainEvent interface {
@@ -95,10 +96,149 @@ func NewUserEmailVerifiedEvent(userID, email string) *UserEmailVerifiedEvent {
 
 func (e UserEmailVerifiedEvent) GetEventData() interface{} { return e }
 
+// UserRoleChangedEvent - fired when a user is assigned a new role
+type UserRoleChangedEvent struct {
+	BaseEvent
+	PreviousRole string `json:"previous_role"`
+	NewRole      string `json:"new_role"`
+}
+
+func NewUserRoleChangedEvent(userID, previousRole, newRole string) *UserRoleChangedEvent {
+	return &UserRoleChangedEvent{
+		BaseEvent: BaseEvent{
+			EventID:     generateEventID(),
+			EventType:   "user.role_changed",
+			AggregateID: userID,
+			OccurredAt:  time.Now(),
+		},
+		PreviousRole: previousRole,
+		NewRole:      newRole,
+	}
+}
+
+func (e UserRoleChangedEvent) GetEventData() interface{} { return e }
+
+// UserLoginFailedEvent - fired when a wrong password is given for an existing user
+type UserLoginFailedEvent struct {
+	BaseEvent
+	IPAddress string `json:"ip_address,omitempty"`
+	UserAgent string `json:"user_agent,omitempty"`
+}
+
+func NewUserLoginFailedEvent(userID, ipAddress, userAgent string) *UserLoginFailedEvent {
+	return &UserLoginFailedEvent{
+		BaseEvent: BaseEvent{
+			EventID:     generateEventID(),
+			EventType:   "user.login_failed",
+			AggregateID: userID,
+			OccurredAt:  time.Now(),
+		},
+		IPAddress: ipAddress,
+		UserAgent: userAgent,
+	}
+}
+
+func (e UserLoginFailedEvent) GetEventData() interface{} { return e }
+
+// UserMFAFailedEvent - fired when a wrong code is given in the MFA step of a login
+type UserMFAFailedEvent struct {
+	BaseEvent
+	IPAddress string `json:"ip_address,omitempty"`
+	UserAgent string `json:"user_agent,omitempty"`
+}
+
+func NewUserMFAFailedEvent(userID, ipAddress, userAgent string) *UserMFAFailedEvent {
+	return &UserMFAFailedEvent{
+		BaseEvent: BaseEvent{
+			EventID:     generateEventID(),
+			EventType:   "user.mfa_failed",
+			AggregateID: userID,
+			OccurredAt:  time.Now(),
+		},
+		IPAddress: ipAddress,
+		UserAgent: userAgent,
+	}
+}
+
+func (e UserMFAFailedEvent) GetEventData() interface{} { return e }
+
+// UserPasswordChangedEvent - fired when user changes their password
+type UserPasswordChangedEvent struct {
+	BaseEvent
+}
+
+func NewUserPasswordChangedEvent(userID string) *UserPasswordChangedEvent {
+	return &UserPasswordChangedEvent{
+		BaseEvent: BaseEvent{
+			EventID:     generateEventID(),
+			EventType:   "user.password_changed",
+			AggregateID: userID,
+			OccurredAt:  time.Now(),
+		},
+	}
+}
+
+func (e UserPasswordChangedEvent) GetEventData() interface{} { return e }
+
+// UserMFAEnabledEvent - fired when user turns on two-factor authentication
+type UserMFAEnabledEvent struct {
+	BaseEvent
+}
+
+func NewUserMFAEnabledEvent(userID string) *UserMFAEnabledEvent {
+	return &UserMFAEnabledEvent{
+		BaseEvent: BaseEvent{
+			EventID:     generateEventID(),
+			EventType:   "user.mfa_enabled",
+			AggregateID: userID,
+			OccurredAt:  time.Now(),
+		},
+	}
+}
+
+func (e UserMFAEnabledEvent) GetEventData() interface{} { return e }
+
+// UserMFADisabledEvent - fired when user turns off two-factor authentication
+type UserMFADisabledEvent struct {
+	BaseEvent
+}
+
+func NewUserMFADisabledEvent(userID string) *UserMFADisabledEvent {
+	return &UserMFADisabledEvent{
+		BaseEvent: BaseEvent{
+			EventID:     generateEventID(),
+			EventType:   "user.mfa_disabled",
+			AggregateID: userID,
+			OccurredAt:  time.Now(),
+		},
+	}
+}
+
+func (e UserMFADisabledEvent) GetEventData() interface{} { return e }
+
+// SessionRevokedEvent - fired when one of the user's sessions is revoked
+type SessionRevokedEvent struct {
+	BaseEvent
+	SessionID string `json:"session_id"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+func NewSessionRevokedEvent(userID, sessionID, reason string) *SessionRevokedEvent {
+	return &SessionRevokedEvent{
+		BaseEvent: BaseEvent{
+			EventID:     generateEventID(),
+			EventType:   "session.revoked",
+			AggregateID: userID,
+			OccurredAt:  time.Now(),
+		},
+		SessionID: sessionID,
+		Reason:    reason,
+	}
+}
+
+func (e SessionRevokedEvent) GetEventData() interface{} { return e }
+
 // Helper Functions
 func generateEventID() string {
-	bytes := make([]byte, 8)
-
-	timestamp := time.Now().UnixNano()
-	return fmt.Sprintf("evt_%d_%x", timestamp, bytes)
+	return ids.New()
 }
@@ -1,8 +1,11 @@
 package user
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"strings"
+	"sync"
 
 	"golang.org/x/crypto/bcrypt"
 )
@@ -11,6 +14,11 @@ type PasswordHash struct {
 	hash string
 }
 
+var (
+	dummyHashOnce sync.Once
+	dummyHash     []byte
+)
+
 func validatePassword(password string) error {
 	if password == "" {
 		return errors.New("password is required")
@@ -69,6 +77,11 @@ func NewPasswordHashed(password string) (PasswordHash, error) {
 	return PasswordHash{hash: string(hashedBytes)}, nil
 }
 
+// PasswordHashFromStorage rebuilds a hash loaded from the database without re-validating it
+func PasswordHashFromStorage(hash string) PasswordHash {
+	return PasswordHash{hash: hash}
+}
+
 func (p PasswordHash) Verify(other string) bool {
 	err := bcrypt.CompareHashAndPassword([]byte(p.hash), []byte(other))
 	return err == nil
@@ -77,3 +90,18 @@ func (p PasswordHash) Verify(other string) bool {
 func (p PasswordHash) String() string {
 	return p.hash
 }
+
+// VerifyDummyPassword spends the same bcrypt work as Verify against a hash that
+// never matches. Use it when the account is missing so response timing does not
+// reveal which accounts exist.
+func VerifyDummyPassword(password string) {
+	dummyHashOnce.Do(func() {
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			secret = []byte("amora-dummy-password-never-matches")
+		}
+		dummyHash, _ = bcrypt.GenerateFromPassword([]byte(hex.EncodeToString(secret)), bcrypt.DefaultCost)
+	})
+
+	bcrypt.CompareHashAndPassword(dummyHash, []byte(password))
+}
@@ -7,7 +7,12 @@ type Repository interface {
 	GetByID(ctx context.Context, id string) (*User, error)
 	GetByEmail(ctx context.Context, email Email) (*User, error)
 	GetByUsername(ctx context.Context, username Username) (*User, error)
+	// Update increments the version, or returns ErrConcurrentModification when
+	// the stored version moved on since the user was loaded
 	Update(ctx context.Context, user *User) error
 	DeleteByID(ctx context.Context, id string) error
 	Exists(ctx context.Context, email Email, username Username) (bool, error)
+
+	// ExistingUsernames returns which of the usernames are taken, in a single lookup
+	ExistingUsernames(ctx context.Context, usernames []Username) ([]Username, error)
 }
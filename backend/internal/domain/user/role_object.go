@@ -0,0 +1,76 @@
+package user
+
+import (
+	"errors"
+	"strings"
+)
+
+type Role string
+
+const (
+	RoleMember Role = "member"
+	RoleAdmin  Role = "admin"
+)
+
+// Permission is a single capability granted through a role
+type Permission string
+
+const (
+	PermissionReadProfile  Permission = "read:profile"
+	PermissionWriteProfile Permission = "write:profile"
+	PermissionAdminUsers   Permission = "admin:users"
+	PermissionAdminSystem  Permission = "admin:system"
+)
+
+// rolePermissions maps each role to the permissions it grants
+var rolePermissions = map[Role][]Permission{
+	RoleMember: {
+		PermissionReadProfile,
+		PermissionWriteProfile,
+	},
+	RoleAdmin: {
+		PermissionReadProfile,
+		PermissionWriteProfile,
+		PermissionAdminUsers,
+		PermissionAdminSystem,
+	},
+}
+
+func NewRole(role string) (Role, error) {
+	r := Role(strings.ToLower(strings.TrimSpace(role)))
+	if !r.IsValid() {
+		return "", errors.New("invalid role")
+	}
+
+	return r, nil
+}
+
+func (r Role) String() string {
+	return string(r)
+}
+
+func (r Role) IsValid() bool {
+	_, ok := rolePermissions[r]
+	return ok
+}
+
+// Permissions returns a copy of the permissions granted by the role
+func (r Role) Permissions() []Permission {
+	permissions := make([]Permission, len(rolePermissions[r]))
+	copy(permissions, rolePermissions[r])
+	return permissions
+}
+
+// HasPermission checks if the role grants the given permission
+func (r Role) HasPermission(permission Permission) bool {
+	for _, p := range rolePermissions[r] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+func (p Permission) String() string {
+	return string(p)
+}
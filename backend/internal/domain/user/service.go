@@ -6,9 +6,18 @@ import (
 	"encoding/base32"
 	"errors"
 	"fmt"
+	mathrand "math/rand/v2"
 	"strings"
 )
 
+const (
+	// maxSuggestionRounds bounds the lookups of one username suggestion request
+	maxSuggestionRounds = 3
+
+	// suggestionsPerBase is how many numbered variants of a name one round tries
+	suggestionsPerBase = 3
+)
+
 type UserService struct {
 	userRepo Repository
 }
@@ -20,66 +29,112 @@ func NewUserService(userRepo Repository) *UserService {
 }
 
 // IsUsernameAvailable checks if username is available
-func (s UserService) IsUsernameAvailable(ctx context.Context, username string) (bool, error) {
+func (s *UserService) IsUsernameAvailable(ctx context.Context, username string) (bool, error) {
 	usernameVO, err := NewUsername(username)
 	if err != nil {
-		return false, err
+		return false, fmt.Errorf("%w: %v", ErrValidation, err)
 	}
 
-	user, err := s.userRepo.GetByUsername(ctx, usernameVO)
+	taken, err := s.userRepo.ExistingUsernames(ctx, []Username{usernameVO})
 	if err != nil {
-		// If error is "not found", username is available
-		return true, nil
+		return false, err
 	}
 
-	return user == nil, nil
+	return len(taken) == 0, nil
 }
 
 // IsEmailAvailable checks if email is available
 func (s *UserService) IsEmailAvailable(ctx context.Context, email string) (bool, error) {
 	emailVO, err := NewEmail(email)
 	if err != nil {
-		return false, err
+		return false, fmt.Errorf("%w: %v", ErrValidation, err)
 	}
 
-	user, err := s.userRepo.GetByEmail(ctx, emailVO)
-	if err != nil {
+	// Only a confirmed miss means available, lookup failures must not let duplicates through
+	_, err = s.userRepo.GetByEmail(ctx, emailVO)
+	if errors.Is(err, ErrNotFound) {
 		return true, nil
 	}
+	if err != nil {
+		return false, err
+	}
 
-	return user == nil, nil
+	return false, nil
 }
 
-// GenerateUniqueUsername generates a unique username based on first/last name
-func (s *UserService) GenerateUniqueUsername(ctx context.Context, firstName, lastName string) (string, error) {
-	// Clean and prepare base username
-	base := s.createBaseUsername(firstName, lastName)
+// AvailableUsernames returns the candidates nobody has taken yet, in their order.
+// Invalid candidates are skipped, all of them are checked with a single lookup.
+func (s *UserService) AvailableUsernames(ctx context.Context, candidates []string) ([]string, error) {
+	usernames := make([]Username, 0, len(candidates))
+	for _, candidate := range candidates {
+		if username, err := NewUsername(candidate); err == nil {
+			usernames = append(usernames, username)
+		}
+	}
 
-	// Try the base username
-	if available, err := s.IsUsernameAvailable(ctx, base); err == nil && available {
-		return base, nil
+	taken, err := s.userRepo.ExistingUsernames(ctx, usernames)
+	if err != nil {
+		return nil, err
 	}
 
-	// Try with numbers appended
-	for i := 1; i <= 999; i++ {
-		candidate := fmt.Sprintf("%s%d", base, i)
-		if available, err := s.IsUsernameAvailable(ctx, candidate); err == nil && available {
-			return candidate, nil
+	available := make([]string, 0, len(usernames))
+	for _, username := range usernames {
+		if !containsUsername(taken, username) {
+			available = append(available, username.String())
 		}
 	}
 
-	return "", errors.New("unable to generate unique username")
+	return available, nil
 }
 
-// ValidateUserForCreation performs comprehensive validation before user creation
-func (s *UserService) ValidateUserForCreation(ctx context.Context, email, username, password string) error {
-	// Check if email is available
-	emailAvailable, err := s.IsEmailAvailable(ctx, email)
+// SuggestUsernames returns up to count available usernames built from the names.
+// Plain name combinations come first, then the names with digits appended.
+func (s *UserService) SuggestUsernames(ctx context.Context, firstName, lastName string, count int) ([]string, error) {
+	first := s.cleanForUsername(strings.ToLower(strings.TrimSpace(firstName)))
+	last := s.cleanForUsername(strings.ToLower(strings.TrimSpace(lastName)))
+
+	seen := make(map[string]bool)
+	suggestions := make([]string, 0, count)
+	for round := 0; round < maxSuggestionRounds && len(suggestions) < count; round++ {
+		var candidates []string
+		for _, candidate := range usernameCandidates(first, last, round) {
+			if !seen[candidate] {
+				seen[candidate] = true
+				candidates = append(candidates, candidate)
+			}
+		}
+
+		available, err := s.AvailableUsernames(ctx, candidates)
+		if err != nil {
+			return nil, err
+		}
+		suggestions = append(suggestions, available[:min(len(available), count-len(suggestions))]...)
+	}
+
+	return suggestions, nil
+}
+
+// GenerateUniqueUsername generates a unique username based on first/last name
+func (s *UserService) GenerateUniqueUsername(ctx context.Context, firstName, lastName string) (string, error) {
+	suggestions, err := s.SuggestUsernames(ctx, firstName, lastName, 1)
 	if err != nil {
-		return fmt.Errorf("error checking email availability: %w", err)
+		return "", err
 	}
-	if !emailAvailable {
-		return errors.New("email is already registered")
+	if len(suggestions) == 0 {
+		return "", errors.New("unable to generate unique username")
+	}
+
+	return suggestions[0], nil
+}
+
+// ValidateUserForCreation performs comprehensive validation before user creation.
+// The email is checked last so a weak password or taken username is reported the
+// same way whether or not the email is registered.
+func (s *UserService) ValidateUserForCreation(ctx context.Context, email, username, password string) error {
+	// Check the score of the password
+	passswordScore, suggestions := s.ValidatePasswordStrength(password)
+	if passswordScore < 4 {
+		return fmt.Errorf("%w %v", ErrWeakPassword, suggestions)
 	}
 
 	// Check if username is available
@@ -88,13 +143,16 @@ func (s *UserService) ValidateUserForCreation(ctx context.Context, email, userna
 		return fmt.Errorf("error checking username availability: %w", err)
 	}
 	if !usernameAvailable {
-		return errors.New("username is already taken")
+		return ErrUsernameTaken
 	}
 
-	// Check the score of the password
-	passswordScore, suggestions := s.ValidatePasswordStrength(password)
-	if passswordScore < 4 {
-		return fmt.Errorf("weak password %v", suggestions)
+	// Check if email is available
+	emailAvailable, err := s.IsEmailAvailable(ctx, email)
+	if err != nil {
+		return fmt.Errorf("error checking email availability: %w", err)
+	}
+	if !emailAvailable {
+		return ErrEmailTaken
 	}
 
 	return nil
@@ -127,7 +185,7 @@ func (s *UserService) CanUserChangeEmail(ctx context.Context, user *User, newEma
 	}
 
 	if !available {
-		return errors.New("email is already registered to another account")
+		return ErrEmailTaken
 	}
 
 	return nil
@@ -146,32 +204,67 @@ func (s *UserService) CanUserChangeUsername(ctx context.Context, user *User, new
 		return fmt.Errorf("error checking username availability: %w", err)
 	}
 	if !available {
-		return errors.New("username is already taken")
+		return ErrUsernameTaken
 	}
 
 	return nil
 }
 
 // Helper functions
-func (s *UserService) createBaseUsername(firstName, lastName string) string {
-	// Clean the names
-	firstName = strings.ToLower(strings.TrimSpace(firstName))
-	lastName = strings.ToLower(strings.TrimSpace(lastName))
 
-	// Remove non-alphanumeric characters
-	firstName = s.cleanForUsername(firstName)
-	lastName = s.cleanForUsername(lastName)
+// usernameCandidates lists the usernames tried in one round of suggestions. The
+// first round combines the names, later rounds append longer random numbers.
+func usernameCandidates(first, last string, round int) []string {
+	var bases []string
+	switch {
+	case first != "" && last != "":
+		bases = []string{first + last, first + "_" + last, first, first + last[:1], first[:1] + last, last + first, last + "_" + first}
+	case first != "" || last != "":
+		bases = []string{first + last}
+	default:
+		bases = []string{"user"}
+	}
+
+	if round == 0 {
+		candidates := make([]string, 0, len(bases))
+		for _, base := range bases {
+			candidates = append(candidates, truncateUsername(base, ""))
+		}
+		return candidates
+	}
 
-	// Create base username
-	if len(firstName) > 0 && len(lastName) > 0 {
-		return firstName + lastName
-	} else if len(firstName) > 0 {
-		return firstName
-	} else if len(lastName) > 0 {
-		return lastName
+	// Two digits in the second round, four in the third
+	digits := 2 * round
+	limit := 1
+	for i := 0; i < digits; i++ {
+		limit *= 10
 	}
 
-	return "user"
+	var candidates []string
+	for _, base := range bases[:min(len(bases), 3)] {
+		for i := 0; i < suggestionsPerBase; i++ {
+			suffix := fmt.Sprintf("%0*d", digits, mathrand.IntN(limit))
+			candidates = append(candidates, truncateUsername(base, suffix), truncateUsername(base+"_", suffix))
+		}
+	}
+	return candidates
+}
+
+// truncateUsername shortens base so base+suffix fits the username length limit
+func truncateUsername(base, suffix string) string {
+	if len(base)+len(suffix) > maxUsernameLength {
+		base = strings.TrimRight(base[:maxUsernameLength-len(suffix)], "_")
+	}
+	return base + suffix
+}
+
+func containsUsername(usernames []Username, username Username) bool {
+	for _, u := range usernames {
+		if u.Equals(username) {
+			return true
+		}
+	}
+	return false
 }
 
 // ValidatePasswordStrength performs additional password strength validation
@@ -0,0 +1,70 @@
+package user
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	totpPeriod = 30 * time.Second
+	totpDigits = 6
+
+	// totpSkew accepts codes from one step before and after to tolerate clock drift
+	totpSkew = 1
+)
+
+// VerifyMFACode checks a TOTP code (RFC 6238) against the user's MFA secret.
+// Each time step is accepted once, so an observed code cannot be replayed, save
+// the user afterwards to keep the step.
+func (u *User) VerifyMFACode(code string, at time.Time) bool {
+	if !u.Credentials.MfaEnabled || u.Credentials.MfaSecret == nil {
+		return false
+	}
+
+	secret, err := base32.StdEncoding.DecodeString(strings.ToUpper(*u.Credentials.MfaSecret))
+	if err != nil {
+		return false
+	}
+
+	code = strings.TrimSpace(code)
+	if len(code) != totpDigits {
+		return false
+	}
+
+	counter := at.Unix() / int64(totpPeriod.Seconds())
+	matched := int64(-1)
+	for offset := int64(-totpSkew); offset <= totpSkew; offset++ {
+		step := counter + offset
+		expected := totpCode(secret, uint64(step))
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			matched = step
+		}
+	}
+
+	if matched < 0 || matched <= u.Credentials.MfaLastStep {
+		return false
+	}
+
+	u.Credentials.MfaLastStep = matched
+	return true
+}
+
+func totpCode(secret []byte, counter uint64) string {
+	message := make([]byte, 8)
+	binary.BigEndian.PutUint64(message, counter)
+
+	mac := hmac.New(sha1.New, secret)
+	mac.Write(message)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	return fmt.Sprintf("%06d", value%1000000)
+}
@@ -6,6 +6,9 @@ import (
 	"strings"
 )
 
+// maxUsernameLength is the longest username accepted
+const maxUsernameLength = 30
+
 type Username struct {
 	value string
 }
@@ -20,7 +23,7 @@ func NewUsername(username string) (Username, error) {
 		return Username{}, errors.New("username must be at least 3 characters")
 	}
 
-	if len(username) > 30 {
+	if len(username) > maxUsernameLength {
 		return Username{}, errors.New("username too long (max 30 characters)")
 	}
 
@@ -0,0 +1,186 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/application/interfaces"
+	"github.com/StefanPenchev05/Amora/backend/internal/config"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	tokenIssuer   = "amora-backend"
+	tokenAudience = "amora-api"
+
+	accessTokenType  = "access"
+	refreshTokenType = "refresh"
+	mfaTokenType     = "mfa"
+)
+
+var (
+	ErrInvalidToken     = errors.New("invalid token")
+	ErrInvalidTokenType = errors.New("invalid token type")
+)
+
+// claims is the JWT payload issued by the service
+type claims struct {
+	jwt.RegisteredClaims
+	SessionID string `json:"sid,omitempty"`
+	Device    string `json:"dfp,omitempty"`
+	Role      string `json:"role,omitempty"`
+	Scope     string `json:"scope,omitempty"`
+	TokenType string `json:"typ"`
+}
+
+// JWTService signs tokens with the active key and verifies them against every configured key
+type JWTService struct {
+	keys       *KeySet
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+}
+
+// Constructor
+func NewJWTService(cfg config.JWTConfig) (interfaces.JWTService, error) {
+	keys, err := NewKeySet(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load JWT keys: %w", err)
+	}
+
+	return &JWTService{
+		keys:       keys,
+		accessTTL:  cfg.AccessTTL,
+		refreshTTL: cfg.RefreshTTL,
+	}, nil
+}
+
+func (s *JWTService) GenerateAccessToken(subject interfaces.TokenSubject) (string, error) {
+	return s.sign(subjectClaims(subject, accessTokenType), s.accessTTL)
+}
+
+func (s *JWTService) GenerateRefreshToken(subject interfaces.TokenSubject) (string, error) {
+	return s.sign(subjectClaims(subject, refreshTokenType), s.refreshTTL)
+}
+
+// GenerateMFAToken names the challenge in the token ID, the challenge store decides whether it is still usable
+func (s *JWTService) GenerateMFAToken(challengeID, userID, deviceFingerprint string, expiresAt time.Time) (string, error) {
+	if challengeID == "" {
+		return "", errors.New("challenge ID is required")
+	}
+
+	return s.sign(claims{
+		RegisteredClaims: jwt.RegisteredClaims{Subject: userID, ID: challengeID},
+		Device:           deviceFingerprint,
+		TokenType:        mfaTokenType,
+	}, time.Until(expiresAt))
+}
+
+func (s *JWTService) ValidateMFAToken(token string) (string, string, string, error) {
+	parsed, err := s.parse(token, mfaTokenType)
+	if err != nil {
+		return "", "", "", err
+	}
+	if parsed.ID == "" {
+		return "", "", "", ErrInvalidToken
+	}
+
+	return parsed.ID, parsed.Subject, parsed.Device, nil
+}
+
+func (s *JWTService) ValidateToken(token string) (*interfaces.TokenClaims, error) {
+	parsed, err := s.parse(token, accessTokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	return toTokenClaims(parsed), nil
+}
+
+// ValidateRefreshToken only verifies the token, the role and scope it carries may be out of date
+func (s *JWTService) ValidateRefreshToken(refreshToken string) (*interfaces.TokenClaims, error) {
+	parsed, err := s.parse(refreshToken, refreshTokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	return toTokenClaims(parsed), nil
+}
+
+func (s *JWTService) GetAccessTokenExpiration() int64 {
+	return int64(s.accessTTL.Seconds())
+}
+
+func (s *JWTService) PublicJWKS() interfaces.JSONWebKeySet {
+	return s.keys.JWKS()
+}
+
+func (s *JWTService) sign(c claims, ttl time.Duration) (string, error) {
+	if c.Subject == "" {
+		return "", errors.New("user ID is required")
+	}
+
+	now := time.Now()
+	c.Issuer = tokenIssuer
+	c.Audience = jwt.ClaimStrings{tokenAudience}
+	c.IssuedAt = jwt.NewNumericDate(now)
+	c.ExpiresAt = jwt.NewNumericDate(now.Add(ttl))
+
+	signed, err := s.keys.Sign(c)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token: %w", err)
+	}
+
+	return signed, nil
+}
+
+func (s *JWTService) parse(token, expectedType string) (*claims, error) {
+	parsed := &claims{}
+	_, err := jwt.ParseWithClaims(token, parsed, s.keys.Keyfunc,
+		jwt.WithValidMethods(s.keys.Algorithms()),
+		jwt.WithIssuer(tokenIssuer),
+		jwt.WithAudience(tokenAudience),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	if parsed.TokenType != expectedType {
+		return nil, ErrInvalidTokenType
+	}
+
+	return parsed, nil
+}
+
+func subjectClaims(subject interfaces.TokenSubject, tokenType string) claims {
+	return claims{
+		RegisteredClaims: jwt.RegisteredClaims{Subject: subject.UserID},
+		SessionID:        subject.SessionID,
+		Role:             subject.Role,
+		Scope:            strings.Join(subject.Permissions, " "),
+		TokenType:        tokenType,
+	}
+}
+
+func toTokenClaims(c *claims) *interfaces.TokenClaims {
+	tokenClaims := &interfaces.TokenClaims{
+		UserID:    c.Subject,
+		SessionID: c.SessionID,
+		Issuer:    c.Issuer,
+		Role:      c.Role,
+		Scope:     c.Scope,
+	}
+	if c.ExpiresAt != nil {
+		tokenClaims.ExpiresAt = c.ExpiresAt.Unix()
+	}
+	if c.IssuedAt != nil {
+		tokenClaims.IssuedAt = c.IssuedAt.Unix()
+	}
+	if len(c.Audience) > 0 {
+		tokenClaims.Audience = c.Audience[0]
+	}
+
+	return tokenClaims
+}
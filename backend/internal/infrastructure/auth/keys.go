@@ -0,0 +1,290 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/application/interfaces"
+	"github.com/StefanPenchev05/Amora/backend/internal/config"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// hmacKeyID is the kid of the shared secret, it is never published
+const hmacKeyID = "hs256"
+
+// verificationKey is a key accepted when validating tokens
+type verificationKey struct {
+	kid    string
+	method jwt.SigningMethod
+	key    interface{}
+
+	// until retires the key at the given time, zero keeps it
+	until time.Time
+}
+
+// signingKey is the key new tokens are signed with
+type signingKey struct {
+	verificationKey
+	private interface{}
+}
+
+// KeySet holds the active signing key and every key tokens may still be verified with.
+// Keeping the previous public keys lets keys rotate without invalidating issued tokens.
+type KeySet struct {
+	signing      signingKey
+	verification map[string]verificationKey
+
+	// legacyUntil accepts tokens without a kid as HMAC signed until then, zero refuses them
+	legacyUntil time.Time
+}
+
+// NewKeySet builds the key set from configuration. Without a signing key file tokens
+// are signed with the shared secret. Otherwise the secret only verifies tokens issued
+// before the switch to asymmetric keys, and only when LegacyHS256Until opts in.
+func NewKeySet(cfg config.JWTConfig) (*KeySet, error) {
+	if cfg.SigningKeyFile == "" {
+		return NewHMACKeySet(cfg.Secret), nil
+	}
+
+	keySet, err := LoadKeySet(cfg.SigningKeyFile, cfg.VerificationKeyFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.Secret != "" && cfg.LegacyHS256Until != nil && time.Now().Before(*cfg.LegacyHS256Until) {
+		keySet.verification[hmacKeyID] = verificationKey{
+			kid:    hmacKeyID,
+			method: jwt.SigningMethodHS256,
+			key:    []byte(cfg.Secret),
+			until:  *cfg.LegacyHS256Until,
+		}
+		keySet.legacyUntil = *cfg.LegacyHS256Until
+	}
+
+	return keySet, nil
+}
+
+// NewHMACKeySet uses the shared secret for signing and verification
+func NewHMACKeySet(secret string) *KeySet {
+	key := verificationKey{kid: hmacKeyID, method: jwt.SigningMethodHS256, key: []byte(secret)}
+	return &KeySet{
+		signing:      signingKey{verificationKey: key, private: []byte(secret)},
+		verification: map[string]verificationKey{hmacKeyID: key},
+	}
+}
+
+// LoadKeySet signs with the private key in signingKeyFile and additionally accepts
+// the keys in verificationKeyFiles, which may hold public or private keys
+func LoadKeySet(signingKeyFile string, verificationKeyFiles []string) (*KeySet, error) {
+	private, err := readPrivateKey(signingKeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	signing, err := newVerificationKey(publicKeyOf(private))
+	if err != nil {
+		return nil, err
+	}
+
+	keySet := &KeySet{
+		signing:      signingKey{verificationKey: signing, private: private},
+		verification: map[string]verificationKey{signing.kid: signing},
+	}
+
+	for _, file := range verificationKeyFiles {
+		public, err := readPublicKey(file)
+		if err != nil {
+			return nil, err
+		}
+
+		key, err := newVerificationKey(public)
+		if err != nil {
+			return nil, err
+		}
+		keySet.verification[key.kid] = key
+	}
+
+	return keySet, nil
+}
+
+// Sign signs the token with the active key and sets the kid header
+func (ks *KeySet) Sign(c jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(ks.signing.method, c)
+	token.Header["kid"] = ks.signing.kid
+	return token.SignedString(ks.signing.private)
+}
+
+// Keyfunc resolves the verification key from the kid header
+func (ks *KeySet) Keyfunc(token *jwt.Token) (interface{}, error) {
+	now := time.Now()
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		// Tokens issued before key ids were introduced are HMAC signed, accepted while the legacy window is open
+		if ks.legacyUntil.IsZero() || !now.Before(ks.legacyUntil) {
+			return nil, errors.New("token has no key id")
+		}
+		kid = hmacKeyID
+	}
+
+	key, ok := ks.verification[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+	if !key.until.IsZero() && !now.Before(key.until) {
+		return nil, fmt.Errorf("key %q was retired at %s", kid, key.until.Format(time.RFC3339))
+	}
+
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method %s for key %q", token.Method.Alg(), kid)
+	}
+
+	return key.key, nil
+}
+
+// Algorithms lists the signing methods accepted by the key set
+func (ks *KeySet) Algorithms() []string {
+	seen := make(map[string]bool)
+	algorithms := make([]string, 0)
+	for _, key := range ks.verification {
+		if !seen[key.method.Alg()] {
+			seen[key.method.Alg()] = true
+			algorithms = append(algorithms, key.method.Alg())
+		}
+	}
+	return algorithms
+}
+
+// JWKS returns the public verification keys, the shared HMAC secret is never included
+func (ks *KeySet) JWKS() interfaces.JSONWebKeySet {
+	jwks := interfaces.JSONWebKeySet{Keys: make([]interfaces.JSONWebKey, 0)}
+	for _, key := range ks.verification {
+		switch public := key.key.(type) {
+		case ed25519.PublicKey:
+			jwks.Keys = append(jwks.Keys, interfaces.JSONWebKey{
+				Kty: "OKP",
+				Crv: "Ed25519",
+				X:   base64.RawURLEncoding.EncodeToString(public),
+				Kid: key.kid,
+				Alg: key.method.Alg(),
+				Use: "sig",
+			})
+		case *rsa.PublicKey:
+			jwks.Keys = append(jwks.Keys, interfaces.JSONWebKey{
+				Kty: "RSA",
+				N:   base64.RawURLEncoding.EncodeToString(public.N.Bytes()),
+				E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes()),
+				Kid: key.kid,
+				Alg: key.method.Alg(),
+				Use: "sig",
+			})
+		}
+	}
+
+	// Keep the document stable between requests
+	sort.Slice(jwks.Keys, func(i, j int) bool { return jwks.Keys[i].Kid < jwks.Keys[j].Kid })
+
+	return jwks
+}
+
+func newVerificationKey(public crypto.PublicKey) (verificationKey, error) {
+	switch key := public.(type) {
+	case ed25519.PublicKey:
+		return verificationKey{kid: thumbprint(key), method: jwt.SigningMethodEdDSA, key: key}, nil
+	case *rsa.PublicKey:
+		if key.N.BitLen() < 2048 {
+			return verificationKey{}, errors.New("RSA keys must be at least 2048 bits")
+		}
+		return verificationKey{kid: thumbprint(key), method: jwt.SigningMethodRS256, key: key}, nil
+	default:
+		return verificationKey{}, fmt.Errorf("unsupported key type %T, use Ed25519 or RSA", public)
+	}
+}
+
+// thumbprint derives the kid from the public key (RFC 7638), so every service computes the same id
+func thumbprint(public crypto.PublicKey) string {
+	var members string
+	switch key := public.(type) {
+	case ed25519.PublicKey:
+		members = fmt.Sprintf(`{"crv":"Ed25519","kty":"OKP","x":"%s"}`, base64.RawURLEncoding.EncodeToString(key))
+	case *rsa.PublicKey:
+		members = fmt.Sprintf(`{"e":"%s","kty":"RSA","n":"%s"}`,
+			base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			base64.RawURLEncoding.EncodeToString(key.N.Bytes()))
+	}
+
+	sum := sha256.Sum256([]byte(members))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func publicKeyOf(private interface{}) crypto.PublicKey {
+	switch key := private.(type) {
+	case ed25519.PrivateKey:
+		return key.Public()
+	case *rsa.PrivateKey:
+		return &key.PublicKey
+	default:
+		return nil
+	}
+}
+
+func readPEM(file string) (*pem.Block, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("key file %s is not PEM encoded", file)
+	}
+
+	return block, nil
+}
+
+func readPrivateKey(file string) (interface{}, error) {
+	block, err := readPEM(file)
+	if err != nil {
+		return nil, err
+	}
+
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	return nil, fmt.Errorf("key file %s does not hold a PKCS#8 or PKCS#1 private key", file)
+}
+
+func readPublicKey(file string) (crypto.PublicKey, error) {
+	block, err := readPEM(file)
+	if err != nil {
+		return nil, err
+	}
+
+	if key, err := x509.ParsePKIXPublicKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParsePKCS1PublicKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	// Previous signing keys may be kept in their private form
+	if private, err := readPrivateKey(file); err == nil {
+		return publicKeyOf(private), nil
+	}
+
+	return nil, fmt.Errorf("key file %s does not hold a public key", file)
+}
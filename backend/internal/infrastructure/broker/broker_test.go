@@ -0,0 +1,283 @@
+package broker_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	appEvents "github.com/StefanPenchev05/Amora/backend/internal/application/events"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/user"
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/broker"
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/broker/natstest"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+const userCreated = "user.created"
+
+var errHandler = errors.New("handler failed")
+
+// recordingHandler stands in for the event bus of a worker. It fails the first
+// deliveries of the events set in failures.
+type recordingHandler struct {
+	mu       sync.Mutex
+	failures map[string]int
+	calls    map[string]int
+	handled  chan appEvents.Envelope
+}
+
+func newRecordingHandler() *recordingHandler {
+	return &recordingHandler{
+		failures: make(map[string]int),
+		calls:    make(map[string]int),
+		handled:  make(chan appEvents.Envelope, 16),
+	}
+}
+
+func (h *recordingHandler) failFirst(eventID string, deliveries int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.failures[eventID] = deliveries
+}
+
+func (h *recordingHandler) Publish(_ context.Context, envelopes ...appEvents.Envelope) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, envelope := range envelopes {
+		h.calls[envelope.EventID]++
+		if h.calls[envelope.EventID] <= h.failures[envelope.EventID] {
+			return errHandler
+		}
+		h.handled <- envelope
+	}
+	return nil
+}
+
+func (h *recordingHandler) callsOf(eventID string) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.calls[eventID]
+}
+
+type fixture struct {
+	js        jetstream.JetStream
+	stream    jetstream.Stream
+	registry  *appEvents.Registry
+	publisher *broker.JetStreamPublisher
+}
+
+func setup(t *testing.T) *fixture {
+	t.Helper()
+
+	srv, err := natstest.Start()
+	if err != nil {
+		t.Fatalf("start NATS: %v", err)
+	}
+	t.Cleanup(srv.Shutdown)
+
+	conn, js, err := srv.Connect()
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(conn.Close)
+
+	stream, err := broker.EnsureStream(context.Background(), js, broker.DefaultStreamOptions())
+	if err != nil {
+		t.Fatalf("ensure stream: %v", err)
+	}
+
+	registry := appEvents.DefaultRegistry()
+	return &fixture{
+		js:        js,
+		stream:    stream,
+		registry:  registry,
+		publisher: broker.NewJetStreamPublisher(js, registry),
+	}
+}
+
+func (f *fixture) envelope(t *testing.T, username string) appEvents.Envelope {
+	t.Helper()
+
+	event := user.NewUserCreatedEvent("user-"+username, username+"@example.com", username, "Test", "User")
+	envelope, err := f.registry.Encode(context.Background(), event)
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	return envelope
+}
+
+func (f *fixture) storedMessages(t *testing.T) uint64 {
+	t.Helper()
+
+	info, err := f.stream.Info(context.Background())
+	if err != nil {
+		t.Fatalf("stream info: %v", err)
+	}
+	return info.State.Msgs
+}
+
+func (f *fixture) runConsumer(t *testing.T, options broker.ConsumerOptions, handler appEvents.Publisher) jetstream.Consumer {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	consumer, err := broker.NewConsumer(ctx, f.js, broker.DefaultStreamOptions().Name, options, handler, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		cancel()
+		t.Fatalf("new consumer: %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if err := consumer.Run(ctx); err != nil {
+			t.Errorf("run consumer: %v", err)
+		}
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	created, err := f.js.Consumer(context.Background(), broker.DefaultStreamOptions().Name, options.Durable)
+	if err != nil {
+		t.Fatalf("look up consumer: %v", err)
+	}
+	return created
+}
+
+func waitHandled(t *testing.T, handler *recordingHandler) appEvents.Envelope {
+	t.Helper()
+
+	select {
+	case envelope := <-handler.handled:
+		return envelope
+	case <-time.After(5 * time.Second):
+		t.Fatal("event was not handled")
+		return appEvents.Envelope{}
+	}
+}
+
+func TestPublisherStoresEventWithHeaders(t *testing.T) {
+	f := setup(t)
+	envelope := f.envelope(t, "alice")
+
+	if err := f.publisher.Publish(context.Background(), envelope); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+
+	msg, err := f.stream.GetLastMsgForSubject(context.Background(), broker.Subject(userCreated))
+	if err != nil {
+		t.Fatalf("get message: %v", err)
+	}
+	if got := msg.Header.Get(broker.HeaderEventType); got != userCreated {
+		t.Errorf("event type header = %q, want %q", got, userCreated)
+	}
+	if got := msg.Header.Get("Nats-Msg-Id"); got != envelope.EventID {
+		t.Errorf("message ID = %q, want the event ID %q", got, envelope.EventID)
+	}
+}
+
+func TestPublisherDropsDuplicateEventIDs(t *testing.T) {
+	f := setup(t)
+	envelope := f.envelope(t, "alice")
+
+	// The relay publishes again when it could not mark the message published
+	for i := 0; i < 3; i++ {
+		if err := f.publisher.Publish(context.Background(), envelope); err != nil {
+			t.Fatalf("publish %d: %v", i, err)
+		}
+	}
+	if err := f.publisher.Publish(context.Background(), f.envelope(t, "bob")); err != nil {
+		t.Fatalf("publish other event: %v", err)
+	}
+
+	if got := f.storedMessages(t); got != 2 {
+		t.Errorf("stored %d messages, want 2", got)
+	}
+}
+
+func TestConsumerAcknowledgesHandledEvents(t *testing.T) {
+	f := setup(t)
+	envelope := f.envelope(t, "alice")
+	if err := f.publisher.Publish(context.Background(), envelope); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+
+	handler := newRecordingHandler()
+	consumer := f.runConsumer(t, broker.DefaultConsumerOptions("ack-test"), handler)
+
+	got := waitHandled(t, handler)
+	if got.EventID != envelope.EventID || got.AggregateID != envelope.AggregateID {
+		t.Errorf("handled %+v, want %+v", got, envelope)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		info, err := consumer.Info(context.Background())
+		if err != nil {
+			t.Fatalf("consumer info: %v", err)
+		}
+		if info.NumAckPending == 0 && info.AckFloor.Consumer == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("event not acknowledged, %d pending", info.NumAckPending)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if calls := handler.callsOf(envelope.EventID); calls != 1 {
+		t.Errorf("handled %d times, want once", calls)
+	}
+}
+
+func TestConsumerRedeliversFailedEvents(t *testing.T) {
+	f := setup(t)
+	envelope := f.envelope(t, "alice")
+	if err := f.publisher.Publish(context.Background(), envelope); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+
+	options := broker.DefaultConsumerOptions("redelivery-test", userCreated)
+	options.Backoff = []time.Duration{10 * time.Millisecond}
+	handler := newRecordingHandler()
+	handler.failFirst(envelope.EventID, 2)
+	f.runConsumer(t, options, handler)
+
+	waitHandled(t, handler)
+	if calls := handler.callsOf(envelope.EventID); calls != 3 {
+		t.Errorf("delivered %d times, want 3", calls)
+	}
+}
+
+func TestConsumerStopsAfterMaxDeliver(t *testing.T) {
+	f := setup(t)
+	failing := f.envelope(t, "alice")
+	if err := f.publisher.Publish(context.Background(), failing); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+
+	options := broker.DefaultConsumerOptions("max-deliver-test")
+	options.MaxDeliver = 2
+	options.Backoff = []time.Duration{10 * time.Millisecond}
+	handler := newRecordingHandler()
+	handler.failFirst(failing.EventID, options.MaxDeliver)
+	f.runConsumer(t, options, handler)
+
+	next := f.envelope(t, "bob")
+	if err := f.publisher.Publish(context.Background(), next); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+
+	if got := waitHandled(t, handler); got.EventID != next.EventID {
+		t.Errorf("handled %s, want %s", got.EventID, next.EventID)
+	}
+	// Leave the failing event time for deliveries past MaxDeliver
+	time.Sleep(100 * time.Millisecond)
+	if calls := handler.callsOf(failing.EventID); calls != 2 {
+		t.Errorf("delivered %d times, want MaxDeliver 2", calls)
+	}
+}
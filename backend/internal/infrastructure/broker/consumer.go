@@ -0,0 +1,171 @@
+package broker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	appEvents "github.com/StefanPenchev05/Amora/backend/internal/application/events"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// ConsumerOptions describes a durable consumer of the event stream
+type ConsumerOptions struct {
+	// Durable names the consumer, processes sharing the name share the work
+	Durable string
+
+	// EventTypes filters the events delivered, all events when empty
+	EventTypes []string
+
+	// AckWait is how long a delivery may take before JetStream redelivers it
+	AckWait time.Duration
+
+	// MaxDeliver bounds the deliveries of one event, after that it is dropped
+	MaxDeliver int
+
+	// Backoff is the delay before each redelivery of a failed event, the last
+	// delay repeats once the list runs out
+	Backoff []time.Duration
+}
+
+func DefaultConsumerOptions(durable string, eventTypes ...string) ConsumerOptions {
+	return ConsumerOptions{
+		Durable:    durable,
+		EventTypes: eventTypes,
+		AckWait:    30 * time.Second,
+		MaxDeliver: 10,
+		Backoff:    []time.Duration{time.Second, 5 * time.Second, 30 * time.Second, 2 * time.Minute},
+	}
+}
+
+// Consumer delivers the events of a durable JetStream consumer to a handler,
+// usually the event bus of a worker process. An event is acknowledged once the
+// handler succeeds and redelivered with backoff when it fails.
+type Consumer struct {
+	consumer jetstream.Consumer
+	handler  appEvents.Publisher
+	options  ConsumerOptions
+	logger   *slog.Logger
+}
+
+// NewConsumer creates the durable consumer on the stream, or updates its configuration
+func NewConsumer(
+	ctx context.Context,
+	js jetstream.JetStream,
+	stream string,
+	options ConsumerOptions,
+	handler appEvents.Publisher,
+	logger *slog.Logger,
+) (*Consumer, error) {
+	subjects := make([]string, 0, len(options.EventTypes))
+	for _, eventType := range options.EventTypes {
+		subjects = append(subjects, Subject(eventType))
+	}
+
+	consumer, err := js.CreateOrUpdateConsumer(ctx, stream, jetstream.ConsumerConfig{
+		Durable:        options.Durable,
+		FilterSubjects: subjects,
+		AckPolicy:      jetstream.AckExplicitPolicy,
+		AckWait:        options.AckWait,
+		MaxDeliver:     options.MaxDeliver,
+		DeliverPolicy:  jetstream.DeliverAllPolicy,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create consumer %s: %w", options.Durable, err)
+	}
+
+	return &Consumer{
+		consumer: consumer,
+		handler:  handler,
+		options:  options,
+		logger:   logger,
+	}, nil
+}
+
+// Run consumes events until the context is cancelled, then lets the deliveries
+// in progress finish
+func (c *Consumer) Run(ctx context.Context) error {
+	handlerCtx := context.WithoutCancel(ctx)
+	consumeCtx, err := c.consumer.Consume(
+		func(msg jetstream.Msg) { c.handle(handlerCtx, msg) },
+		jetstream.ConsumeErrHandler(func(_ jetstream.ConsumeContext, err error) {
+			c.logger.Warn("JetStream consumer error", "consumer", c.options.Durable, "error", err.Error())
+		}),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to start consumer %s: %w", c.options.Durable, err)
+	}
+
+	<-ctx.Done()
+	consumeCtx.Drain()
+	<-consumeCtx.Closed()
+
+	return nil
+}
+
+func (c *Consumer) handle(ctx context.Context, msg jetstream.Msg) {
+	var envelope appEvents.Envelope
+	if err := json.Unmarshal(msg.Data(), &envelope); err != nil {
+		// Redelivering a malformed message cannot help
+		c.logger.Error("Dropping malformed event",
+			"consumer", c.options.Durable,
+			"subject", msg.Subject(),
+			"error", err.Error(),
+		)
+		c.settle(msg.Term())
+		return
+	}
+
+	var delivered uint64 = 1
+	if metadata, err := msg.Metadata(); err == nil {
+		delivered = metadata.NumDelivered
+	}
+
+	err := c.handler.Publish(ctx, envelope)
+	if err == nil {
+		c.settle(msg.Ack())
+		return
+	}
+
+	if c.options.MaxDeliver > 0 && delivered >= uint64(c.options.MaxDeliver) {
+		c.logger.Error("Event handling failed, giving up",
+			"consumer", c.options.Durable,
+			"event_id", envelope.EventID,
+			"event_type", envelope.EventType,
+			"deliveries", delivered,
+			"error", err.Error(),
+		)
+		c.settle(msg.Term())
+		return
+	}
+
+	c.logger.Warn("Event handling failed, redelivering",
+		"consumer", c.options.Durable,
+		"event_id", envelope.EventID,
+		"event_type", envelope.EventType,
+		"deliveries", delivered,
+		"error", err.Error(),
+	)
+	c.settle(msg.NakWithDelay(c.backoff(delivered)))
+}
+
+// backoff returns the delay before the next delivery after the given number of deliveries
+func (c *Consumer) backoff(delivered uint64) time.Duration {
+	if len(c.options.Backoff) == 0 {
+		return 0
+	}
+
+	index := int(delivered) - 1
+	if index >= len(c.options.Backoff) {
+		index = len(c.options.Backoff) - 1
+	}
+	return c.options.Backoff[max(index, 0)]
+}
+
+func (c *Consumer) settle(err error) {
+	if err != nil {
+		c.logger.Warn("Failed to settle event", "consumer", c.options.Durable, "error", err.Error())
+	}
+}
@@ -0,0 +1,79 @@
+package broker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// SubjectPrefix namespaces Amora events, user.created is published to amora.user.created
+const SubjectPrefix = "amora"
+
+// StreamOptions describes the JetStream stream holding the domain events
+type StreamOptions struct {
+	Name     string
+	MaxAge   time.Duration
+	Replicas int
+
+	// Duplicates is how long JetStream remembers event IDs to drop redeliveries of the relay
+	Duplicates time.Duration
+}
+
+func DefaultStreamOptions() StreamOptions {
+	return StreamOptions{
+		Name:       "AMORA_EVENTS",
+		MaxAge:     7 * 24 * time.Hour,
+		Replicas:   1,
+		Duplicates: 10 * time.Minute,
+	}
+}
+
+// Subject returns the subject an event type is published to
+func Subject(eventType string) string {
+	return SubjectPrefix + "." + eventType
+}
+
+// EventType returns the event type of a subject built by Subject
+func EventType(subject string) string {
+	return strings.TrimPrefix(subject, SubjectPrefix+".")
+}
+
+// Connect opens a JetStream context on the NATS server at url
+func Connect(url string, options ...nats.Option) (*nats.Conn, jetstream.JetStream, error) {
+	options = append([]nats.Option{nats.Name("amora-backend"), nats.MaxReconnects(-1)}, options...)
+
+	conn, err := nats.Connect(url, options...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to NATS: %w", err)
+	}
+
+	js, err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+		return nil, nil, fmt.Errorf("failed to open JetStream: %w", err)
+	}
+
+	return conn, js, nil
+}
+
+// EnsureStream creates the event stream or updates its configuration
+func EnsureStream(ctx context.Context, js jetstream.JetStream, options StreamOptions) (jetstream.Stream, error) {
+	stream, err := js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:       options.Name,
+		Subjects:   []string{SubjectPrefix + ".>"},
+		Storage:    jetstream.FileStorage,
+		Retention:  jetstream.LimitsPolicy,
+		MaxAge:     options.MaxAge,
+		Replicas:   options.Replicas,
+		Duplicates: options.Duplicates,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to ensure stream %s: %w", options.Name, err)
+	}
+
+	return stream, nil
+}
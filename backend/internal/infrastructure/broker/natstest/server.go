@@ -0,0 +1,72 @@
+// Package natstest runs an in-process NATS server with JetStream, so the broker
+// adapters can be exercised without an external service.
+package natstest
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// Server is an embedded NATS server that accepts in-process connections only
+type Server struct {
+	server   *server.Server
+	storeDir string
+}
+
+// Start runs a JetStream enabled server storing its data in a temporary directory
+func Start() (*Server, error) {
+	storeDir, err := os.MkdirTemp("", "amora-nats-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create JetStream directory: %w", err)
+	}
+
+	srv, err := server.NewServer(&server.Options{
+		ServerName: "amora-test",
+		DontListen: true,
+		JetStream:  true,
+		StoreDir:   storeDir,
+		NoLog:      true,
+		NoSigs:     true,
+	})
+	if err != nil {
+		os.RemoveAll(storeDir)
+		return nil, fmt.Errorf("failed to create NATS server: %w", err)
+	}
+
+	srv.Start()
+	if !srv.ReadyForConnections(5 * time.Second) {
+		srv.Shutdown()
+		os.RemoveAll(storeDir)
+		return nil, fmt.Errorf("NATS server did not start")
+	}
+
+	return &Server{server: srv, storeDir: storeDir}, nil
+}
+
+// Connect opens an in-process connection and its JetStream context
+func (s *Server) Connect() (*nats.Conn, jetstream.JetStream, error) {
+	conn, err := nats.Connect("", nats.InProcessServer(s.server))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to NATS server: %w", err)
+	}
+
+	js, err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+		return nil, nil, fmt.Errorf("failed to open JetStream: %w", err)
+	}
+
+	return conn, js, nil
+}
+
+// Shutdown stops the server and removes its data
+func (s *Server) Shutdown() {
+	s.server.Shutdown()
+	s.server.WaitForShutdown()
+	os.RemoveAll(s.storeDir)
+}
@@ -0,0 +1,84 @@
+package broker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	appEvents "github.com/StefanPenchev05/Amora/backend/internal/application/events"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/user"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// Headers set on every published event, consumers may route on them without decoding the body
+const (
+	HeaderEventType     = "Amora-Event-Type"
+	HeaderSchemaVersion = "Amora-Schema-Version"
+	HeaderCorrelationID = "Amora-Correlation-Id"
+)
+
+// JetStreamPublisher publishes event envelopes to JetStream for other processes.
+// The event ID is the message ID, so JetStream drops redeliveries of the outbox relay.
+type JetStreamPublisher struct {
+	js       jetstream.JetStream
+	registry *appEvents.Registry
+	codec    *appEvents.JSONCodec
+}
+
+func NewJetStreamPublisher(js jetstream.JetStream, registry *appEvents.Registry) *JetStreamPublisher {
+	return &JetStreamPublisher{
+		js:       js,
+		registry: registry,
+		codec:    appEvents.NewJSONCodec(registry),
+	}
+}
+
+// PublishEvents encodes domain events that were not stored in the outbox and publishes them
+func (p *JetStreamPublisher) PublishEvents(ctx context.Context, domainEvents ...user.DomainEvent) error {
+	envelopes := make([]appEvents.Envelope, 0, len(domainEvents))
+	for _, event := range domainEvents {
+		envelope, err := p.registry.Encode(ctx, event)
+		if err != nil {
+			return err
+		}
+		envelopes = append(envelopes, envelope)
+	}
+
+	return p.Publish(ctx, envelopes...)
+}
+
+// Publish publishes the envelopes, waiting for JetStream to store each of them
+func (p *JetStreamPublisher) Publish(ctx context.Context, envelopes ...appEvents.Envelope) error {
+	var errs []error
+	for _, envelope := range envelopes {
+		data, err := p.codec.MarshalEnvelope(envelope)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		if err := p.publish(ctx, envelope, data); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (p *JetStreamPublisher) publish(ctx context.Context, envelope appEvents.Envelope, data []byte) error {
+	msg := nats.NewMsg(Subject(envelope.EventType))
+	msg.Data = data
+	msg.Header.Set(HeaderEventType, envelope.EventType)
+	if envelope.SchemaVersion > 0 {
+		msg.Header.Set(HeaderSchemaVersion, strconv.Itoa(envelope.SchemaVersion))
+	}
+	if envelope.CorrelationID != "" {
+		msg.Header.Set(HeaderCorrelationID, envelope.CorrelationID)
+	}
+
+	if _, err := p.js.PublishMsg(ctx, msg, jetstream.WithMsgID(envelope.EventID)); err != nil {
+		return fmt.Errorf("failed to publish %s to JetStream: %w", envelope.EventID, err)
+	}
+	return nil
+}
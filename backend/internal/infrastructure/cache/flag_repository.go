@@ -0,0 +1,84 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/feature"
+)
+
+// flagsKey is the cache key of the stored flags, they are read as one list
+const flagsKey = "feature:flags"
+
+// FlagStore caches the stored flags, every gated request and every login
+// evaluates them. Saving a flag invalidates the list in every instance sharing
+// the cache, the TTL bounds how long an edit made directly in the table goes unseen.
+type FlagStore struct {
+	feature.FlagStore
+	readThrough *ReadThrough
+}
+
+func NewFlagStore(next feature.FlagStore, readThrough *ReadThrough) feature.FlagStore {
+	return &FlagStore{
+		FlagStore:   next,
+		readThrough: readThrough,
+	}
+}
+
+func (s *FlagStore) ListFlags(ctx context.Context) ([]feature.Flag, error) {
+	data, err := s.readThrough.Get(ctx, flagsKey, func(ctx context.Context) ([]byte, error) {
+		flags, err := s.FlagStore.ListFlags(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return encodeFlags(flags)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeFlags(data)
+}
+
+// SaveFlag invalidates the list even when it fails, a stale version in the cache
+// is the likely reason for a concurrent modification error
+func (s *FlagStore) SaveFlag(ctx context.Context, flag *feature.Flag, expectedVersion *int64) error {
+	err := s.FlagStore.SaveFlag(ctx, flag, expectedVersion)
+	s.readThrough.Invalidate(ctx, flagsKey)
+	return err
+}
+
+// cachedFlag keeps the version, which the JSON form of a flag leaves out
+type cachedFlag struct {
+	feature.Flag
+	Version int64 `json:"version"`
+}
+
+func encodeFlags(flags []feature.Flag) ([]byte, error) {
+	cached := make([]cachedFlag, 0, len(flags))
+	for _, flag := range flags {
+		cached = append(cached, cachedFlag{Flag: flag, Version: flag.Version})
+	}
+
+	data, err := json.Marshal(cached)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode cached flags: %w", err)
+	}
+	return data, nil
+}
+
+func decodeFlags(data []byte) ([]feature.Flag, error) {
+	var cached []cachedFlag
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return nil, fmt.Errorf("failed to decode cached flags: %w", err)
+	}
+
+	flags := make([]feature.Flag, 0, len(cached))
+	for _, entry := range cached {
+		flag := entry.Flag
+		flag.Version = entry.Version
+		flags = append(flags, flag)
+	}
+	return flags, nil
+}
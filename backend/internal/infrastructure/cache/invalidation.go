@@ -0,0 +1,61 @@
+package cache
+
+import (
+	"context"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/relationship"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/user"
+)
+
+// UserInvalidationSubscriber drops cached users when events show they changed.
+// Repositories invalidate on their own writes already, the events catch up with
+// writes committed later by a unit of work and, in a shared cache, of other instances.
+type UserInvalidationSubscriber struct {
+	readThrough *ReadThrough
+}
+
+func NewUserInvalidationSubscriber(readThrough *ReadThrough) *UserInvalidationSubscriber {
+	return &UserInvalidationSubscriber{readThrough: readThrough}
+}
+
+// EventTypes lists the domain events the subscriber handles
+func (s *UserInvalidationSubscriber) EventTypes() []string {
+	return []string{
+		"user.logged_in",
+		"user.role_changed",
+		"user.password_changed",
+		"user.mfa_enabled",
+		"user.mfa_disabled",
+		"email.email_verified",
+		"relationship.invite_accepted",
+		"relationship.created",
+		"relationship.ended",
+		"relationship.reconnected",
+	}
+}
+
+func (s *UserInvalidationSubscriber) Handle(ctx context.Context, event user.DomainEvent) error {
+	keys := make([]string, 0, 2)
+	for _, userID := range changedUsers(event) {
+		keys = append(keys, UserKey(userID))
+	}
+	s.readThrough.Invalidate(ctx, keys...)
+	return nil
+}
+
+// changedUsers returns the users an event changed. Relationship events name the
+// relationship as their aggregate, the partners joining or leaving it are in the payload.
+func changedUsers(event user.DomainEvent) []string {
+	switch e := event.(type) {
+	case *relationship.InviteAcceptedEvent:
+		return []string{e.InviterID, e.InviteeID}
+	case *relationship.RelationshipCreatedEvent:
+		return []string{e.PartnerAID, e.PartnerBID}
+	case *relationship.RelationshipEndedEvent:
+		return []string{e.RequestedBy, e.ConfirmedBy}
+	case *relationship.RelationshipReconnectedEvent:
+		return []string{e.RequestedBy, e.ConfirmedBy}
+	default:
+		return []string{event.GetAggregateID()}
+	}
+}
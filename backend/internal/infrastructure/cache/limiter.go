@@ -0,0 +1,86 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/application/interfaces"
+)
+
+// MemoryAttemptLimiter counts failed attempts of this process. Like LRUCache it
+// is not shared, each instance locks out on its own count.
+type MemoryAttemptLimiter struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[string]*attempts
+}
+
+type attempts struct {
+	failures    int
+	windowEnds  time.Time
+	lockedUntil time.Time
+}
+
+// NewMemoryAttemptLimiter sweeps expired keys once capacity keys are tracked
+func NewMemoryAttemptLimiter(capacity int) interfaces.AttemptLimiter {
+	return &MemoryAttemptLimiter{
+		capacity: max(capacity, 1),
+		entries:  make(map[string]*attempts),
+	}
+}
+
+func (l *MemoryAttemptLimiter) Check(ctx context.Context, key string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if entry, ok := l.entries[key]; ok && time.Now().Before(entry.lockedUntil) {
+		return interfaces.ErrTooManyAttempts
+	}
+	return nil
+}
+
+func (l *MemoryAttemptLimiter) Fail(ctx context.Context, key string, limit interfaces.AttemptLimit) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	entry, ok := l.entries[key]
+	if !ok {
+		if len(l.entries) >= l.capacity {
+			l.sweep(now)
+		}
+		entry = &attempts{}
+		l.entries[key] = entry
+	}
+	if !now.Before(entry.windowEnds) {
+		entry.failures, entry.windowEnds = 0, now.Add(limit.Window)
+	}
+
+	entry.failures++
+	if entry.failures >= limit.MaxFailures {
+		entry.failures, entry.windowEnds = 0, time.Time{}
+		entry.lockedUntil = now.Add(limit.Lockout)
+	}
+	return nil
+}
+
+func (l *MemoryAttemptLimiter) Reset(ctx context.Context, key string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if entry, ok := l.entries[key]; ok {
+		entry.failures, entry.windowEnds = 0, time.Time{}
+	}
+	return nil
+}
+
+// sweep drops the keys with neither failures nor a lockout in force, it must be
+// called with the lock held
+func (l *MemoryAttemptLimiter) sweep(now time.Time) {
+	for key, entry := range l.entries {
+		if !now.Before(entry.windowEnds) && !now.Before(entry.lockedUntil) {
+			delete(l.entries, key)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/application/interfaces"
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/cache"
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/cache/redistest"
+)
+
+var testLimit = interfaces.AttemptLimit{MaxFailures: 3, Window: time.Minute, Lockout: time.Hour}
+
+// limiters returns each implementation with a function moving its clock forward
+func limiters() map[string]func(t *testing.T) (interfaces.AttemptLimiter, func(time.Duration)) {
+	return map[string]func(t *testing.T) (interfaces.AttemptLimiter, func(time.Duration)){
+		"redis": func(t *testing.T) (interfaces.AttemptLimiter, func(time.Duration)) {
+			srv, err := redistest.Start()
+			if err != nil {
+				t.Fatalf("start Redis: %v", err)
+			}
+			t.Cleanup(srv.Shutdown)
+			client := srv.Client()
+			t.Cleanup(func() { client.Close() })
+			return cache.NewRedisAttemptLimiter(client, "test:"), srv.FastForward
+		},
+		"memory": func(t *testing.T) (interfaces.AttemptLimiter, func(time.Duration)) {
+			// The in-process limiter reads the wall clock, tests use short limits instead
+			return cache.NewMemoryAttemptLimiter(100), nil
+		},
+	}
+}
+
+func fail(t *testing.T, limiter interfaces.AttemptLimiter, key string, limit interfaces.AttemptLimit, times int) {
+	t.Helper()
+
+	for i := 0; i < times; i++ {
+		if err := limiter.Fail(context.Background(), key, limit); err != nil {
+			t.Fatalf("fail %s: %v", key, err)
+		}
+	}
+}
+
+func TestAttemptLimiterLocksOutAtMaxFailures(t *testing.T) {
+	for name, start := range limiters() {
+		t.Run(name, func(t *testing.T) {
+			limiter, _ := start(t)
+			ctx := context.Background()
+
+			fail(t, limiter, "invite_code:user:1", testLimit, testLimit.MaxFailures-1)
+			if err := limiter.Check(ctx, "invite_code:user:1"); err != nil {
+				t.Fatalf("check below the limit = %v, want nil", err)
+			}
+
+			fail(t, limiter, "invite_code:user:1", testLimit, 1)
+			if err := limiter.Check(ctx, "invite_code:user:1"); !errors.Is(err, interfaces.ErrTooManyAttempts) {
+				t.Errorf("check at the limit = %v, want %v", err, interfaces.ErrTooManyAttempts)
+			}
+			if err := limiter.Check(ctx, "invite_code:user:2"); err != nil {
+				t.Errorf("other key check = %v, want nil", err)
+			}
+		})
+	}
+}
+
+func TestAttemptLimiterResetKeepsLockout(t *testing.T) {
+	for name, start := range limiters() {
+		t.Run(name, func(t *testing.T) {
+			limiter, _ := start(t)
+			ctx := context.Background()
+
+			// A success forgets earlier failures
+			fail(t, limiter, "user:1", testLimit, testLimit.MaxFailures-1)
+			if err := limiter.Reset(ctx, "user:1"); err != nil {
+				t.Fatalf("reset: %v", err)
+			}
+			fail(t, limiter, "user:1", testLimit, testLimit.MaxFailures-1)
+			if err := limiter.Check(ctx, "user:1"); err != nil {
+				t.Fatalf("check after reset = %v, want nil", err)
+			}
+
+			// but does not lift a lockout in force
+			fail(t, limiter, "user:1", testLimit, 1)
+			if err := limiter.Reset(ctx, "user:1"); err != nil {
+				t.Fatalf("reset: %v", err)
+			}
+			if err := limiter.Check(ctx, "user:1"); !errors.Is(err, interfaces.ErrTooManyAttempts) {
+				t.Errorf("check after reset of a lockout = %v, want %v", err, interfaces.ErrTooManyAttempts)
+			}
+		})
+	}
+}
+
+func TestAttemptLimiterForgetsFailuresAfterWindowAndLockout(t *testing.T) {
+	for name, start := range limiters() {
+		t.Run(name, func(t *testing.T) {
+			limiter, fastForward := start(t)
+			ctx := context.Background()
+
+			limit := testLimit
+			wait := fastForward
+			if fastForward == nil {
+				limit = interfaces.AttemptLimit{MaxFailures: 3, Window: 20 * time.Millisecond, Lockout: 20 * time.Millisecond}
+				wait = func(d time.Duration) { time.Sleep(d) }
+			}
+
+			fail(t, limiter, "ip:203.0.113.7", limit, limit.MaxFailures-1)
+			wait(limit.Window + time.Millisecond)
+			fail(t, limiter, "ip:203.0.113.7", limit, 1)
+			if err := limiter.Check(ctx, "ip:203.0.113.7"); err != nil {
+				t.Fatalf("failures of an expired window counted: %v", err)
+			}
+
+			fail(t, limiter, "ip:203.0.113.7", limit, limit.MaxFailures-1)
+			if err := limiter.Check(ctx, "ip:203.0.113.7"); !errors.Is(err, interfaces.ErrTooManyAttempts) {
+				t.Fatalf("check = %v, want %v", err, interfaces.ErrTooManyAttempts)
+			}
+			wait(limit.Lockout + time.Millisecond)
+			if err := limiter.Check(ctx, "ip:203.0.113.7"); err != nil {
+				t.Errorf("check after the lockout = %v, want nil", err)
+			}
+		})
+	}
+}
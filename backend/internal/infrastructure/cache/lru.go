@@ -0,0 +1,92 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/application/interfaces"
+)
+
+// LRUCache keeps the most recently used entries of this process in memory.
+// Other instances do not see its invalidations, keep the TTL short when
+// several instances share a database.
+type LRUCache struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[string]*list.Element
+
+	// order holds the most recently used entry at the front
+	order *list.List
+}
+
+type lruEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+func NewLRUCache(capacity int) interfaces.Cache {
+	return &LRUCache{
+		capacity: max(capacity, 1),
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+func (c *LRUCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+
+	entry := element.Value.(*lruEntry)
+	if time.Now().After(entry.expiresAt) {
+		c.remove(element)
+		return nil, false, nil
+	}
+
+	c.order.MoveToFront(element)
+	return entry.value, true, nil
+}
+
+func (c *LRUCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiresAt := time.Now().Add(ttl)
+	if element, ok := c.entries[key]; ok {
+		entry := element.Value.(*lruEntry)
+		entry.value, entry.expiresAt = value, expiresAt
+		c.order.MoveToFront(element)
+		return nil
+	}
+
+	c.entries[key] = c.order.PushFront(&lruEntry{key: key, value: value, expiresAt: expiresAt})
+	for c.order.Len() > c.capacity {
+		c.remove(c.order.Back())
+	}
+	return nil
+}
+
+func (c *LRUCache) Delete(ctx context.Context, keys ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		if element, ok := c.entries[key]; ok {
+			c.remove(element)
+		}
+	}
+	return nil
+}
+
+// remove must be called with the lock held
+func (c *LRUCache) remove(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*lruEntry).key)
+}
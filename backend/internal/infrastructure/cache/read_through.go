@@ -0,0 +1,93 @@
+package cache
+
+import (
+	"context"
+	"log/slog"
+	"math/rand/v2"
+	"time"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/application/interfaces"
+	"golang.org/x/sync/singleflight"
+)
+
+// ReadThrough loads missing entries and stores them in the cache. Concurrent
+// misses of one key share a single load, and TTLs are jittered, so a hot key
+// expiring does not send a stampede of identical queries to the database.
+type ReadThrough struct {
+	cache  interfaces.Cache
+	ttl    time.Duration
+	group  singleflight.Group
+	logger *slog.Logger
+}
+
+func NewReadThrough(cache interfaces.Cache, ttl time.Duration, logger *slog.Logger) *ReadThrough {
+	return &ReadThrough{
+		cache:  cache,
+		ttl:    ttl,
+		logger: logger,
+	}
+}
+
+// Get returns the cached value of key, or the value load returns. Cache failures
+// are logged and the value is loaded as if the cache were empty. Reads asking for
+// the primary and reads inside a unit of work always load and leave the cache
+// alone, the unit has to see its own uncommitted writes and must not cache them.
+func (rt *ReadThrough) Get(ctx context.Context, key string, load func(ctx context.Context) ([]byte, error)) ([]byte, error) {
+	if bypasses(ctx) {
+		return load(ctx)
+	}
+
+	if value, found, err := rt.cache.Get(ctx, key); err != nil {
+		rt.logger.Warn("Failed to read cache", "key", key, "error", err.Error())
+	} else if found {
+		return value, nil
+	}
+
+	// The shared load outlives a caller giving up, the others still wait for it
+	result := rt.group.DoChan(key, func() (interface{}, error) {
+		loadCtx := context.WithoutCancel(ctx)
+		value, err := load(loadCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := rt.cache.Set(loadCtx, key, value, rt.jitteredTTL()); err != nil {
+			rt.logger.Warn("Failed to write cache", "key", key, "error", err.Error())
+		}
+		return value, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case loaded := <-result:
+		if loaded.Err != nil {
+			return nil, loaded.Err
+		}
+		return loaded.Val.([]byte), nil
+	}
+}
+
+// Invalidate drops the keys and forgets loads in flight, so the next read sees the change
+func (rt *ReadThrough) Invalidate(ctx context.Context, keys ...string) {
+	for _, key := range keys {
+		rt.group.Forget(key)
+	}
+	if err := rt.cache.Delete(ctx, keys...); err != nil {
+		rt.logger.Warn("Failed to invalidate cache", "keys", keys, "error", err.Error())
+	}
+}
+
+// jitteredTTL spreads expiries over the last tenth of the TTL
+func (rt *ReadThrough) jitteredTTL() time.Duration {
+	jitter := rt.ttl / 10
+	if jitter <= 0 {
+		return rt.ttl
+	}
+	return rt.ttl - rand.N(jitter)
+}
+
+// bypasses reports whether reads in ctx must skip the cache
+func bypasses(ctx context.Context) bool {
+	return interfaces.ReadsFromPrimary(ctx) || interfaces.InUnitOfWork(ctx)
+}
@@ -0,0 +1,265 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/application/interfaces"
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/cache"
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/cache/redistest"
+)
+
+var discardLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+// startRedis returns a Redis cache on a fresh in-memory server
+func startRedis(t *testing.T) (*redistest.Server, interfaces.Cache) {
+	t.Helper()
+
+	srv, err := redistest.Start()
+	if err != nil {
+		t.Fatalf("start Redis: %v", err)
+	}
+	t.Cleanup(srv.Shutdown)
+
+	client := srv.Client()
+	t.Cleanup(func() { client.Close() })
+
+	return srv, cache.NewRedisCache(client, "test:")
+}
+
+// countingLoad returns a load function answering value and counting its calls
+func countingLoad(value string, calls *atomic.Int64) func(ctx context.Context) ([]byte, error) {
+	return func(ctx context.Context) ([]byte, error) {
+		calls.Add(1)
+		return []byte(value), nil
+	}
+}
+
+func get(t *testing.T, ctx context.Context, rt *cache.ReadThrough, key string, load func(ctx context.Context) ([]byte, error)) string {
+	t.Helper()
+
+	value, err := rt.Get(ctx, key, load)
+	if err != nil {
+		t.Fatalf("get %s: %v", key, err)
+	}
+	return string(value)
+}
+
+func TestReadThroughLoadsOnMissAndServesHits(t *testing.T) {
+	srv, redisCache := startRedis(t)
+	rt := cache.NewReadThrough(redisCache, time.Minute, discardLogger)
+	ctx := context.Background()
+
+	var calls atomic.Int64
+	for i := 0; i < 3; i++ {
+		if got := get(t, ctx, rt, "user:id:1", countingLoad("alice", &calls)); got != "alice" {
+			t.Fatalf("get = %q, want alice", got)
+		}
+	}
+
+	if calls.Load() != 1 {
+		t.Errorf("loaded %d times, want once", calls.Load())
+	}
+	if stored, err := srv.Get("test:user:id:1"); err != nil || stored != "alice" {
+		t.Errorf("Redis holds %q (%v), want alice", stored, err)
+	}
+	if ttl := srv.TTL("test:user:id:1"); ttl <= 0 || ttl > time.Minute {
+		t.Errorf("TTL = %s, want within a minute", ttl)
+	}
+}
+
+func TestReadThroughDoesNotCacheFailedLoads(t *testing.T) {
+	_, redisCache := startRedis(t)
+	rt := cache.NewReadThrough(redisCache, time.Minute, discardLogger)
+	ctx := context.Background()
+
+	errLoad := errors.New("database is down")
+	_, err := rt.Get(ctx, "user:id:1", func(ctx context.Context) ([]byte, error) { return nil, errLoad })
+	if !errors.Is(err, errLoad) {
+		t.Fatalf("get error = %v, want %v", err, errLoad)
+	}
+
+	var calls atomic.Int64
+	if got := get(t, ctx, rt, "user:id:1", countingLoad("alice", &calls)); got != "alice" || calls.Load() != 1 {
+		t.Errorf("get = %q after %d loads, want alice after one", got, calls.Load())
+	}
+}
+
+func TestReadThroughCollapsesConcurrentMisses(t *testing.T) {
+	_, redisCache := startRedis(t)
+	rt := cache.NewReadThrough(redisCache, time.Minute, discardLogger)
+	ctx := context.Background()
+
+	var calls atomic.Int64
+	release := make(chan struct{})
+	load := func(ctx context.Context) ([]byte, error) {
+		calls.Add(1)
+		<-release
+		return []byte("alice"), nil
+	}
+
+	const readers = 20
+	var started, done sync.WaitGroup
+	started.Add(readers)
+	done.Add(readers)
+	values := make([]string, readers)
+	for i := 0; i < readers; i++ {
+		go func(i int) {
+			defer done.Done()
+			started.Done()
+			value, err := rt.Get(ctx, "user:id:1", load)
+			if err != nil {
+				t.Errorf("get: %v", err)
+				return
+			}
+			values[i] = string(value)
+		}(i)
+	}
+
+	// Hold the load until every reader missed the cache
+	started.Wait()
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	done.Wait()
+
+	if calls.Load() != 1 {
+		t.Errorf("loaded %d times for %d concurrent readers, want once", calls.Load(), readers)
+	}
+	for i, value := range values {
+		if value != "alice" {
+			t.Errorf("reader %d got %q, want alice", i, value)
+		}
+	}
+}
+
+func TestReadThroughInvalidateReloads(t *testing.T) {
+	srv, redisCache := startRedis(t)
+	rt := cache.NewReadThrough(redisCache, time.Minute, discardLogger)
+	ctx := context.Background()
+
+	var calls atomic.Int64
+	get(t, ctx, rt, "user:id:1", countingLoad("alice", &calls))
+	rt.Invalidate(ctx, "user:id:1")
+
+	if srv.Exists("test:user:id:1") {
+		t.Error("invalidated key is still in Redis")
+	}
+	if got := get(t, ctx, rt, "user:id:1", countingLoad("alicia", &calls)); got != "alicia" {
+		t.Errorf("get after invalidation = %q, want alicia", got)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("loaded %d times, want twice", calls.Load())
+	}
+}
+
+func TestReadThroughBypassesCacheForPrimaryReadsAndUnits(t *testing.T) {
+	srv, redisCache := startRedis(t)
+	rt := cache.NewReadThrough(redisCache, time.Minute, discardLogger)
+
+	var calls atomic.Int64
+	get(t, context.Background(), rt, "user:id:1", countingLoad("alice", &calls))
+
+	bypassing := map[string]context.Context{
+		"primary reads": interfaces.WithPrimaryReads(context.Background()),
+		"unit of work":  interfaces.WithUnitHooks(context.Background(), &interfaces.UnitHooks{}),
+	}
+	for name, ctx := range bypassing {
+		if got := get(t, ctx, rt, "user:id:1", countingLoad("uncommitted", &calls)); got != "uncommitted" {
+			t.Errorf("%s: get = %q, want the loaded value", name, got)
+		}
+	}
+
+	if calls.Load() != 3 {
+		t.Errorf("loaded %d times, want every bypassing read to load", calls.Load())
+	}
+	if stored, _ := srv.Get("test:user:id:1"); stored != "alice" {
+		t.Errorf("Redis holds %q, bypassing reads must not write the cache", stored)
+	}
+}
+
+func TestReadThroughFallsBackToLoadWhenRedisIsDown(t *testing.T) {
+	srv, redisCache := startRedis(t)
+	rt := cache.NewReadThrough(redisCache, time.Minute, discardLogger)
+	srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var calls atomic.Int64
+	for i := 0; i < 2; i++ {
+		if got := get(t, ctx, rt, "user:id:1", countingLoad("alice", &calls)); got != "alice" {
+			t.Fatalf("get = %q, want alice", got)
+		}
+	}
+	if calls.Load() != 2 {
+		t.Errorf("loaded %d times, want every read to load", calls.Load())
+	}
+}
+
+func TestLRUCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	lru := cache.NewLRUCache(2)
+	ctx := context.Background()
+
+	for _, key := range []string{"a", "b"} {
+		if err := lru.Set(ctx, key, []byte(key), time.Minute); err != nil {
+			t.Fatalf("set %s: %v", key, err)
+		}
+	}
+	// Reading a makes b the least recently used
+	if _, found, _ := lru.Get(ctx, "a"); !found {
+		t.Fatal("a is missing")
+	}
+	if err := lru.Set(ctx, "c", []byte("c"), time.Minute); err != nil {
+		t.Fatalf("set c: %v", err)
+	}
+
+	for key, want := range map[string]bool{"a": true, "b": false, "c": true} {
+		if _, found, _ := lru.Get(ctx, key); found != want {
+			t.Errorf("%s found = %t, want %t", key, found, want)
+		}
+	}
+}
+
+func TestLRUCacheExpiresEntries(t *testing.T) {
+	lru := cache.NewLRUCache(10)
+	rt := cache.NewReadThrough(lru, 20*time.Millisecond, discardLogger)
+	ctx := context.Background()
+
+	var calls atomic.Int64
+	get(t, ctx, rt, "user:id:1", countingLoad("alice", &calls))
+	get(t, ctx, rt, "user:id:1", countingLoad("alice", &calls))
+	time.Sleep(30 * time.Millisecond)
+	get(t, ctx, rt, "user:id:1", countingLoad("alice", &calls))
+
+	if calls.Load() != 2 {
+		t.Errorf("loaded %d times, want once before and once after the TTL", calls.Load())
+	}
+}
+
+func TestLRUCacheDeletesKeys(t *testing.T) {
+	lru := cache.NewLRUCache(10)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		key := fmt.Sprintf("k%d", i)
+		if err := lru.Set(ctx, key, []byte(key), time.Minute); err != nil {
+			t.Fatalf("set %s: %v", key, err)
+		}
+	}
+	if err := lru.Delete(ctx, "k0", "k2", "missing"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+
+	for key, want := range map[string]bool{"k0": false, "k1": true, "k2": false} {
+		if _, found, _ := lru.Get(ctx, key); found != want {
+			t.Errorf("%s found = %t, want %t", key, found, want)
+		}
+	}
+}
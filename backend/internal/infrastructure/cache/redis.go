@@ -0,0 +1,76 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/application/interfaces"
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisCache shares cached entries and their invalidations across instances.
+// It speaks the Redis protocol, so Valkey, KeyDB and the like work as well.
+type RedisCache struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+// NewRedisCache namespaces every key with prefix, so several services can share one server
+func NewRedisCache(client redis.UniversalClient, prefix string) interfaces.Cache {
+	return &RedisCache{
+		client: client,
+		prefix: prefix,
+	}
+}
+
+// Connect opens a client from a redis:// or rediss:// URL and checks the server answers
+func Connect(ctx context.Context, url string) (*redis.Client, error) {
+	options, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Redis URL: %w", err)
+	}
+
+	client := redis.NewClient(options)
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+
+	return client, nil
+}
+
+func (c *RedisCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, err := c.client.Get(ctx, c.prefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read cache: %w", err)
+	}
+
+	return value, true, nil
+}
+
+func (c *RedisCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if err := c.client.Set(ctx, c.prefix+key, value, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to write cache: %w", err)
+	}
+	return nil
+}
+
+func (c *RedisCache) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	prefixed := make([]string, len(keys))
+	for i, key := range keys {
+		prefixed[i] = c.prefix + key
+	}
+	if err := c.client.Del(ctx, prefixed...).Err(); err != nil {
+		return fmt.Errorf("failed to invalidate cache: %w", err)
+	}
+	return nil
+}
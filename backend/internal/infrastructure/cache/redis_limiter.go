@@ -0,0 +1,72 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/application/interfaces"
+	"github.com/redis/go-redis/v9"
+)
+
+// failScript counts a failure in KEYS[1], opening a window of ARGV[1] ms on the
+// first one. At ARGV[2] failures KEYS[2] locks the key out for ARGV[3] ms.
+var failScript = redis.NewScript(`
+local failures = redis.call('INCR', KEYS[1])
+if failures == 1 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[1])
+end
+if failures >= tonumber(ARGV[2]) then
+	redis.call('SET', KEYS[2], '1', 'PX', ARGV[3])
+	redis.call('DEL', KEYS[1])
+end
+return failures
+`)
+
+// RedisAttemptLimiter shares failure counts and lockouts across instances
+type RedisAttemptLimiter struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+// NewRedisAttemptLimiter namespaces every key with prefix
+func NewRedisAttemptLimiter(client redis.UniversalClient, prefix string) interfaces.AttemptLimiter {
+	return &RedisAttemptLimiter{
+		client: client,
+		prefix: prefix,
+	}
+}
+
+func (l *RedisAttemptLimiter) failuresKey(key string) string {
+	return l.prefix + "attempts:failures:" + key
+}
+
+func (l *RedisAttemptLimiter) lockKey(key string) string {
+	return l.prefix + "attempts:locked:" + key
+}
+
+func (l *RedisAttemptLimiter) Check(ctx context.Context, key string) error {
+	locked, err := l.client.Exists(ctx, l.lockKey(key)).Result()
+	if err != nil {
+		return fmt.Errorf("failed to check attempts: %w", err)
+	}
+	if locked > 0 {
+		return interfaces.ErrTooManyAttempts
+	}
+	return nil
+}
+
+func (l *RedisAttemptLimiter) Fail(ctx context.Context, key string, limit interfaces.AttemptLimit) error {
+	keys := []string{l.failuresKey(key), l.lockKey(key)}
+	err := failScript.Run(ctx, l.client, keys, limit.Window.Milliseconds(), limit.MaxFailures, limit.Lockout.Milliseconds()).Err()
+	if err != nil {
+		return fmt.Errorf("failed to count attempt: %w", err)
+	}
+	return nil
+}
+
+func (l *RedisAttemptLimiter) Reset(ctx context.Context, key string) error {
+	if err := l.client.Del(ctx, l.failuresKey(key)).Err(); err != nil {
+		return fmt.Errorf("failed to reset attempts: %w", err)
+	}
+	return nil
+}
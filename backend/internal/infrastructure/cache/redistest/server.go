@@ -0,0 +1,34 @@
+// Package redistest runs an in-process Redis-compatible server, so the Redis
+// cache can be exercised without an external service.
+package redistest
+
+import (
+	"fmt"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+// Server is an in-memory Redis server listening on a random local port
+type Server struct {
+	*miniredis.Miniredis
+}
+
+func Start() (*Server, error) {
+	srv, err := miniredis.Run()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start Redis server: %w", err)
+	}
+
+	return &Server{Miniredis: srv}, nil
+}
+
+// Client opens a client connected to the server
+func (s *Server) Client() *redis.Client {
+	return redis.NewClient(&redis.Options{Addr: s.Addr()})
+}
+
+// Shutdown stops the server and drops its data
+func (s *Server) Shutdown() {
+	s.Close()
+}
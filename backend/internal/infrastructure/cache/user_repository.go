@@ -0,0 +1,248 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/application/interfaces"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/user"
+)
+
+// ErrCredentialsNotLoaded is returned when saving a user read from the cache
+var ErrCredentialsNotLoaded = errors.New("user was read from the cache without its credentials")
+
+// UserRepository caches the users read by ID, email and username. Users are
+// stored under their ID, email and username keys only point to the ID, so an
+// update invalidates a single entry and a stale pointer is detected on read.
+// The password hash and the MFA secret are never cached, callers checking or
+// changing credentials read from the primary or inside a unit of work.
+type UserRepository struct {
+	user.Repository
+	readThrough *ReadThrough
+}
+
+func NewUserRepository(next user.Repository, readThrough *ReadThrough) user.Repository {
+	return &UserRepository{
+		Repository:  next,
+		readThrough: readThrough,
+	}
+}
+
+// UserKey is the cache key of the user with the ID
+func UserKey(id string) string {
+	return "user:id:" + id
+}
+
+func emailKey(email user.Email) string {
+	return "user:email:" + strings.ToLower(email.String())
+}
+
+func usernameKey(username user.Username) string {
+	return "user:username:" + strings.ToLower(username.String())
+}
+
+// GetByID reads a user with its credentials when the cache is bypassed, and
+// without them when it comes from the cache
+func (r *UserRepository) GetByID(ctx context.Context, id string) (*user.User, error) {
+	if bypasses(ctx) {
+		return r.Repository.GetByID(ctx, id)
+	}
+
+	data, err := r.readThrough.Get(ctx, UserKey(id), func(ctx context.Context) ([]byte, error) {
+		u, err := r.Repository.GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		return encodeUser(u)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeUser(data)
+}
+
+func (r *UserRepository) GetByEmail(ctx context.Context, email user.Email) (*user.User, error) {
+	return r.getByIndex(ctx, emailKey(email),
+		func(ctx context.Context) (*user.User, error) { return r.Repository.GetByEmail(ctx, email) },
+		func(u *user.User) bool { return u.Credentials.Email.Equals(email) },
+	)
+}
+
+func (r *UserRepository) GetByUsername(ctx context.Context, username user.Username) (*user.User, error) {
+	return r.getByIndex(ctx, usernameKey(username),
+		func(ctx context.Context) (*user.User, error) { return r.Repository.GetByUsername(ctx, username) },
+		func(u *user.User) bool { return u.Credentials.Username.Equals(username) },
+	)
+}
+
+// Update invalidates the user even when it fails, a stale version in the cache
+// is the likely reason for a concurrent modification error. Inside a unit of work
+// the user is invalidated again after the commit, a read in between may have
+// cached the old row. Users read from the cache lack their credentials and are
+// refused, saving them would wipe the password and the MFA secret.
+func (r *UserRepository) Update(ctx context.Context, u *user.User) error {
+	if u.Credentials.PasswordHash.String() == "" {
+		return fmt.Errorf("%w: user %s", ErrCredentialsNotLoaded, u.ID)
+	}
+
+	err := r.Repository.Update(ctx, u)
+	r.invalidate(ctx, UserKey(u.ID))
+	return err
+}
+
+func (r *UserRepository) DeleteByID(ctx context.Context, id string) error {
+	err := r.Repository.DeleteByID(ctx, id)
+	r.invalidate(ctx, UserKey(id))
+	return err
+}
+
+func (r *UserRepository) invalidate(ctx context.Context, key string) {
+	r.readThrough.Invalidate(ctx, key)
+	if interfaces.InUnitOfWork(ctx) {
+		interfaces.AfterCommit(ctx, func(ctx context.Context) {
+			r.readThrough.Invalidate(ctx, key)
+		})
+	}
+}
+
+// getByIndex resolves the ID through the index key, then reads the user by ID.
+// An index left behind by an email or username change is dropped and reloaded.
+func (r *UserRepository) getByIndex(
+	ctx context.Context,
+	key string,
+	load func(ctx context.Context) (*user.User, error),
+	matches func(u *user.User) bool,
+) (*user.User, error) {
+	if bypasses(ctx) {
+		return load(ctx)
+	}
+
+	for attempt := 0; attempt < 2; attempt++ {
+		id, err := r.readThrough.Get(ctx, key, func(ctx context.Context) ([]byte, error) {
+			u, err := load(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return []byte(u.ID), nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		u, err := r.GetByID(ctx, string(id))
+		if err != nil && !errors.Is(err, user.ErrNotFound) {
+			return nil, err
+		}
+		if err == nil && matches(u) {
+			return u, nil
+		}
+		r.readThrough.Invalidate(ctx, key)
+	}
+
+	return load(ctx)
+}
+
+// cachedUser is the cached form of the aggregate, pending events and secrets are never cached
+type cachedUser struct {
+	ID        string    `json:"id"`
+	Role      string    `json:"role"`
+	Version   int64     `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Email         string     `json:"email"`
+	Username      string     `json:"username"`
+	EmailVerified bool       `json:"email_verified"`
+	MfaEnabled    bool       `json:"mfa_enabled"`
+	LastLoginAt   *time.Time `json:"last_login_at,omitempty"`
+
+	FirstName      string     `json:"first_name"`
+	LastName       string     `json:"last_name"`
+	Gender         string     `json:"gender"`
+	DateOfBirth    *time.Time `json:"date_of_birth,omitempty"`
+	Bio            *string    `json:"bio,omitempty"`
+	DisplayName    *string    `json:"display_name,omitempty"`
+	AvatarPhotoID  *string    `json:"avatar_photo_id,omitempty"`
+	RelationshipID *string    `json:"relationship_id,omitempty"`
+	Locale         string     `json:"locale"`
+	Timezone       string     `json:"timezone"`
+}
+
+func encodeUser(u *user.User) ([]byte, error) {
+	data, err := json.Marshal(cachedUser{
+		ID:             u.ID,
+		Role:           u.Role.String(),
+		Version:        u.Version,
+		CreatedAt:      u.CreatedAt,
+		UpdatedAt:      u.UpdatedAt,
+		Email:          u.Credentials.Email.String(),
+		Username:       u.Credentials.Username.String(),
+		EmailVerified:  u.Credentials.EmailVerified,
+		MfaEnabled:     u.Credentials.MfaEnabled,
+		LastLoginAt:    u.Credentials.LastLoginAt,
+		FirstName:      u.Profile.FirstName,
+		LastName:       u.Profile.LastName,
+		Gender:         string(u.Profile.Gender),
+		DateOfBirth:    u.Profile.DateOfBirth,
+		Bio:            u.Profile.Bio,
+		DisplayName:    u.Profile.DisplayName,
+		AvatarPhotoID:  u.Profile.AvatarPhotoID,
+		RelationshipID: u.Profile.RelationshipID,
+		Locale:         u.Profile.Locale,
+		Timezone:       u.Profile.Timezone,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode cached user: %w", err)
+	}
+	return data, nil
+}
+
+func decodeUser(data []byte) (*user.User, error) {
+	var cached cachedUser
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return nil, fmt.Errorf("failed to decode cached user: %w", err)
+	}
+
+	email, err := user.NewEmail(cached.Email)
+	if err != nil {
+		return nil, fmt.Errorf("cached email of user %s is invalid: %w", cached.ID, err)
+	}
+	username, err := user.NewUsername(cached.Username)
+	if err != nil {
+		return nil, fmt.Errorf("cached username of user %s is invalid: %w", cached.ID, err)
+	}
+
+	return &user.User{
+		ID:        cached.ID,
+		Role:      user.Role(cached.Role),
+		Version:   cached.Version,
+		CreatedAt: cached.CreatedAt,
+		UpdatedAt: cached.UpdatedAt,
+		Credentials: user.Credentials{
+			UserID:        cached.ID,
+			Email:         email,
+			Username:      username,
+			EmailVerified: cached.EmailVerified,
+			MfaEnabled:    cached.MfaEnabled,
+			LastLoginAt:   cached.LastLoginAt,
+		},
+		Profile: user.Profile{
+			UserID:         cached.ID,
+			FirstName:      cached.FirstName,
+			LastName:       cached.LastName,
+			Gender:         user.Gender(cached.Gender),
+			DateOfBirth:    cached.DateOfBirth,
+			Bio:            cached.Bio,
+			DisplayName:    cached.DisplayName,
+			AvatarPhotoID:  cached.AvatarPhotoID,
+			RelationshipID: cached.RelationshipID,
+			Locale:         cached.Locale,
+			Timezone:       cached.Timezone,
+		},
+	}, nil
+}
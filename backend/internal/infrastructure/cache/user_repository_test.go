@@ -0,0 +1,210 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/application/interfaces"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/relationship"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/user"
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/cache"
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/cache/redistest"
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/persistence/memory"
+)
+
+const password = "Correct-Horse-9"
+
+type userFixture struct {
+	srv         *redistest.Server
+	readThrough *cache.ReadThrough
+	users       user.Repository
+	unitOfWork  interfaces.UnitOfWork
+}
+
+func setupUsers(t *testing.T) *userFixture {
+	t.Helper()
+
+	srv, redisCache := startRedis(t)
+	stored := memory.NewUserRepository(memory.NewOutboxStore())
+	readThrough := cache.NewReadThrough(redisCache, time.Minute, discardLogger)
+
+	return &userFixture{
+		srv:         srv,
+		readThrough: readThrough,
+		users:       cache.NewUserRepository(stored, readThrough),
+		unitOfWork:  memory.NewUnitOfWork(stored),
+	}
+}
+
+func (f *userFixture) create(t *testing.T, username string) *user.User {
+	t.Helper()
+
+	u, err := user.NewUser(username+"@example.com", username, "Test", "User", password)
+	if err != nil {
+		t.Fatalf("new user: %v", err)
+	}
+	if err := f.users.Create(context.Background(), u); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	return u
+}
+
+func (f *userFixture) cached(key string) bool {
+	return f.srv.Exists("test:" + key)
+}
+
+func TestUserRepositoryNeverCachesCredentials(t *testing.T) {
+	f := setupUsers(t)
+	created := f.create(t, "alice")
+	ctx := context.Background()
+
+	fromCache, err := f.users.GetByUsername(ctx, created.Credentials.Username)
+	if err != nil {
+		t.Fatalf("get by username: %v", err)
+	}
+	stored, err := f.srv.Get("test:" + cache.UserKey(created.ID))
+	if err != nil {
+		t.Fatalf("user was not cached: %v", err)
+	}
+	if strings.Contains(stored, "password") || strings.Contains(stored, created.Credentials.PasswordHash.String()) {
+		t.Errorf("cached user holds the password hash: %s", stored)
+	}
+
+	// A cached copy cannot be saved, it would wipe the credentials
+	fromCache.Profile.FirstName = "Alicia"
+	if err := f.users.Update(ctx, fromCache); !errors.Is(err, cache.ErrCredentialsNotLoaded) {
+		t.Errorf("update of a cached user = %v, want %v", err, cache.ErrCredentialsNotLoaded)
+	}
+
+	fromPrimary, err := f.users.GetByUsername(interfaces.WithPrimaryReads(ctx), created.Credentials.Username)
+	if err != nil {
+		t.Fatalf("get from primary: %v", err)
+	}
+	if !fromPrimary.Credentials.PasswordHash.Verify(password) {
+		t.Error("user read from the primary cannot verify its password")
+	}
+}
+
+func TestUserRepositoryUpdateInvalidates(t *testing.T) {
+	f := setupUsers(t)
+	created := f.create(t, "alice")
+	ctx := context.Background()
+
+	if _, err := f.users.GetByID(ctx, created.ID); err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if !f.cached(cache.UserKey(created.ID)) {
+		t.Fatal("user was not cached")
+	}
+
+	loaded, err := f.users.GetByID(interfaces.WithPrimaryReads(ctx), created.ID)
+	if err != nil {
+		t.Fatalf("get from primary: %v", err)
+	}
+	loaded.Profile.FirstName = "Alicia"
+	if err := f.users.Update(ctx, loaded); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+
+	if f.cached(cache.UserKey(created.ID)) {
+		t.Error("updated user is still cached")
+	}
+	got, err := f.users.GetByID(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("get after update: %v", err)
+	}
+	if got.Profile.FirstName != "Alicia" {
+		t.Errorf("first name = %q, want Alicia", got.Profile.FirstName)
+	}
+}
+
+func TestUserRepositoryInvalidatesAgainAfterCommit(t *testing.T) {
+	f := setupUsers(t)
+	created := f.create(t, "alice")
+	key := cache.UserKey(created.ID)
+
+	err := f.unitOfWork.Do(context.Background(), func(ctx context.Context) error {
+		loaded, err := f.users.GetByID(ctx, created.ID)
+		if err != nil {
+			return err
+		}
+		loaded.Profile.FirstName = "Alicia"
+		if err := f.users.Update(ctx, loaded); err != nil {
+			return err
+		}
+
+		// Another request reads the row before the unit commits and caches it
+		if _, err := f.users.GetByID(context.Background(), created.ID); err != nil {
+			return err
+		}
+		if !f.cached(key) {
+			t.Error("concurrent read did not cache the user")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unit of work: %v", err)
+	}
+
+	if f.cached(key) {
+		t.Error("user cached during the unit is still cached after the commit")
+	}
+}
+
+func TestUserRepositoryKeepsCacheWhenUnitRollsBack(t *testing.T) {
+	f := setupUsers(t)
+	created := f.create(t, "alice")
+	key := cache.UserKey(created.ID)
+	errAbort := errors.New("abort")
+
+	err := f.unitOfWork.Do(context.Background(), func(ctx context.Context) error {
+		loaded, err := f.users.GetByID(ctx, created.ID)
+		if err != nil {
+			return err
+		}
+		if err := f.users.Update(ctx, loaded); err != nil {
+			return err
+		}
+
+		if _, err := f.users.GetByID(context.Background(), created.ID); err != nil {
+			return err
+		}
+		return errAbort
+	})
+	if !errors.Is(err, errAbort) {
+		t.Fatalf("unit of work = %v, want %v", err, errAbort)
+	}
+
+	if !f.cached(key) {
+		t.Error("rolled back unit invalidated the user after the fact")
+	}
+}
+
+func TestUserInvalidationSubscriberDropsPartnersOfRelationshipEvents(t *testing.T) {
+	f := setupUsers(t)
+	alice := f.create(t, "alice")
+	bob := f.create(t, "bob")
+	carol := f.create(t, "carol")
+	ctx := context.Background()
+
+	for _, u := range []*user.User{alice, bob, carol} {
+		if _, err := f.users.GetByID(ctx, u.ID); err != nil {
+			t.Fatalf("get %s: %v", u.ID, err)
+		}
+	}
+
+	subscriber := cache.NewUserInvalidationSubscriber(f.readThrough)
+	event := relationship.NewRelationshipCreatedEvent("relationship-1", alice.ID, bob.ID)
+	if err := subscriber.Handle(ctx, event); err != nil {
+		t.Fatalf("handle: %v", err)
+	}
+
+	for u, want := range map[*user.User]bool{alice: false, bob: false, carol: true} {
+		if got := f.cached(cache.UserKey(u.ID)); got != want {
+			t.Errorf("%s cached = %t, want %t", u.Credentials.Username, got, want)
+		}
+	}
+}
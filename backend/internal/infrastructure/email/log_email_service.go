@@ -0,0 +1,50 @@
+package email
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/application/interfaces"
+)
+
+// LogEmailService writes emails to the log instead of sending them, used until
+// an email provider is configured
+type LogEmailService struct {
+	logger *slog.Logger
+}
+
+func NewLogEmailService(logger *slog.Logger) interfaces.EmailService {
+	return &LogEmailService{
+		logger: logger,
+	}
+}
+
+func (s *LogEmailService) SendWelcomeEmail(ctx context.Context, email, firstName string) error {
+	s.logger.Info("Email sent", "template", "welcome", "to", email, "first_name", firstName)
+	return nil
+}
+
+func (s *LogEmailService) SendEmailVerification(ctx context.Context, email, token string) error {
+	s.logger.Info("Email sent", "template", "email_verification", "to", email)
+	return nil
+}
+
+func (s *LogEmailService) SendPasswordResetEmail(ctx context.Context, email, token string) error {
+	s.logger.Info("Email sent", "template", "password_reset", "to", email)
+	return nil
+}
+
+func (s *LogEmailService) SendAccountExistsEmail(ctx context.Context, email string) error {
+	s.logger.Info("Email sent", "template", "account_exists", "to", email)
+	return nil
+}
+
+func (s *LogEmailService) SendNewDeviceAlert(ctx context.Context, email string, alert interfaces.NewDeviceAlert) error {
+	s.logger.Info("Email sent", "template", "new_device_alert", "to", email, "device", alert.Device, "country", alert.Location.Country)
+	return nil
+}
+
+func (s *LogEmailService) SendRelationshipInvite(ctx context.Context, email string, invite interfaces.RelationshipInviteNotice) error {
+	s.logger.Info("Email sent", "template", "relationship_invite", "to", email, "inviter", invite.InviterUsername)
+	return nil
+}
@@ -0,0 +1,357 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	appEvents "github.com/StefanPenchev05/Amora/backend/internal/application/events"
+	"github.com/StefanPenchev05/Amora/backend/internal/application/interfaces"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/user"
+)
+
+var ErrHandlerPanicked = errors.New("event handler panicked")
+
+// DispatchMode decides whether the publisher waits for a handler
+type DispatchMode int
+
+const (
+	// DispatchSync runs the handler before Publish returns, its failure fails the publish
+	DispatchSync DispatchMode = iota
+
+	// DispatchAsync runs the handler on the bus workers next to the other async
+	// handlers of the event. Publish still waits for it, so its failure fails the
+	// publish and the outbox or the broker delivers the event again, to the
+	// handlers that did not handle it yet.
+	DispatchAsync
+)
+
+// SubscribeOptions tunes how one handler is dispatched and retried
+type SubscribeOptions struct {
+	Mode        DispatchMode
+	MaxAttempts int
+	Backoff     time.Duration
+}
+
+// Sync dispatches the handler in the publishing goroutine
+func Sync() SubscribeOptions {
+	return SubscribeOptions{Mode: DispatchSync, MaxAttempts: 3, Backoff: 100 * time.Millisecond}
+}
+
+// Async dispatches the handler on the bus workers
+func Async() SubscribeOptions {
+	return SubscribeOptions{Mode: DispatchAsync, MaxAttempts: 5, Backoff: 500 * time.Millisecond}
+}
+
+// BusOptions tunes the async workers of the bus
+type BusOptions struct {
+	Workers   int
+	QueueSize int
+}
+
+func DefaultBusOptions() BusOptions {
+	return BusOptions{
+		Workers:   4,
+		QueueSize: 1024,
+	}
+}
+
+// HandlerMetrics counts the deliveries of one handler
+type HandlerMetrics struct {
+	Handled  int64
+	Failed   int64
+	Retried  int64
+	Panicked int64
+	Skipped  int64
+}
+
+// Bus dispatches domain events to the handlers subscribed to their type. It is
+// the publisher behind the outbox relay, and can publish events directly when
+// they do not need to survive a crash. Publishing returns once every handler is
+// done, so an event is only marked published after all of them handled it. The
+// delivery log keeps a redelivered event from the handlers that handled it already.
+type Bus struct {
+	registry   *appEvents.Registry
+	deliveries appEvents.DeliveryLog
+	options    BusOptions
+	logger     *slog.Logger
+
+	mu            sync.RWMutex
+	subscriptions map[string][]*subscription
+
+	queue chan job
+	wg    sync.WaitGroup
+}
+
+type subscription struct {
+	name    string
+	handler appEvents.Handler
+	options SubscribeOptions
+
+	handled  atomic.Int64
+	failed   atomic.Int64
+	retried  atomic.Int64
+	panicked atomic.Int64
+	skipped  atomic.Int64
+}
+
+type job struct {
+	ctx          context.Context
+	subscription *subscription
+	event        user.DomainEvent
+	done         chan<- error
+}
+
+func NewBus(registry *appEvents.Registry, deliveries appEvents.DeliveryLog, options BusOptions, logger *slog.Logger) *Bus {
+	return &Bus{
+		registry:      registry,
+		deliveries:    deliveries,
+		options:       options,
+		logger:        logger,
+		subscriptions: make(map[string][]*subscription),
+		queue:         make(chan job, options.QueueSize),
+	}
+}
+
+// Subscribe registers the handler for each of its event types
+func (b *Bus) Subscribe(handler appEvents.Handler, options SubscribeOptions) {
+	if options.MaxAttempts < 1 {
+		options.MaxAttempts = 1
+	}
+
+	s := &subscription{
+		name:    fmt.Sprintf("%T", handler),
+		handler: handler,
+		options: options,
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, eventType := range handler.EventTypes() {
+		b.subscriptions[eventType] = append(b.subscriptions[eventType], s)
+	}
+}
+
+// Run processes async deliveries until the context is cancelled, then waits
+// for the deliveries in progress. Publishers still waiting on queued deliveries
+// left behind fail once their own context is done, so the outbox redelivers them.
+func (b *Bus) Run(ctx context.Context) {
+	for i := 0; i < b.options.Workers; i++ {
+		b.wg.Add(1)
+		go b.work(ctx)
+	}
+	b.wg.Wait()
+}
+
+// PublishEvents dispatches domain events that were not stored in the outbox
+func (b *Bus) PublishEvents(ctx context.Context, domainEvents ...user.DomainEvent) error {
+	var errs []error
+	for _, event := range domainEvents {
+		if err := b.dispatch(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Publish decodes the envelopes and dispatches their events. Handlers see the
+// request that raised the event, with its client, and events they raise name it
+// as their cause.
+func (b *Bus) Publish(ctx context.Context, envelopes ...appEvents.Envelope) error {
+	var errs []error
+	for _, envelope := range envelopes {
+		event, err := b.registry.Decode(envelope)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to decode event %s: %w", envelope.EventID, err))
+			continue
+		}
+
+		handlerCtx := appEvents.WithCause(ctx, envelope)
+		if info := interfaces.RequestInfoFromContext(ctx); info.RequestID == "" {
+			handlerCtx = interfaces.WithRequestInfo(handlerCtx, interfaces.RequestInfo{
+				RequestID: envelope.CorrelationID,
+				IPAddress: envelope.IPAddress,
+				UserAgent: envelope.UserAgent,
+				ActorID:   envelope.ActorID,
+			})
+		}
+
+		if err := b.dispatch(handlerCtx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Metrics returns the delivery counters of every handler by name
+func (b *Bus) Metrics() map[string]HandlerMetrics {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	metrics := make(map[string]HandlerMetrics)
+	for _, subscriptions := range b.subscriptions {
+		for _, s := range subscriptions {
+			metrics[s.name] = HandlerMetrics{
+				Handled:  s.handled.Load(),
+				Failed:   s.failed.Load(),
+				Retried:  s.retried.Load(),
+				Panicked: s.panicked.Load(),
+				Skipped:  s.skipped.Load(),
+			}
+		}
+	}
+	return metrics
+}
+
+// Backlog returns how many async deliveries are queued and how many fit in the queue
+func (b *Bus) Backlog() (queued, capacity int) {
+	return len(b.queue), cap(b.queue)
+}
+
+func (b *Bus) dispatch(ctx context.Context, event user.DomainEvent) error {
+	b.mu.RLock()
+	subscriptions := b.subscriptions[event.GetEventType()]
+	b.mu.RUnlock()
+
+	var errs []error
+	var pending []chan error
+	for _, s := range subscriptions {
+		if s.options.Mode == DispatchAsync {
+			// A delivery the publisher stops waiting for still runs to the end
+			done := make(chan error, 1)
+			queued := job{ctx: context.WithoutCancel(ctx), subscription: s, event: event, done: done}
+			select {
+			case b.queue <- queued:
+				pending = append(pending, done)
+			case <-ctx.Done():
+				errs = append(errs, fmt.Errorf("failed to queue %s for %s: %w", event.GetEventType(), s.name, ctx.Err()))
+			}
+			continue
+		}
+
+		if err := b.deliver(ctx, ctx, s, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	for _, done := range pending {
+		select {
+		case err := <-done:
+			if err != nil {
+				errs = append(errs, err)
+			}
+		case <-ctx.Done():
+			errs = append(errs, fmt.Errorf("stopped waiting for %s handlers: %w", event.GetEventType(), ctx.Err()))
+			return errors.Join(errs...)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (b *Bus) work(ctx context.Context) {
+	defer b.wg.Done()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case queued := <-b.queue:
+			err := b.deliver(ctx, queued.ctx, queued.subscription, queued.event)
+			if err != nil {
+				b.logger.Error("Async event handler gave up",
+					"handler", queued.subscription.name,
+					"event_id", queued.event.GetEventID(),
+					"event_type", queued.event.GetEventType(),
+					"error", err.Error(),
+				)
+			}
+			queued.done <- err
+		}
+	}
+}
+
+// deliver calls the handler until it succeeds or runs out of attempts, unless it
+// handled the event before. Retries stop early once lifetime, the context of the
+// caller or the worker, is done.
+func (b *Bus) deliver(lifetime, ctx context.Context, s *subscription, event user.DomainEvent) error {
+	handled, err := b.deliveries.Handled(ctx, event.GetEventID(), s.name)
+	if err != nil {
+		s.failed.Add(1)
+		return fmt.Errorf("%s could not check %s: %w", s.name, event.GetEventType(), err)
+	}
+	if handled {
+		s.skipped.Add(1)
+		return nil
+	}
+
+	backoff := s.options.Backoff
+	for attempt := 1; attempt <= s.options.MaxAttempts; attempt++ {
+		if err = b.call(ctx, s, event); err == nil {
+			s.handled.Add(1)
+			b.markHandled(ctx, s, event)
+			return nil
+		}
+
+		b.logger.Warn("Event handler failed",
+			"handler", s.name,
+			"event_id", event.GetEventID(),
+			"event_type", event.GetEventType(),
+			"attempt", attempt,
+			"error", err.Error(),
+		)
+		if attempt == s.options.MaxAttempts {
+			break
+		}
+
+		select {
+		case <-lifetime.Done():
+			s.failed.Add(1)
+			return fmt.Errorf("%s stopped retrying %s: %w", s.name, event.GetEventType(), err)
+		case <-time.After(backoff):
+		}
+		s.retried.Add(1)
+		backoff *= 2
+	}
+
+	s.failed.Add(1)
+	return fmt.Errorf("%s failed to handle %s: %w", s.name, event.GetEventType(), err)
+}
+
+// markHandled records the delivery. Failing to record it only means the handler
+// may see the event again, so it is logged and the delivery still succeeds.
+func (b *Bus) markHandled(ctx context.Context, s *subscription, event user.DomainEvent) {
+	if err := b.deliveries.MarkHandled(ctx, event.GetEventID(), s.name); err != nil {
+		b.logger.Error("Failed to record event delivery",
+			"handler", s.name,
+			"event_id", event.GetEventID(),
+			"event_type", event.GetEventType(),
+			"error", err.Error(),
+		)
+	}
+}
+
+// call runs the handler once, turning a panic into an error so one broken
+// handler cannot take down the publisher or the other handlers
+func (b *Bus) call(ctx context.Context, s *subscription, event user.DomainEvent) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			s.panicked.Add(1)
+			b.logger.Error("Event handler panicked",
+				"handler", s.name,
+				"event_id", event.GetEventID(),
+				"event_type", event.GetEventType(),
+				"panic", fmt.Sprint(recovered),
+				"stack", string(debug.Stack()),
+			)
+			err = fmt.Errorf("%w: %v", ErrHandlerPanicked, recovered)
+		}
+	}()
+
+	return s.handler.Handle(ctx, event)
+}
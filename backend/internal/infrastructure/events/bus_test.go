@@ -0,0 +1,87 @@
+package events_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	appEvents "github.com/StefanPenchev05/Amora/backend/internal/application/events"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/user"
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/events"
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/persistence/memory"
+)
+
+var discardLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+// countingHandler counts its calls and fails the first failures of them
+type countingHandler struct {
+	calls    atomic.Int64
+	failures int64
+}
+
+func (h *countingHandler) EventTypes() []string { return []string{"user.created"} }
+
+func (h *countingHandler) Handle(ctx context.Context, event user.DomainEvent) error {
+	if h.calls.Add(1) <= h.failures {
+		return errors.New("handler failed")
+	}
+	return nil
+}
+
+// failingHandler is a second type, the bus tells handlers apart by type
+type failingHandler struct{ countingHandler }
+
+func startBus(t *testing.T) *events.Bus {
+	t.Helper()
+
+	bus := events.NewBus(appEvents.DefaultRegistry(), memory.NewDeliveryLog(), events.DefaultBusOptions(), discardLogger)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go bus.Run(ctx)
+
+	return bus
+}
+
+func TestBusRedeliversOnlyToHandlersThatFailed(t *testing.T) {
+	for name, options := range map[string]events.SubscribeOptions{"sync": events.Sync(), "async": events.Async()} {
+		t.Run(name, func(t *testing.T) {
+			bus := startBus(t)
+			options.MaxAttempts = 1
+			options.Backoff = time.Millisecond
+
+			counting := &countingHandler{}
+			failing := &failingHandler{countingHandler{failures: 1}}
+			bus.Subscribe(counting, options)
+			bus.Subscribe(failing, options)
+
+			envelope, err := appEvents.Encode(context.Background(), user.NewUserCreatedEvent("user-1", "alice@example.com", "alice", "Alice", "Example"))
+			if err != nil {
+				t.Fatalf("encode: %v", err)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := bus.Publish(ctx, envelope); err == nil {
+				t.Fatal("publish with a failing handler succeeded")
+			}
+			// The outbox delivers the whole event again
+			if err := bus.Publish(ctx, envelope); err != nil {
+				t.Fatalf("redelivery: %v", err)
+			}
+
+			if calls := counting.calls.Load(); calls != 1 {
+				t.Errorf("handler that succeeded was called %d times, want once", calls)
+			}
+			if calls := failing.calls.Load(); calls != 2 {
+				t.Errorf("handler that failed was called %d times, want twice", calls)
+			}
+			if skipped := bus.Metrics()["*events_test.countingHandler"].Skipped; skipped != 1 {
+				t.Errorf("skipped deliveries = %d, want 1", skipped)
+			}
+		})
+	}
+}
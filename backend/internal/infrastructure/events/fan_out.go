@@ -0,0 +1,27 @@
+package events
+
+import (
+	"context"
+
+	appEvents "github.com/StefanPenchev05/Amora/backend/internal/application/events"
+)
+
+// FanOutPublisher publishes every envelope to each of its publishers in order and
+// stops at the first failure, so the outbox redelivers to all of them. Put the
+// publishers that deduplicate by event ID first.
+type FanOutPublisher struct {
+	publishers []appEvents.Publisher
+}
+
+func NewFanOutPublisher(publishers ...appEvents.Publisher) appEvents.Publisher {
+	return &FanOutPublisher{publishers: publishers}
+}
+
+func (p *FanOutPublisher) Publish(ctx context.Context, envelopes ...appEvents.Envelope) error {
+	for _, publisher := range p.publishers {
+		if err := publisher.Publish(ctx, envelopes...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
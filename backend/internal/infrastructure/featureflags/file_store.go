@@ -0,0 +1,115 @@
+package featureflags
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/feature"
+)
+
+// DefaultFlags are the features available before any configuration is loaded
+var DefaultFlags = []feature.Flag{
+	{Key: "posts", Description: "Shared wall posts", Enabled: true, RolloutPercentage: 100},
+	{Key: "calendar", Description: "Shared calendar", Enabled: true, RolloutPercentage: 100},
+	{Key: "notes", Description: "Couple notes", Enabled: true, RolloutPercentage: 100},
+	{Key: "invite_codes", Description: "Invite codes for partners without an account", Enabled: true, RolloutPercentage: 100},
+	{
+		Key:                 "advanced_analytics",
+		Description:         "Relationship insights",
+		Enabled:             true,
+		RolloutPercentage:   100,
+		RequiredEntitlement: feature.EntitlementAdvancedAnalytics,
+	},
+	{
+		Key:                 "priority_support",
+		Description:         "Priority support channel",
+		Enabled:             true,
+		RolloutPercentage:   100,
+		RequiredEntitlement: feature.EntitlementPrioritySupport,
+	},
+}
+
+// fileFormat is the JSON layout of FEATURE_FLAGS_FILE
+type fileFormat struct {
+	Flags []feature.Flag `json:"flags"`
+}
+
+// StaticFlagStore serves flags defined in code and an optional JSON file
+type StaticFlagStore struct {
+	flags []feature.Flag
+}
+
+// NewFileFlagStore loads the default flags overridden by the flags in path.
+// An empty path serves the defaults only.
+func NewFileFlagStore(path string) (feature.FlagRepository, error) {
+	flags := append([]feature.Flag(nil), DefaultFlags...)
+	if path == "" {
+		return &StaticFlagStore{flags: flags}, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read feature flags file: %w", err)
+	}
+
+	var file fileFormat
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse feature flags file: %w", err)
+	}
+
+	for _, flag := range file.Flags {
+		if err := flag.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid feature flag %q: %w", flag.Key, err)
+		}
+	}
+
+	return &StaticFlagStore{flags: mergeFlags(flags, file.Flags)}, nil
+}
+
+func (s *StaticFlagStore) ListFlags(ctx context.Context) ([]feature.Flag, error) {
+	return append([]feature.Flag(nil), s.flags...), nil
+}
+
+// CompositeFlagStore merges several flag sources, later sources override earlier ones by key
+type CompositeFlagStore struct {
+	stores []feature.FlagRepository
+}
+
+func NewCompositeFlagStore(stores ...feature.FlagRepository) feature.FlagRepository {
+	return &CompositeFlagStore{stores: stores}
+}
+
+func (s *CompositeFlagStore) ListFlags(ctx context.Context) ([]feature.Flag, error) {
+	var merged []feature.Flag
+	for _, store := range s.stores {
+		flags, err := store.ListFlags(ctx)
+		if err != nil {
+			return nil, err
+		}
+		merged = mergeFlags(merged, flags)
+	}
+
+	return merged, nil
+}
+
+// mergeFlags replaces flags in base with overrides sharing the same key
+func mergeFlags(base, overrides []feature.Flag) []feature.Flag {
+	result := append([]feature.Flag(nil), base...)
+	for _, override := range overrides {
+		replaced := false
+		for i := range result {
+			if result[i].Key == override.Key {
+				result[i] = override
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			result = append(result, override)
+		}
+	}
+
+	return result
+}
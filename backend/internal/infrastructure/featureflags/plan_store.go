@@ -0,0 +1,20 @@
+package featureflags
+
+import (
+	"context"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/feature"
+)
+
+// FixedPlanStore assigns the same plan to every user, used until billing is connected
+type FixedPlanStore struct {
+	plan feature.Plan
+}
+
+func NewFixedPlanStore(plan feature.Plan) feature.PlanRepository {
+	return &FixedPlanStore{plan: plan}
+}
+
+func (s *FixedPlanStore) GetPlanByUserID(ctx context.Context, userID string) (feature.Plan, error) {
+	return s.plan, nil
+}
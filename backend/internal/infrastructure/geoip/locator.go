@@ -0,0 +1,56 @@
+package geoip
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/application/interfaces"
+	"github.com/oschwald/geoip2-golang"
+)
+
+// MaxMindLocator resolves locations from a local GeoLite2/GeoIP2 City database file
+type MaxMindLocator struct {
+	reader *geoip2.Reader
+}
+
+// NewLocator opens the database at path, an empty path disables lookups
+func NewLocator(path string) (interfaces.GeoLocator, error) {
+	if path == "" {
+		return &NoopLocator{}, nil
+	}
+
+	reader, err := geoip2.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open GeoIP database: %w", err)
+	}
+
+	return &MaxMindLocator{reader: reader}, nil
+}
+
+func (l *MaxMindLocator) Locate(ipAddress string) (interfaces.GeoLocation, error) {
+	ip := net.ParseIP(ipAddress)
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() {
+		return interfaces.GeoLocation{}, nil
+	}
+
+	record, err := l.reader.City(ip)
+	if err != nil {
+		return interfaces.GeoLocation{}, fmt.Errorf("failed to look up %s: %w", ipAddress, err)
+	}
+
+	return interfaces.GeoLocation{
+		Country: record.Country.IsoCode,
+		City:    record.City.Names["en"],
+	}, nil
+}
+
+func (l *MaxMindLocator) Close() error {
+	return l.reader.Close()
+}
+
+// NoopLocator never resolves a location
+type NoopLocator struct{}
+
+func (l *NoopLocator) Locate(ipAddress string) (interfaces.GeoLocation, error) {
+	return interfaces.GeoLocation{}, nil
+}
@@ -0,0 +1,167 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultTimeout bounds a check registered without its own timeout
+const DefaultTimeout = 2 * time.Second
+
+// Statuses of a check and of the whole report
+const (
+	StatusOK       = "ok"
+	StatusFailing  = "failing"
+	StatusReady    = "ready"
+	StatusDegraded = "degraded"
+	StatusNotReady = "not_ready"
+)
+
+// Check is one dependency the service needs. A failing critical check makes the
+// service not ready, a failing non-critical one only degrades it.
+type Check struct {
+	Name     string
+	Critical bool
+	Timeout  time.Duration
+	Run      func(ctx context.Context) error
+}
+
+// Result is the outcome of one check
+type Result struct {
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Critical   bool   `json:"critical"`
+	DurationMS int64  `json:"duration_ms"`
+	Error      string `json:"error,omitempty"`
+}
+
+// Report is the outcome of every registered check
+type Report struct {
+	Status string   `json:"status"`
+	Checks []Result `json:"checks"`
+}
+
+// Ready reports whether every critical check passed
+func (r Report) Ready() bool {
+	return r.Status != StatusNotReady
+}
+
+// Registry holds the checks components register while the container is built
+type Registry struct {
+	mu     sync.RWMutex
+	checks []Check
+}
+
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register adds the checks, a later check replaces an earlier one of the same name
+func (r *Registry) Register(checks ...Check) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, check := range checks {
+		if check.Timeout <= 0 {
+			check.Timeout = DefaultTimeout
+		}
+		r.checks = append(removeCheck(r.checks, check.Name), check)
+	}
+}
+
+// Run executes every check concurrently, each bounded by its own timeout
+func (r *Registry) Run(ctx context.Context) Report {
+	r.mu.RLock()
+	checks := append([]Check(nil), r.checks...)
+	r.mu.RUnlock()
+
+	results := make([]Result, len(checks))
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = run(ctx, check)
+		}()
+	}
+	wg.Wait()
+
+	sort.Slice(results, func(i, j int) bool { return results[i].Name < results[j].Name })
+
+	report := Report{Status: StatusReady, Checks: results}
+	for _, result := range results {
+		if result.Status == StatusOK {
+			continue
+		}
+		if result.Critical {
+			report.Status = StatusNotReady
+			break
+		}
+		report.Status = StatusDegraded
+	}
+
+	return report
+}
+
+// Heartbeat checks that a background worker reported progress within maxAge
+func Heartbeat(name string, lastBeat func() time.Time, maxAge time.Duration) Check {
+	return Check{
+		Name: name,
+		Run: func(ctx context.Context) error {
+			last := lastBeat()
+			if last.IsZero() {
+				return fmt.Errorf("not started")
+			}
+			if age := time.Since(last); age > maxAge {
+				return fmt.Errorf("no progress for %s", age.Round(time.Second))
+			}
+			return nil
+		},
+	}
+}
+
+func run(ctx context.Context, check Check) (result Result) {
+	result = Result{Name: check.Name, Status: StatusOK, Critical: check.Critical}
+
+	ctx, cancel := context.WithTimeout(ctx, check.Timeout)
+	defer cancel()
+
+	started := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				done <- fmt.Errorf("check panicked: %v", recovered)
+			}
+		}()
+		done <- check.Run(ctx)
+	}()
+
+	// A check ignoring its context still cannot hold up the report
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = fmt.Errorf("timed out after %s", check.Timeout)
+	}
+
+	result.DurationMS = time.Since(started).Milliseconds()
+	if err != nil {
+		result.Status = StatusFailing
+		result.Error = err.Error()
+	}
+	return result
+}
+
+func removeCheck(checks []Check, name string) []Check {
+	kept := checks[:0]
+	for _, check := range checks {
+		if check.Name != name {
+			kept = append(kept, check)
+		}
+	}
+	return kept
+}
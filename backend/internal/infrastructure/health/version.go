@@ -0,0 +1,41 @@
+package health
+
+import (
+	"runtime/debug"
+	"sync"
+)
+
+// BuildInfo identifies the running binary
+type BuildInfo struct {
+	Version   string `json:"version"`
+	Revision  string `json:"revision,omitempty"`
+	Modified  bool   `json:"modified,omitempty"`
+	GoVersion string `json:"go_version"`
+}
+
+// Build reads the module version and VCS stamp embedded by the Go toolchain.
+// Builds outside a tagged module report "(devel)".
+var Build = sync.OnceValue(func() BuildInfo {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return BuildInfo{Version: "unknown"}
+	}
+
+	build := BuildInfo{
+		Version:   info.Main.Version,
+		GoVersion: info.GoVersion,
+	}
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			build.Revision = setting.Value
+		case "vcs.modified":
+			build.Modified = setting.Value == "true"
+		}
+	}
+	if build.Version == "" {
+		build.Version = "(devel)"
+	}
+
+	return build
+})
@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/application/authz"
+	"github.com/StefanPenchev05/Amora/backend/internal/application/interfaces"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/session"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/user"
+	httpApp "github.com/StefanPenchev05/Amora/backend/internal/infrastructure/http"
+)
+
+type claimsContextKey struct{}
+
+// AuthMiddleware validates the bearer token and stores its claims in the request context.
+// Tokens without a session or belonging to a revoked session are rejected.
+type AuthMiddleware struct {
+	jwtService  interfaces.JWTService
+	sessionRepo session.Repository
+}
+
+func NewAuthMiddleware(jwtService interfaces.JWTService, sessionRepo session.Repository) httpApp.Middleware {
+	return &AuthMiddleware{
+		jwtService:  jwtService,
+		sessionRepo: sessionRepo,
+	}
+}
+
+func (am *AuthMiddleware) Handle(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := r.Header.Get("Authorization")
+		token, found := strings.CutPrefix(header, "Bearer ")
+		if !found || token == "" {
+			writeError(w, http.StatusUnauthorized, authz.ErrUnauthenticated.Error())
+			return
+		}
+
+		claims, err := am.jwtService.ValidateToken(token)
+		if err != nil {
+			writeError(w, http.StatusUnauthorized, "invalid or expired token")
+			return
+		}
+
+		// Every login issues tokens bound to a session, a token without one could not be revoked
+		if claims.SessionID == "" {
+			writeError(w, http.StatusUnauthorized, "invalid or expired token")
+			return
+		}
+
+		// A replica may lag behind a login or a revocation that just happened
+		activeSession, err := am.sessionRepo.GetByID(interfaces.WithPrimaryReads(r.Context()), claims.SessionID)
+		if err != nil || !activeSession.IsActive() {
+			writeError(w, http.StatusUnauthorized, "session has been revoked")
+			return
+		}
+
+		// Attribute whatever the request changes to the authenticated user
+		info := interfaces.RequestInfoFromContext(r.Context())
+		info.ActorID = claims.UserID
+		ctx := interfaces.WithRequestInfo(WithClaims(r.Context(), claims), info)
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// RequirePermission rejects requests whose token does not grant every given permission.
+// It must run after AuthMiddleware.
+func RequirePermission(permissions ...user.Permission) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims, _ := ClaimsFromContext(r.Context())
+			switch err := authz.Require(claims, permissions...); err {
+			case nil:
+				next.ServeHTTP(w, r)
+			case authz.ErrUnauthenticated:
+				writeError(w, http.StatusUnauthorized, err.Error())
+			default:
+				writeError(w, http.StatusForbidden, err.Error())
+			}
+		})
+	}
+}
+
+// WithClaims returns a copy of ctx carrying the token claims
+func WithClaims(ctx context.Context, claims *interfaces.TokenClaims) context.Context {
+	return context.WithValue(ctx, claimsContextKey{}, claims)
+}
+
+// ClaimsFromContext returns the token claims stored by AuthMiddleware
+func ClaimsFromContext(ctx context.Context) (*interfaces.TokenClaims, bool) {
+	claims, ok := ctx.Value(claimsContextKey{}).(*interfaces.TokenClaims)
+	return claims, ok && claims != nil
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
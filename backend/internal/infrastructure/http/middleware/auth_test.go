@@ -0,0 +1,73 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/application/interfaces"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/session"
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/http/middleware"
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/persistence/memory"
+)
+
+// tokenService accepts any token with the claims it holds
+type tokenService struct {
+	interfaces.JWTService
+	claims *interfaces.TokenClaims
+}
+
+func (s tokenService) ValidateToken(token string) (*interfaces.TokenClaims, error) {
+	return s.claims, nil
+}
+
+func TestAuthRejectsTokensWithoutAnActiveSession(t *testing.T) {
+	sessions := memory.NewSessionRepository(memory.NewOutboxStore())
+
+	active, _, err := session.NewSession("user-1", "", "test", "203.0.113.7")
+	if err != nil {
+		t.Fatalf("new session: %v", err)
+	}
+	revoked, _, err := session.NewSession("user-1", "", "test", "203.0.113.7")
+	if err != nil {
+		t.Fatalf("new session: %v", err)
+	}
+	if err := revoked.Revoke("logout"); err != nil {
+		t.Fatalf("revoke session: %v", err)
+	}
+	for _, s := range []*session.Session{active, revoked} {
+		if err := sessions.Create(context.Background(), s); err != nil {
+			t.Fatalf("create session: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		sessionID string
+		want      int
+	}{
+		{name: "active session", sessionID: active.ID, want: http.StatusOK},
+		{name: "revoked session", sessionID: revoked.ID, want: http.StatusUnauthorized},
+		{name: "unknown session", sessionID: "missing", want: http.StatusUnauthorized},
+		{name: "no session", sessionID: "", want: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens := tokenService{claims: &interfaces.TokenClaims{UserID: "user-1", SessionID: tt.sessionID}}
+			handler := middleware.NewAuthMiddleware(tokens, sessions).Handle(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) }),
+			)
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Authorization", "Bearer token")
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			if recorder.Code != tt.want {
+				t.Errorf("status = %d, want %d", recorder.Code, tt.want)
+			}
+		})
+	}
+}
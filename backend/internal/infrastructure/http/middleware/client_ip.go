@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	httpApp "github.com/StefanPenchev05/Amora/backend/internal/infrastructure/http"
+)
+
+// ClientIPMiddleware replaces the remote address with the client named by the
+// forwarding headers, but only for requests coming from a trusted proxy. Anyone
+// else could set X-Forwarded-For to dodge the limits keyed on the address.
+type ClientIPMiddleware struct {
+	trustedProxies []*net.IPNet
+}
+
+func NewClientIPMiddleware(trustedProxies []*net.IPNet) httpApp.Middleware {
+	return &ClientIPMiddleware{trustedProxies: trustedProxies}
+}
+
+func (cm *ClientIPMiddleware) Handle(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			host = r.RemoteAddr
+		}
+
+		if cm.trusted(net.ParseIP(host)) {
+			if client := cm.forwardedClient(r); client != "" {
+				r.RemoteAddr = client
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// forwardedClient walks X-Forwarded-For from the nearest hop and returns the
+// first address not belonging to a trusted proxy, the hops before it are
+// written by the client and cannot be believed
+func (cm *ClientIPMiddleware) forwardedClient(r *http.Request) string {
+	var hops []string
+	if forwarded := r.Header.Values("X-Forwarded-For"); len(forwarded) > 0 {
+		hops = strings.Split(strings.Join(forwarded, ","), ",")
+	}
+	for i := len(hops) - 1; i >= 0; i-- {
+		ip := net.ParseIP(strings.TrimSpace(hops[i]))
+		if ip == nil {
+			return ""
+		}
+		if !cm.trusted(ip) {
+			return ip.String()
+		}
+	}
+
+	if ip := net.ParseIP(strings.TrimSpace(r.Header.Get("X-Real-IP"))); ip != nil {
+		return ip.String()
+	}
+	return ""
+}
+
+func (cm *ClientIPMiddleware) trusted(ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+	for _, network := range cm.trustedProxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+package middleware_test
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/http/middleware"
+)
+
+func TestClientIPTrustsForwardingHeadersOnlyFromProxies(t *testing.T) {
+	_, proxies, _ := net.ParseCIDR("10.0.0.0/8")
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		forwarded  string
+		realIP     string
+		want       string
+	}{
+		{name: "direct client", remoteAddr: "203.0.113.7:5000", forwarded: "198.51.100.1", want: "203.0.113.7:5000"},
+		{name: "direct client with X-Real-IP", remoteAddr: "203.0.113.7:5000", realIP: "198.51.100.1", want: "203.0.113.7:5000"},
+		{name: "through proxy", remoteAddr: "10.0.0.2:5000", forwarded: "203.0.113.7", want: "203.0.113.7"},
+		{name: "spoofed hop before proxy", remoteAddr: "10.0.0.2:5000", forwarded: "198.51.100.1, 203.0.113.7, 10.0.0.3", want: "203.0.113.7"},
+		{name: "proxy with X-Real-IP", remoteAddr: "10.0.0.2:5000", realIP: "203.0.113.7", want: "203.0.113.7"},
+		{name: "proxy without headers", remoteAddr: "10.0.0.2:5000", want: "10.0.0.2:5000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			handler := middleware.NewClientIPMiddleware([]*net.IPNet{proxies}).Handle(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { got = r.RemoteAddr }),
+			)
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = tt.remoteAddr
+			if tt.forwarded != "" {
+				req.Header.Set("X-Forwarded-For", tt.forwarded)
+			}
+			if tt.realIP != "" {
+				req.Header.Set("X-Real-IP", tt.realIP)
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			if got != tt.want {
+				t.Errorf("remote address = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
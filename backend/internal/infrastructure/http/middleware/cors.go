@@ -14,8 +14,9 @@ type CORSMiddleware struct {
 func NewCORSMiddleware(allowedOrigins []string) httpApp.Middleware {
 	corsHandler := cors.Handler(cors.Options{
 		AllowedOrigins:   allowedOrigins,
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type"},
+		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "If-Match"},
+		ExposedHeaders:   []string{"ETag"},
 		AllowCredentials: true,
 		MaxAge:           300,
 	})
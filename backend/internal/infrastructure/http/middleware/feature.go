@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/application/features"
+	"github.com/StefanPenchev05/Amora/backend/internal/application/interfaces"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/user"
+)
+
+// RequireFeature hides routes behind a feature flag, responding 404 while it is off.
+// The subject is built from the stored user like the login bootstrap, so partners
+// see the same features there and on the gated routes. It must run after AuthMiddleware.
+func RequireFeature(featureService interfaces.FeatureService, userRepo user.Repository, key string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims, ok := ClaimsFromContext(r.Context())
+			if !ok {
+				writeError(w, http.StatusNotFound, "not found")
+				return
+			}
+
+			currentUser, err := userRepo.GetByID(r.Context(), claims.UserID)
+			if err != nil {
+				writeError(w, http.StatusNotFound, "not found")
+				return
+			}
+
+			if !featureService.IsEnabled(r.Context(), key, features.SubjectOf(currentUser)) {
+				writeError(w, http.StatusNotFound, "not found")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/application/interfaces"
+	httpApp "github.com/StefanPenchev05/Amora/backend/internal/infrastructure/http"
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+)
+
+// RequestInfoMiddleware exposes the request ID, client IP and user agent to the application layer.
+// It relies on the RequestID middleware applied by the router and on ClientIPMiddleware
+// running before it.
+type RequestInfoMiddleware struct{}
+
+func NewRequestInfoMiddleware() httpApp.Middleware {
+	return &RequestInfoMiddleware{}
+}
+
+func (rm *RequestInfoMiddleware) Handle(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ipAddress, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			ipAddress = r.RemoteAddr
+		}
+
+		ctx := interfaces.WithRequestInfo(r.Context(), interfaces.RequestInfo{
+			RequestID: chiMiddleware.GetReqID(r.Context()),
+			IPAddress: ipAddress,
+			UserAgent: r.UserAgent(),
+		})
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -16,9 +16,9 @@ type ChiRouter struct {
 func NewRouter(middlewares ...Middleware) Router {
 	r := chi.NewRouter()
 
-	// Essential build-in middlewares
+	// Essential build-in middlewares. RealIP is left out, it believes forwarding
+	// headers from any client, see middleware.ClientIPMiddleware
 	r.Use(middleware.RequestID)
-	r.Use(middleware.RealIP)
 	r.Use(middleware.Logger)
 	r.Use(middleware.Recoverer)
 
@@ -0,0 +1,38 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/application/events"
+)
+
+type DeliveryLog struct {
+	mu      sync.RWMutex
+	handled map[deliveryKey]struct{}
+}
+
+type deliveryKey struct {
+	eventID string
+	handler string
+}
+
+func NewDeliveryLog() events.DeliveryLog {
+	return &DeliveryLog{handled: make(map[deliveryKey]struct{})}
+}
+
+func (l *DeliveryLog) Handled(ctx context.Context, eventID, handler string) (bool, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	_, ok := l.handled[deliveryKey{eventID: eventID, handler: handler}]
+	return ok, nil
+}
+
+func (l *DeliveryLog) MarkHandled(ctx context.Context, eventID, handler string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.handled[deliveryKey{eventID: eventID, handler: handler}] = struct{}{}
+	return nil
+}
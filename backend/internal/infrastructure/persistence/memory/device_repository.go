@@ -0,0 +1,116 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/ids"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/session"
+)
+
+// DeviceRepository enforces one device per user and fingerprint like the SQL unique index
+type DeviceRepository struct {
+	mu      sync.RWMutex
+	devices map[string]*session.Device
+}
+
+func NewDeviceRepository() session.DeviceRepository {
+	return &DeviceRepository{
+		devices: make(map[string]*session.Device),
+	}
+}
+
+func (r *DeviceRepository) Create(ctx context.Context, d *session.Device) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if d.ID == "" {
+		d.ID = ids.New()
+	}
+	if _, ok := r.devices[d.ID]; ok {
+		return fmt.Errorf("failed to create device: duplicate id %s", d.ID)
+	}
+	for _, stored := range r.devices {
+		if stored.UserID == d.UserID && stored.Fingerprint == d.Fingerprint {
+			return fmt.Errorf("failed to create device: duplicate fingerprint")
+		}
+	}
+
+	r.devices[d.ID] = cloneDevice(d)
+	return nil
+}
+
+func (r *DeviceRepository) GetByID(ctx context.Context, id string) (*session.Device, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stored, ok := r.devices[id]
+	if !ok {
+		return nil, session.ErrDeviceNotFound
+	}
+
+	return cloneDevice(stored), nil
+}
+
+func (r *DeviceRepository) GetByFingerprint(ctx context.Context, userID, fingerprint string) (*session.Device, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, stored := range r.devices {
+		if stored.UserID == userID && stored.Fingerprint == fingerprint {
+			return cloneDevice(stored), nil
+		}
+	}
+
+	return nil, session.ErrDeviceNotFound
+}
+
+func (r *DeviceRepository) ListByUserID(ctx context.Context, userID string) ([]*session.Device, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	devices := make([]*session.Device, 0)
+	for _, stored := range r.devices {
+		if stored.UserID == userID {
+			devices = append(devices, cloneDevice(stored))
+		}
+	}
+
+	sort.Slice(devices, func(i, j int) bool { return devices[i].LastSeenAt.After(devices[j].LastSeenAt) })
+	return devices, nil
+}
+
+func (r *DeviceRepository) Update(ctx context.Context, d *session.Device) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.devices[d.ID]
+	if !ok {
+		return session.ErrDeviceNotFound
+	}
+
+	stored.LastSeenAt = d.LastSeenAt
+	stored.TrustedUntil = clonePtr(d.TrustedUntil)
+	stored.TrustTokenHash = d.TrustTokenHash
+	return nil
+}
+
+func cloneDevice(d *session.Device) *session.Device {
+	clone := *d
+	clone.TrustedUntil = clonePtr(d.TrustedUntil)
+	return &clone
+}
+
+func (r *DeviceRepository) snapshot() func() {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	saved := copyMap(r.devices, cloneDevice)
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.devices = saved
+	}
+}
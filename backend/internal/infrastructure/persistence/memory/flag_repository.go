@@ -0,0 +1,55 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/feature"
+)
+
+// FlagRepository keeps the flags saved through the admin routes
+type FlagRepository struct {
+	mu    sync.RWMutex
+	flags map[string]feature.Flag
+}
+
+func NewFlagRepository() feature.FlagStore {
+	return &FlagRepository{
+		flags: make(map[string]feature.Flag),
+	}
+}
+
+func (r *FlagRepository) ListFlags(ctx context.Context) ([]feature.Flag, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	flags := make([]feature.Flag, 0, len(r.flags))
+	for _, flag := range r.flags {
+		flags = append(flags, cloneFlag(flag))
+	}
+
+	sort.Slice(flags, func(i, j int) bool { return flags[i].Key < flags[j].Key })
+	return flags, nil
+}
+
+func (r *FlagRepository) SaveFlag(ctx context.Context, flag *feature.Flag, expectedVersion *int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored := r.flags[flag.Key].Version
+	if expectedVersion != nil && *expectedVersion != stored {
+		return feature.ErrConcurrentModification
+	}
+
+	flag.Version = stored + 1
+	r.flags[flag.Key] = cloneFlag(*flag)
+	return nil
+}
+
+func cloneFlag(flag feature.Flag) feature.Flag {
+	flag.Environments = append([]string(nil), flag.Environments...)
+	flag.UserIDs = append([]string(nil), flag.UserIDs...)
+	flag.RelationshipIDs = append([]string(nil), flag.RelationshipIDs...)
+	return flag
+}
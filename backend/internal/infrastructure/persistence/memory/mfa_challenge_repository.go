@@ -0,0 +1,87 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/session"
+)
+
+// MFAChallengeRepository keeps pending MFA steps, it does not take part in
+// units of work since a burnt challenge must stay burnt
+type MFAChallengeRepository struct {
+	mu         sync.Mutex
+	challenges map[string]*session.MFAChallenge
+}
+
+func NewMFAChallengeRepository() session.MFAChallengeRepository {
+	return &MFAChallengeRepository{
+		challenges: make(map[string]*session.MFAChallenge),
+	}
+}
+
+func (r *MFAChallengeRepository) Create(ctx context.Context, challenge *session.MFAChallenge) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.challenges[challenge.ID]; ok {
+		return fmt.Errorf("failed to create MFA challenge: duplicate id %s", challenge.ID)
+	}
+
+	// Expired challenges are only ever looked up to be refused
+	now := time.Now()
+	for id, stored := range r.challenges {
+		if !now.Before(stored.ExpiresAt) {
+			delete(r.challenges, id)
+		}
+	}
+
+	r.challenges[challenge.ID] = cloneChallenge(challenge)
+	return nil
+}
+
+func (r *MFAChallengeRepository) GetByID(ctx context.Context, id string) (*session.MFAChallenge, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.challenges[id]
+	if !ok {
+		return nil, session.ErrChallengeNotFound
+	}
+
+	return cloneChallenge(stored), nil
+}
+
+func (r *MFAChallengeRepository) RecordFailure(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.challenges[id]
+	if !ok {
+		return session.ErrChallengeNotFound
+	}
+
+	stored.FailedAttempts++
+	return nil
+}
+
+func (r *MFAChallengeRepository) Complete(ctx context.Context, id string, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.challenges[id]
+	if !ok || !stored.IsOpen(at) {
+		return session.ErrChallengeClosed
+	}
+
+	stored.CompletedAt = &at
+	return nil
+}
+
+func cloneChallenge(c *session.MFAChallenge) *session.MFAChallenge {
+	clone := *c
+	clone.CompletedAt = clonePtr(c.CompletedAt)
+	return &clone
+}
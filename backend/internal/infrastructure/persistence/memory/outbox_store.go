@@ -0,0 +1,154 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/application/outbox"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/user"
+)
+
+// OutboxStore keeps outbox messages in memory, in append order
+type OutboxStore struct {
+	mu       sync.Mutex
+	messages []*outboxEntry
+	byID     map[string]*outboxEntry
+}
+
+type outboxEntry struct {
+	message     outbox.Message
+	lockedUntil time.Time
+}
+
+func NewOutboxStore() *OutboxStore {
+	return &OutboxStore{
+		byID: make(map[string]*outboxEntry),
+	}
+}
+
+func (s *OutboxStore) Append(ctx context.Context, messages ...outbox.Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.append(messages)
+	return nil
+}
+
+func (s *OutboxStore) Claim(ctx context.Context, limit int, lease time.Duration) ([]outbox.Message, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	claimed := make([]outbox.Message, 0)
+	for _, entry := range s.messages {
+		if len(claimed) >= limit {
+			break
+		}
+		if entry.message.Status != outbox.StatusPending || entry.message.NextAttemptAt.After(now) || entry.lockedUntil.After(now) {
+			continue
+		}
+
+		entry.lockedUntil = now.Add(lease)
+		claimed = append(claimed, entry.message)
+	}
+
+	return claimed, nil
+}
+
+func (s *OutboxStore) MarkPublished(ctx context.Context, eventID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if entry, ok := s.byID[eventID]; ok {
+		entry.message.Status = outbox.StatusPublished
+		entry.lockedUntil = time.Time{}
+	}
+	return nil
+}
+
+func (s *OutboxStore) MarkFailed(ctx context.Context, message outbox.Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if entry, ok := s.byID[message.EventID]; ok {
+		entry.message.Status = message.Status
+		entry.message.Attempts = message.Attempts
+		entry.message.NextAttemptAt = message.NextAttemptAt
+		entry.message.LastError = message.LastError
+		entry.lockedUntil = time.Time{}
+	}
+	return nil
+}
+
+// appendEvents stores the events raised by an aggregate, repositories call it
+// while holding their own lock so the write is atomic with the aggregate
+func (s *OutboxStore) appendEvents(ctx context.Context, events []user.DomainEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	messages, err := outbox.NewMessages(ctx, events...)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.append(messages)
+	return nil
+}
+
+// append must be called with the lock held, known event IDs are ignored
+func (s *OutboxStore) append(messages []outbox.Message) {
+	for _, message := range messages {
+		if _, ok := s.byID[message.EventID]; ok {
+			continue
+		}
+
+		message.Status = outbox.StatusPending
+		entry := &outboxEntry{message: message}
+		s.messages = append(s.messages, entry)
+		s.byID[message.EventID] = entry
+	}
+
+	// Drop published messages once in a while so the dev server does not grow forever
+	if len(s.messages) > 10000 {
+		kept := s.messages[:0]
+		for _, entry := range s.messages {
+			if entry.message.Status == outbox.StatusPublished {
+				delete(s.byID, entry.message.EventID)
+				continue
+			}
+			kept = append(kept, entry)
+		}
+		s.messages = kept
+	}
+}
+
+// snapshot only undoes appends, the relay may keep marking messages meanwhile
+func (s *OutboxStore) snapshot() func() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	known := make(map[string]struct{}, len(s.byID))
+	for eventID := range s.byID {
+		known[eventID] = struct{}{}
+	}
+
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		kept := s.messages[:0]
+		for _, entry := range s.messages {
+			if _, ok := known[entry.message.EventID]; !ok {
+				delete(s.byID, entry.message.EventID)
+				continue
+			}
+			kept = append(kept, entry)
+		}
+		s.messages = kept
+	}
+}
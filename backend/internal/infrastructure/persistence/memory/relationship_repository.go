@@ -0,0 +1,321 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/ids"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/relationship"
+)
+
+type RelationshipRepository struct {
+	mu            sync.RWMutex
+	relationships map[string]*relationship.Relationship
+	outbox        *OutboxStore
+
+	// slugs maps every slug, current or replaced, to the relationship that used it
+	slugs map[string]string
+}
+
+func NewRelationshipRepository(outbox *OutboxStore) relationship.Repository {
+	return &RelationshipRepository{
+		relationships: make(map[string]*relationship.Relationship),
+		outbox:        outbox,
+		slugs:         make(map[string]string),
+	}
+}
+
+func (r *RelationshipRepository) Create(ctx context.Context, rel *relationship.Relationship) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if rel.ID == "" {
+		rel.ID = ids.New()
+	}
+	if _, ok := r.relationships[rel.ID]; ok {
+		return fmt.Errorf("failed to create relationship: duplicate id %s", rel.ID)
+	}
+	if err := r.checkSlug(rel); err != nil {
+		return err
+	}
+
+	if err := r.outbox.appendEvents(ctx, rel.GetEvents()); err != nil {
+		return err
+	}
+
+	r.claimSlug(rel)
+	rel.Version = 1
+	r.relationships[rel.ID] = cloneRelationship(rel)
+	return nil
+}
+
+func (r *RelationshipRepository) GetByID(ctx context.Context, id string) (*relationship.Relationship, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stored, ok := r.relationships[id]
+	if !ok {
+		return nil, relationship.ErrNotFound
+	}
+
+	return cloneRelationship(stored), nil
+}
+
+func (r *RelationshipRepository) Update(ctx context.Context, rel *relationship.Relationship) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.relationships[rel.ID]
+	if !ok {
+		return relationship.ErrNotFound
+	}
+	if stored.Version != rel.Version {
+		return relationship.ErrConcurrentModification
+	}
+	if err := r.checkSlug(rel); err != nil {
+		return err
+	}
+	if err := r.outbox.appendEvents(ctx, rel.GetEvents()); err != nil {
+		return err
+	}
+
+	// The replaced slug stays in the map, so old links still find the relationship
+	r.claimSlug(rel)
+	rel.Version++
+	r.relationships[rel.ID] = cloneRelationship(rel)
+	return nil
+}
+
+func (r *RelationshipRepository) GetBySlug(ctx context.Context, slug string) (*relationship.Relationship, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stored, ok := r.relationships[r.slugs[slug]]
+	if !ok {
+		return nil, relationship.ErrNotFound
+	}
+
+	return cloneRelationship(stored), nil
+}
+
+func (r *RelationshipRepository) TakenSlugs(ctx context.Context, relationshipID string, slugs []string) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	taken := make([]string, 0)
+	for _, slug := range slugs {
+		if owner, ok := r.slugs[slug]; ok && owner != relationshipID {
+			taken = append(taken, slug)
+		}
+	}
+
+	return taken, nil
+}
+
+// checkSlug rejects the slug of the relationship when another one uses or used it
+func (r *RelationshipRepository) checkSlug(rel *relationship.Relationship) error {
+	if owner, ok := r.slugs[rel.Slug]; rel.Slug != "" && ok && owner != rel.ID {
+		return relationship.ErrSlugTaken
+	}
+	return nil
+}
+
+func (r *RelationshipRepository) claimSlug(rel *relationship.Relationship) {
+	if rel.Slug != "" {
+		r.slugs[rel.Slug] = rel.ID
+	}
+}
+
+func (r *RelationshipRepository) ListByPartner(ctx context.Context, userID string) ([]*relationship.Relationship, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	relationships := make([]*relationship.Relationship, 0)
+	for _, stored := range r.relationships {
+		if stored.HasPartner(userID) && stored.DeletedAt == nil {
+			relationships = append(relationships, cloneRelationship(stored))
+		}
+	}
+
+	sort.Slice(relationships, func(i, j int) bool { return relationships[i].CreatedAt.After(relationships[j].CreatedAt) })
+	return relationships, nil
+}
+
+func (r *RelationshipRepository) ListExpired(ctx context.Context, endedBefore time.Time, limit int) ([]*relationship.Relationship, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	relationships := make([]*relationship.Relationship, 0)
+	for _, stored := range r.relationships {
+		if stored.Status == relationship.StatusEnded && stored.DeletedAt == nil && stored.EndedAt.Before(endedBefore) {
+			relationships = append(relationships, cloneRelationship(stored))
+		}
+	}
+
+	sort.Slice(relationships, func(i, j int) bool { return relationships[i].EndedAt.Before(*relationships[j].EndedAt) })
+	if len(relationships) > limit {
+		relationships = relationships[:limit]
+	}
+	return relationships, nil
+}
+
+func cloneRelationship(rel *relationship.Relationship) *relationship.Relationship {
+	clone := *rel
+	clone.EndedAt = clonePtr(rel.EndedAt)
+	clone.DeletedAt = clonePtr(rel.DeletedAt)
+	clone.AnniversaryAt = clonePtr(rel.AnniversaryAt)
+	clone.Pending = clonePtr(rel.Pending)
+	clone.ClearEvents()
+	return &clone
+}
+
+func (r *RelationshipRepository) snapshot() func() {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	saved := copyMap(r.relationships, cloneRelationship)
+	savedSlugs := copyMap(r.slugs, func(owner string) string { return owner })
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.relationships = saved
+		r.slugs = savedSlugs
+	}
+}
+
+type InviteRepository struct {
+	mu      sync.RWMutex
+	invites map[string]*relationship.Invite
+	outbox  *OutboxStore
+}
+
+func NewInviteRepository(outbox *OutboxStore) relationship.InviteRepository {
+	return &InviteRepository{
+		invites: make(map[string]*relationship.Invite),
+		outbox:  outbox,
+	}
+}
+
+func (r *InviteRepository) Create(ctx context.Context, invite *relationship.Invite) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if invite.ID == "" {
+		invite.ID = ids.New()
+	}
+	if _, ok := r.invites[invite.ID]; ok {
+		return fmt.Errorf("failed to create invite: duplicate id %s", invite.ID)
+	}
+	if invite.CodeHash != "" {
+		for _, stored := range r.invites {
+			if stored.CodeHash == invite.CodeHash {
+				return fmt.Errorf("failed to create invite: duplicate code")
+			}
+		}
+	}
+
+	if err := r.outbox.appendEvents(ctx, invite.GetEvents()); err != nil {
+		return err
+	}
+
+	r.invites[invite.ID] = cloneInvite(invite)
+	return nil
+}
+
+func (r *InviteRepository) GetByID(ctx context.Context, id string) (*relationship.Invite, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stored, ok := r.invites[id]
+	if !ok {
+		return nil, relationship.ErrInviteNotFound
+	}
+
+	return cloneInvite(stored), nil
+}
+
+func (r *InviteRepository) GetByCodeHash(ctx context.Context, codeHash string) (*relationship.Invite, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, stored := range r.invites {
+		if codeHash != "" && stored.CodeHash == codeHash {
+			return cloneInvite(stored), nil
+		}
+	}
+
+	return nil, relationship.ErrInviteNotFound
+}
+
+func (r *InviteRepository) Update(ctx context.Context, invite *relationship.Invite) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.invites[invite.ID]
+	if !ok {
+		return relationship.ErrInviteNotFound
+	}
+	if stored.Status != relationship.InviteSent {
+		return relationship.ErrInviteNotPending
+	}
+
+	if err := r.outbox.appendEvents(ctx, invite.GetEvents()); err != nil {
+		return err
+	}
+
+	// Only the answer changes, like the SQL update
+	stored.InviteeID = invite.InviteeID
+	stored.Status = invite.Status
+	stored.RespondedAt = clonePtr(invite.RespondedAt)
+	return nil
+}
+
+func (r *InviteRepository) ListPending(ctx context.Context, userID string, now time.Time) ([]*relationship.Invite, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	invites := make([]*relationship.Invite, 0)
+	for _, stored := range r.invites {
+		if stored.Involves(userID) && stored.IsPending(now) {
+			invites = append(invites, cloneInvite(stored))
+		}
+	}
+
+	sort.Slice(invites, func(i, j int) bool { return invites[i].CreatedAt.After(invites[j].CreatedAt) })
+	return invites, nil
+}
+
+func (r *InviteRepository) HasPendingBetween(ctx context.Context, userID, otherUserID string, now time.Time) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, stored := range r.invites {
+		if stored.Involves(userID) && stored.Involves(otherUserID) && stored.IsPending(now) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func cloneInvite(invite *relationship.Invite) *relationship.Invite {
+	clone := *invite
+	clone.RespondedAt = clonePtr(invite.RespondedAt)
+	clone.ClearEvents()
+	return &clone
+}
+
+func (r *InviteRepository) snapshot() func() {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	saved := copyMap(r.invites, cloneInvite)
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.invites = saved
+	}
+}
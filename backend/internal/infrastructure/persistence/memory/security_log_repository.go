@@ -0,0 +1,93 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/ids"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/securitylog"
+)
+
+// SecurityLogRepository is append-only, entries can never be changed or removed
+type SecurityLogRepository struct {
+	mu      sync.RWMutex
+	entries []securitylog.Entry
+}
+
+func NewSecurityLogRepository() securitylog.Repository {
+	return &SecurityLogRepository{}
+}
+
+func (r *SecurityLogRepository) Append(ctx context.Context, entry *securitylog.Entry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if entry.ID == "" {
+		entry.ID = ids.New()
+	}
+	for i := range r.entries {
+		if r.entries[i].ID == entry.ID {
+			return nil
+		}
+	}
+
+	r.entries = append(r.entries, cloneEntry(entry))
+	return nil
+}
+
+func (r *SecurityLogRepository) ListByUserID(ctx context.Context, userID string, before *securitylog.Cursor, limit int) ([]*securitylog.Entry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entries := make([]*securitylog.Entry, 0)
+	for i := range r.entries {
+		entry := r.entries[i]
+		if entry.UserID != userID {
+			continue
+		}
+		if before != nil && !before.IsAfter(&entry) {
+			continue
+		}
+
+		clone := cloneEntry(&entry)
+		entries = append(entries, &clone)
+	}
+
+	// Newest first like the SQL query, entries may be appended out of order
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].OccurredAt.Equal(entries[j].OccurredAt) {
+			return entries[i].ID > entries[j].ID
+		}
+		return entries[i].OccurredAt.After(entries[j].OccurredAt)
+	})
+	if len(entries) > limit {
+		entries = entries[:limit]
+	}
+
+	return entries, nil
+}
+
+func cloneEntry(entry *securitylog.Entry) securitylog.Entry {
+	clone := *entry
+	clone.Metadata = make(map[string]string, len(entry.Metadata))
+	for key, value := range entry.Metadata {
+		clone.Metadata[key] = value
+	}
+	return clone
+}
+
+// snapshot only has to drop the entries appended since, the log is append only
+func (r *SecurityLogRepository) snapshot() func() {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	length := len(r.entries)
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		if len(r.entries) > length {
+			r.entries = r.entries[:length]
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/ids"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/session"
+)
+
+type SessionRepository struct {
+	mu       sync.RWMutex
+	sessions map[string]*session.Session
+	outbox   *OutboxStore
+}
+
+func NewSessionRepository(outbox *OutboxStore) session.Repository {
+	return &SessionRepository{
+		sessions: make(map[string]*session.Session),
+		outbox:   outbox,
+	}
+}
+
+func (r *SessionRepository) Create(ctx context.Context, s *session.Session) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if s.ID == "" {
+		s.ID = ids.New()
+	}
+	if _, ok := r.sessions[s.ID]; ok {
+		return fmt.Errorf("failed to create session: duplicate id %s", s.ID)
+	}
+	if s.RevokeTokenHash != "" {
+		for _, stored := range r.sessions {
+			if stored.RevokeTokenHash == s.RevokeTokenHash {
+				return fmt.Errorf("failed to create session: duplicate revoke token")
+			}
+		}
+	}
+
+	if err := r.outbox.appendEvents(ctx, s.GetEvents()); err != nil {
+		return err
+	}
+
+	r.sessions[s.ID] = cloneSession(s)
+	return nil
+}
+
+func (r *SessionRepository) GetByID(ctx context.Context, id string) (*session.Session, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stored, ok := r.sessions[id]
+	if !ok {
+		return nil, session.ErrNotFound
+	}
+
+	return cloneSession(stored), nil
+}
+
+func (r *SessionRepository) GetByRevokeTokenHash(ctx context.Context, tokenHash string) (*session.Session, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, stored := range r.sessions {
+		if tokenHash != "" && stored.RevokeTokenHash == tokenHash {
+			return cloneSession(stored), nil
+		}
+	}
+
+	return nil, session.ErrNotFound
+}
+
+func (r *SessionRepository) Update(ctx context.Context, s *session.Session) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.sessions[s.ID]
+	if !ok {
+		return session.ErrNotFound
+	}
+
+	if err := r.outbox.appendEvents(ctx, s.GetEvents()); err != nil {
+		return err
+	}
+
+	// Only the mutable columns change, like the SQL update
+	stored.LastUsedAt = s.LastUsedAt
+	stored.RevokedAt = clonePtr(s.RevokedAt)
+	stored.TokenVersion = s.TokenVersion
+	return nil
+}
+
+func cloneSession(s *session.Session) *session.Session {
+	clone := *s
+	clone.RevokedAt = clonePtr(s.RevokedAt)
+	clone.ClearEvents()
+	return &clone
+}
+
+func (r *SessionRepository) snapshot() func() {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	saved := copyMap(r.sessions, cloneSession)
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.sessions = saved
+	}
+}
@@ -0,0 +1,56 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/settings"
+)
+
+type SettingsRepository struct {
+	mu       sync.RWMutex
+	settings map[string]settings.UserSettings
+}
+
+func NewSettingsRepository() settings.Repository {
+	return &SettingsRepository{
+		settings: make(map[string]settings.UserSettings),
+	}
+}
+
+func (r *SettingsRepository) GetByUserID(ctx context.Context, userID string) (*settings.UserSettings, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stored, ok := r.settings[userID]
+	if !ok {
+		return nil, settings.ErrNotFound
+	}
+
+	return &stored, nil
+}
+
+func (r *SettingsRepository) Save(ctx context.Context, userSettings *settings.UserSettings) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Apply increments the revision once, so the stored revision must be the previous one
+	if stored := r.settings[userSettings.UserID]; stored.Revision != userSettings.Revision-1 {
+		return settings.ErrConcurrentModification
+	}
+
+	r.settings[userSettings.UserID] = *userSettings
+	return nil
+}
+
+func (r *SettingsRepository) snapshot() func() {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	saved := copyMap(r.settings, func(s settings.UserSettings) settings.UserSettings { return s })
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.settings = saved
+	}
+}
@@ -0,0 +1,88 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/application/interfaces"
+)
+
+// snapshotter is implemented by the repositories able to undo a failed unit of work
+type snapshotter interface {
+	// snapshot captures the current state and returns the function restoring it
+	snapshot() func()
+}
+
+type unitKey struct{}
+
+// UnitOfWork runs one unit at a time and restores the repositories when it fails.
+// Writes made outside of a unit while it runs are lost on rollback, which is
+// fine for the dev server.
+type UnitOfWork struct {
+	mu           sync.Mutex
+	participants []snapshotter
+}
+
+// NewUnitOfWork takes the repositories of this package, the ones without state are ignored
+func NewUnitOfWork(repositories ...any) interfaces.UnitOfWork {
+	participants := make([]snapshotter, 0, len(repositories))
+	for _, repository := range repositories {
+		if participant, ok := repository.(snapshotter); ok {
+			participants = append(participants, participant)
+		}
+	}
+
+	return &UnitOfWork{participants: participants}
+}
+
+func (u *UnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	// Nested units join the outer one
+	if ctx.Value(unitKey{}) != nil {
+		return fn(ctx)
+	}
+
+	hooks := &interfaces.UnitHooks{}
+	if err := u.run(interfaces.WithUnitHooks(context.WithValue(ctx, unitKey{}, true), hooks), fn); err != nil {
+		return err
+	}
+
+	hooks.Committed(ctx)
+	return nil
+}
+
+// run calls fn holding the unit lock and restores the repositories when it fails
+func (u *UnitOfWork) run(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	restores := make([]func(), 0, len(u.participants))
+	for _, participant := range u.participants {
+		restores = append(restores, participant.snapshot())
+	}
+
+	rollback := func() {
+		for i := len(restores) - 1; i >= 0; i-- {
+			restores[i]()
+		}
+	}
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			rollback()
+			panic(recovered)
+		}
+	}()
+
+	if err = fn(ctx); err != nil {
+		rollback()
+	}
+	return err
+}
+
+func copyMap[K comparable, V any](source map[K]V, clone func(V) V) map[K]V {
+	copied := make(map[K]V, len(source))
+	for key, value := range source {
+		copied[key] = clone(value)
+	}
+	return copied
+}
@@ -0,0 +1,200 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/ids"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/user"
+)
+
+// UserRepository keeps users in memory and enforces the same unique email and
+// username constraints as the Credentials table
+type UserRepository struct {
+	mu     sync.RWMutex
+	users  map[string]*user.User
+	outbox *OutboxStore
+}
+
+func NewUserRepository(outbox *OutboxStore) user.Repository {
+	return &UserRepository{
+		users:  make(map[string]*user.User),
+		outbox: outbox,
+	}
+}
+
+func (r *UserRepository) Create(ctx context.Context, u *user.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if u.ID == "" {
+		u.ID = ids.New()
+	}
+	if _, ok := r.users[u.ID]; ok {
+		return fmt.Errorf("%w: duplicate id %s", user.ErrConflict, u.ID)
+	}
+	if err := r.checkUnique(u); err != nil {
+		return err
+	}
+
+	if err := r.outbox.appendEvents(ctx, u.GetEvents()); err != nil {
+		return err
+	}
+
+	u.Credentials.UserID = u.ID
+	u.Profile.UserID = u.ID
+	u.Version = 1
+	r.users[u.ID] = cloneUser(u)
+	return nil
+}
+
+func (r *UserRepository) GetByID(ctx context.Context, id string) (*user.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stored, ok := r.users[id]
+	if !ok {
+		return nil, user.ErrNotFound
+	}
+
+	return cloneUser(stored), nil
+}
+
+func (r *UserRepository) GetByEmail(ctx context.Context, email user.Email) (*user.User, error) {
+	return r.find(func(u *user.User) bool { return u.Credentials.Email.Equals(email) })
+}
+
+func (r *UserRepository) GetByUsername(ctx context.Context, username user.Username) (*user.User, error) {
+	return r.find(func(u *user.User) bool { return u.Credentials.Username.Equals(username) })
+}
+
+func (r *UserRepository) Update(ctx context.Context, u *user.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.users[u.ID]
+	if !ok {
+		return user.ErrNotFound
+	}
+	if stored.Version != u.Version {
+		return user.ErrConcurrentModification
+	}
+	if err := r.checkUnique(u); err != nil {
+		return err
+	}
+	if err := r.outbox.appendEvents(ctx, u.GetEvents()); err != nil {
+		return err
+	}
+
+	u.Version++
+	r.users[u.ID] = cloneUser(u)
+	return nil
+}
+
+func (r *UserRepository) DeleteByID(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.users[id]; !ok {
+		return user.ErrNotFound
+	}
+
+	delete(r.users, id)
+	return nil
+}
+
+func (r *UserRepository) Exists(ctx context.Context, email user.Email, username user.Username) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, stored := range r.users {
+		if stored.Credentials.Email.Equals(email) || stored.Credentials.Username.Equals(username) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (r *UserRepository) ExistingUsernames(ctx context.Context, usernames []user.Username) ([]user.Username, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var existing []user.Username
+	for _, username := range usernames {
+		for _, stored := range r.users {
+			if stored.Credentials.Username.Equals(username) {
+				existing = append(existing, username)
+				break
+			}
+		}
+	}
+
+	return existing, nil
+}
+
+func (r *UserRepository) find(match func(u *user.User) bool) (*user.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, stored := range r.users {
+		if match(stored) {
+			return cloneUser(stored), nil
+		}
+	}
+
+	return nil, user.ErrNotFound
+}
+
+// checkUnique must be called with the lock held
+func (r *UserRepository) checkUnique(u *user.User) error {
+	for id, stored := range r.users {
+		if id == u.ID {
+			continue
+		}
+		if stored.Credentials.Email.Equals(u.Credentials.Email) {
+			return fmt.Errorf("%w: duplicate email", user.ErrConflict)
+		}
+		if stored.Credentials.Username.Equals(u.Credentials.Username) {
+			return fmt.Errorf("%w: duplicate username", user.ErrConflict)
+		}
+	}
+
+	return nil
+}
+
+// cloneUser copies the aggregate so callers never share state with the store,
+// events are not persisted just like in the SQL repository
+func cloneUser(u *user.User) *user.User {
+	clone := *u
+	clone.Credentials.MfaSecret = clonePtr(u.Credentials.MfaSecret)
+	clone.Credentials.LastLoginAt = clonePtr(u.Credentials.LastLoginAt)
+	clone.Profile.DateOfBirth = clonePtr(u.Profile.DateOfBirth)
+	clone.Profile.Bio = clonePtr(u.Profile.Bio)
+	clone.Profile.DisplayName = clonePtr(u.Profile.DisplayName)
+	clone.Profile.AvatarPhotoID = clonePtr(u.Profile.AvatarPhotoID)
+	clone.Profile.RelationshipID = clonePtr(u.Profile.RelationshipID)
+	clone.ClearEvents()
+	return &clone
+}
+
+func clonePtr[T any](value *T) *T {
+	if value == nil {
+		return nil
+	}
+	copied := *value
+	return &copied
+}
+
+func (r *UserRepository) snapshot() func() {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	saved := copyMap(r.users, cloneUser)
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.users = saved
+	}
+}
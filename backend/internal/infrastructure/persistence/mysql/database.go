@@ -0,0 +1,151 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/config"
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/persistence/mysql/models"
+	gormMySQL "gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"gorm.io/plugin/dbresolver"
+)
+
+// Connection is the GORM handle shared by every MySQL repository and the pools behind it.
+// Reads go to the replicas when there are any. Writes, reads inside a transaction,
+// locking reads and reads in a context from interfaces.WithPrimaryReads stay on the primary.
+type Connection struct {
+	DB *gorm.DB
+
+	primary  *sql.DB
+	replicas []*sql.DB
+}
+
+// NewConnection opens the primary and replica pools, all of them in UTC
+func NewConnection(cfg config.DBConfig) (*Connection, error) {
+	primary, err := openPool(cfg, cfg.DSN)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := gorm.Open(gormMySQL.New(gormMySQL.Config{Conn: primary}), &gorm.Config{
+		// Surface driver errors as gorm.ErrDuplicatedKey etc.
+		TranslateError: true,
+		Logger:         logger.Default.LogMode(logger.Warn),
+		NowFunc:        func() time.Time { return time.Now().UTC() },
+	})
+	if err != nil {
+		primary.Close()
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	connection := &Connection{DB: db, primary: primary}
+	if len(cfg.ReplicaDSNs) == 0 {
+		return connection, nil
+	}
+
+	replicas := make([]gorm.Dialector, 0, len(cfg.ReplicaDSNs))
+	for _, dsn := range cfg.ReplicaDSNs {
+		replica, err := openPool(cfg, dsn)
+		if err != nil {
+			connection.Close()
+			return nil, err
+		}
+		connection.replicas = append(connection.replicas, replica)
+		replicas = append(replicas, gormMySQL.New(gormMySQL.Config{Conn: replica}))
+	}
+
+	resolver := dbresolver.Register(dbresolver.Config{
+		Replicas: replicas,
+		Policy:   dbresolver.RandomPolicy{},
+	})
+	if err := db.Use(resolver); err != nil {
+		connection.Close()
+		return nil, fmt.Errorf("failed to route reads to replicas: %w", err)
+	}
+
+	return connection, nil
+}
+
+// Ping checks the primary answers on a pooled connection
+func (c *Connection) Ping(ctx context.Context) error {
+	return c.primary.PingContext(ctx)
+}
+
+// PingReplicas checks every replica answers
+func (c *Connection) PingReplicas(ctx context.Context) error {
+	var errs []error
+	for i, replica := range c.replicas {
+		if err := replica.PingContext(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("replica %d: %w", i, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// HasReplicas reports whether reads are routed to replicas
+func (c *Connection) HasReplicas() bool {
+	return len(c.replicas) > 0
+}
+
+// Stats returns the pool statistics of the primary and of each replica, keyed
+// "primary", "replica_0", "replica_1"...
+func (c *Connection) Stats() map[string]sql.DBStats {
+	stats := map[string]sql.DBStats{"primary": c.primary.Stats()}
+	for i, replica := range c.replicas {
+		stats[fmt.Sprintf("replica_%d", i)] = replica.Stats()
+	}
+	return stats
+}
+
+// Close closes every pool
+func (c *Connection) Close() error {
+	errs := []error{c.primary.Close()}
+	for _, replica := range c.replicas {
+		errs = append(errs, replica.Close())
+	}
+	return errors.Join(errs...)
+}
+
+func openPool(cfg config.DBConfig, dsn string) (*sql.DB, error) {
+	pool, err := sql.Open("mysql", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database pool: %w", err)
+	}
+
+	pool.SetMaxOpenConns(cfg.MaxOpenConns)
+	pool.SetMaxIdleConns(cfg.MaxIdleConns)
+	pool.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	pool.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+
+	return pool, nil
+}
+
+// isNotFound reports whether a query matched no rows, a malformed ID cannot match any
+func isNotFound(err error) bool {
+	return errors.Is(err, gorm.ErrRecordNotFound) || errors.Is(err, models.ErrInvalidUUID)
+}
+
+// isDuplicate reports whether a write violated a unique index
+func isDuplicate(err error) bool {
+	return errors.Is(err, gorm.ErrDuplicatedKey)
+}
+
+// nullable stores empty strings as NULL
+func nullable(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+func valueOf(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
@@ -0,0 +1,46 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/application/events"
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/persistence/mysql/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DeliveryLog keeps a row per event and handler that handled it
+type DeliveryLog struct {
+	db *gorm.DB
+}
+
+func NewDeliveryLog(db *gorm.DB) events.DeliveryLog {
+	return &DeliveryLog{db: db}
+}
+
+func (l *DeliveryLog) Handled(ctx context.Context, eventID, handler string) (bool, error) {
+	var count int64
+	err := conn(ctx, l.db).Model(&models.EventDelivery{}).
+		Where("event_id = ? AND handler = ?", models.UUID(eventID), handler).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to check event delivery: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+func (l *DeliveryLog) MarkHandled(ctx context.Context, eventID, handler string) error {
+	record := models.EventDelivery{
+		EventID:   models.UUID(eventID),
+		Handler:   handler,
+		HandledAt: time.Now(),
+	}
+	if err := conn(ctx, l.db).Clauses(clause.OnConflict{DoNothing: true}).Create(&record).Error; err != nil {
+		return fmt.Errorf("failed to record event delivery: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/ids"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/session"
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/persistence/mysql/models"
+	"gorm.io/gorm"
+)
+
+type DeviceRepository struct {
+	db *gorm.DB
+}
+
+func NewDeviceRepository(db *gorm.DB) session.DeviceRepository {
+	return &DeviceRepository{db: db}
+}
+
+func (r *DeviceRepository) Create(ctx context.Context, d *session.Device) error {
+	if d.ID == "" {
+		d.ID = ids.New()
+	}
+
+	record := toDeviceModel(d)
+	if err := conn(ctx, r.db).Create(&record).Error; err != nil {
+		return fmt.Errorf("failed to create device: %w", err)
+	}
+
+	return nil
+}
+
+func (r *DeviceRepository) GetByID(ctx context.Context, id string) (*session.Device, error) {
+	return r.findOne(ctx, "id = ?", models.UUID(id))
+}
+
+func (r *DeviceRepository) GetByFingerprint(ctx context.Context, userID, fingerprint string) (*session.Device, error) {
+	return r.findOne(ctx, "user_id = ? AND fingerprint = ?", models.UUID(userID), fingerprint)
+}
+
+func (r *DeviceRepository) ListByUserID(ctx context.Context, userID string) ([]*session.Device, error) {
+	var records []models.Device
+	err := conn(ctx, r.db).
+		Where("user_id = ?", models.UUID(userID)).
+		Order("last_seen_at DESC").
+		Find(&records).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list devices: %w", err)
+	}
+
+	devices := make([]*session.Device, 0, len(records))
+	for _, record := range records {
+		devices = append(devices, toDeviceDomain(record))
+	}
+
+	return devices, nil
+}
+
+func (r *DeviceRepository) Update(ctx context.Context, d *session.Device) error {
+	result := conn(ctx, r.db).Model(&models.Device{}).Where("id = ?", models.UUID(d.ID)).Updates(map[string]interface{}{
+		"last_seen_at":     d.LastSeenAt,
+		"trusted_until":    d.TrustedUntil,
+		"trust_token_hash": nullable(d.TrustTokenHash),
+	})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update device: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return session.ErrDeviceNotFound
+	}
+
+	return nil
+}
+
+func (r *DeviceRepository) findOne(ctx context.Context, query string, args ...interface{}) (*session.Device, error) {
+	var record models.Device
+	err := conn(ctx, r.db).Where(query, args...).Take(&record).Error
+	if isNotFound(err) {
+		return nil, session.ErrDeviceNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load device: %w", err)
+	}
+
+	return toDeviceDomain(record), nil
+}
+
+func toDeviceModel(d *session.Device) models.Device {
+	return models.Device{
+		ID:              models.UUID(d.ID),
+		UserID:          models.UUID(d.UserID),
+		Fingerprint:     d.Fingerprint,
+		UserAgentFamily: d.UserAgentFamily,
+		IPBlock:         d.IPBlock,
+		FirstSeenAt:     d.FirstSeenAt,
+		LastSeenAt:      d.LastSeenAt,
+		TrustedUntil:    d.TrustedUntil,
+		TrustTokenHash:  nullable(d.TrustTokenHash),
+	}
+}
+
+func toDeviceDomain(record models.Device) *session.Device {
+	return &session.Device{
+		ID:              string(record.ID),
+		UserID:          string(record.UserID),
+		Fingerprint:     record.Fingerprint,
+		UserAgentFamily: record.UserAgentFamily,
+		IPBlock:         record.IPBlock,
+		FirstSeenAt:     record.FirstSeenAt,
+		LastSeenAt:      record.LastSeenAt,
+		TrustedUntil:    record.TrustedUntil,
+		TrustTokenHash:  valueOf(record.TrustTokenHash),
+	}
+}
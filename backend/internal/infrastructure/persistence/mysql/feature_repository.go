@@ -0,0 +1,158 @@
+package mysql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/feature"
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/persistence/mysql/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// FlagRepository loads feature flags managed in the FeatureFlags table
+type FlagRepository struct {
+	db *gorm.DB
+}
+
+func NewFlagRepository(db *gorm.DB) feature.FlagStore {
+	return &FlagRepository{db: db}
+}
+
+func (r *FlagRepository) ListFlags(ctx context.Context) ([]feature.Flag, error) {
+	var records []models.FeatureFlag
+	if err := conn(ctx, r.db).Order("`key`").Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("failed to list feature flags: %w", err)
+	}
+
+	flags := make([]feature.Flag, 0, len(records))
+	for _, record := range records {
+		flag := feature.Flag{
+			Key:                 record.Key,
+			Description:         valueOf(record.Description),
+			Enabled:             record.Enabled,
+			RolloutPercentage:   record.RolloutPercentage,
+			RequiredEntitlement: feature.Entitlement(valueOf(record.RequiredEntitlement)),
+			Version:             record.Version,
+		}
+
+		if err := decodeList(record.Environments, &flag.Environments); err != nil {
+			return nil, fmt.Errorf("failed to decode environments of flag %s: %w", record.Key, err)
+		}
+		if err := decodeList(record.UserIDs, &flag.UserIDs); err != nil {
+			return nil, fmt.Errorf("failed to decode user IDs of flag %s: %w", record.Key, err)
+		}
+		if err := decodeList(record.RelationshipIDs, &flag.RelationshipIDs); err != nil {
+			return nil, fmt.Errorf("failed to decode relationship IDs of flag %s: %w", record.Key, err)
+		}
+
+		if err := flag.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid feature flag %s: %w", record.Key, err)
+		}
+		flags = append(flags, flag)
+	}
+
+	return flags, nil
+}
+
+func (r *FlagRepository) SaveFlag(ctx context.Context, flag *feature.Flag, expectedVersion *int64) error {
+	record := models.FeatureFlag{
+		Key:                 flag.Key,
+		Description:         nullable(flag.Description),
+		Enabled:             flag.Enabled,
+		RolloutPercentage:   flag.RolloutPercentage,
+		RequiredEntitlement: nullable(string(flag.RequiredEntitlement)),
+	}
+
+	var err error
+	if record.Environments, err = encodeList(flag.Environments); err != nil {
+		return fmt.Errorf("failed to encode environments of flag %s: %w", flag.Key, err)
+	}
+	if record.UserIDs, err = encodeList(flag.UserIDs); err != nil {
+		return fmt.Errorf("failed to encode user IDs of flag %s: %w", flag.Key, err)
+	}
+	if record.RelationshipIDs, err = encodeList(flag.RelationshipIDs); err != nil {
+		return fmt.Errorf("failed to encode relationship IDs of flag %s: %w", flag.Key, err)
+	}
+
+	err = conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		// Lock the stored row so concurrent saves of the same key are checked one at a time
+		var stored models.FeatureFlag
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("version").
+			Where("`key` = ?", flag.Key).
+			Take(&stored).Error
+		if err != nil && !isNotFound(err) {
+			return err
+		}
+		if expectedVersion != nil && *expectedVersion != stored.Version {
+			return feature.ErrConcurrentModification
+		}
+
+		record.Version = stored.Version + 1
+		return tx.Clauses(clause.OnConflict{
+			DoUpdates: clause.AssignmentColumns([]string{
+				"description", "enabled", "environments", "user_ids", "relationship_ids",
+				"rollout_percentage", "required_entitlement", "version", "updated_at",
+			}),
+		}).Create(&record).Error
+	})
+	// A flag created concurrently under the same key
+	if isDuplicate(err) {
+		return feature.ErrConcurrentModification
+	}
+	if errors.Is(err, feature.ErrConcurrentModification) {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save feature flag: %w", err)
+	}
+
+	flag.Version = record.Version
+	return nil
+}
+
+// PlanRepository resolves plans from the UserPlans table, users without an active plan are on free
+type PlanRepository struct {
+	db *gorm.DB
+}
+
+func NewPlanRepository(db *gorm.DB) feature.PlanRepository {
+	return &PlanRepository{db: db}
+}
+
+func (r *PlanRepository) GetPlanByUserID(ctx context.Context, userID string) (feature.Plan, error) {
+	var record models.UserPlan
+	err := conn(ctx, r.db).Where("user_id = ?", models.UUID(userID)).Take(&record).Error
+	if isNotFound(err) {
+		return feature.PlanFree, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to load plan: %w", err)
+	}
+
+	if record.ExpiresAt != nil && !record.ExpiresAt.After(time.Now()) {
+		return feature.PlanFree, nil
+	}
+
+	return feature.NewPlan(record.Plan)
+}
+
+// encodeList writes a nullable JSON array column, empty lists are stored as NULL
+func encodeList(values []string) (json.RawMessage, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(values)
+}
+
+// decodeList reads a nullable JSON array column
+func decodeList(data json.RawMessage, target *[]string) error {
+	if len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, target)
+}
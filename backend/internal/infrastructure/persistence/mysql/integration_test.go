@@ -0,0 +1,365 @@
+//go:build integration
+
+package mysql_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/config"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/ids"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/relationship"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/securitylog"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/session"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/settings"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/user"
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/persistence/mysql"
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/persistence/mysql/mysqltest"
+	"gorm.io/gorm"
+)
+
+// db is shared by every test, each one works on users of its own
+var db *gorm.DB
+
+func TestMain(m *testing.M) {
+	ctx := context.Background()
+
+	srv, err := mysqltest.Start(ctx)
+	if errors.Is(err, mysqltest.ErrUnavailable) {
+		fmt.Println("skipping MySQL integration tests:", err)
+		os.Exit(0)
+	}
+	if err != nil {
+		fmt.Println("failed to start MySQL:", err)
+		os.Exit(1)
+	}
+
+	connection, err := mysql.NewConnection(config.DBConfig{DSN: srv.DSN, MaxOpenConns: 10, MaxIdleConns: 5})
+	if err != nil {
+		srv.Shutdown()
+		fmt.Println("failed to connect to MySQL:", err)
+		os.Exit(1)
+	}
+	db = connection.DB
+
+	code := m.Run()
+
+	connection.Close()
+	srv.Shutdown()
+	os.Exit(code)
+}
+
+// createUser saves a new user with a unique email and username
+func createUser(t *testing.T) *user.User {
+	t.Helper()
+
+	name := "it_" + strings.ReplaceAll(ids.New(), "-", "")[20:]
+	u, err := user.NewUser(name+"@example.com", name, "Integration", "Test", "Sup3rSecret!")
+	if err != nil {
+		t.Fatalf("NewUser: %v", err)
+	}
+	if err := mysql.NewUserRepository(db).Create(context.Background(), u); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	u.ClearEvents()
+
+	return u
+}
+
+func TestUserCreateStoresEventInOutbox(t *testing.T) {
+	ctx := context.Background()
+	u := createUser(t)
+
+	stored, err := mysql.NewUserRepository(db).GetByEmail(ctx, u.Credentials.Email)
+	if err != nil {
+		t.Fatalf("GetByEmail: %v", err)
+	}
+	if stored.ID != u.ID || stored.Version != u.Version {
+		t.Fatalf("loaded user %s version %d, want %s version %d", stored.ID, stored.Version, u.ID, u.Version)
+	}
+
+	messages, err := mysql.NewOutboxStore(db).Claim(ctx, 1000, time.Minute)
+	if err != nil {
+		t.Fatalf("Claim: %v", err)
+	}
+	for _, message := range messages {
+		if message.AggregateID == u.ID && message.EventType == "user.created" {
+			return
+		}
+	}
+	t.Fatalf("no user.created message for user %s among %d claimed", u.ID, len(messages))
+}
+
+func TestUserUpdateDetectsConcurrentModification(t *testing.T) {
+	ctx := context.Background()
+	repo := mysql.NewUserRepository(db)
+	u := createUser(t)
+
+	first, err := repo.GetByID(ctx, u.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	second, err := repo.GetByID(ctx, u.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+
+	if err := first.UpdateProfile("First", "Writer", "", user.GenderPreferNotToSay); err != nil {
+		t.Fatalf("UpdateProfile: %v", err)
+	}
+	if err := repo.Update(ctx, first); err != nil {
+		t.Fatalf("first Update: %v", err)
+	}
+
+	if err := second.UpdateProfile("Second", "Writer", "", user.GenderPreferNotToSay); err != nil {
+		t.Fatalf("UpdateProfile: %v", err)
+	}
+	if err := repo.Update(ctx, second); !errors.Is(err, user.ErrConcurrentModification) {
+		t.Fatalf("stale Update returned %v, want ErrConcurrentModification", err)
+	}
+
+	stored, err := repo.GetByID(ctx, u.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if stored.Profile.FirstName != "First" || stored.Version != first.Version {
+		t.Fatalf("stored %q version %d, want %q version %d", stored.Profile.FirstName, stored.Version, "First", first.Version)
+	}
+}
+
+func TestSettingsSaveChecksRevision(t *testing.T) {
+	ctx := context.Background()
+	repo := mysql.NewSettingsRepository(db)
+	u := createUser(t)
+
+	if _, err := repo.GetByUserID(ctx, u.ID); !errors.Is(err, settings.ErrNotFound) {
+		t.Fatalf("GetByUserID before saving returned %v, want ErrNotFound", err)
+	}
+
+	dark := string(settings.ThemeDark)
+	created := settings.Default(u.ID)
+	if err := created.Apply(settings.Patch{Theme: &dark}); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if err := repo.Save(ctx, created); err != nil {
+		t.Fatalf("first Save: %v", err)
+	}
+
+	stale, err := repo.GetByUserID(ctx, u.ID)
+	if err != nil {
+		t.Fatalf("GetByUserID: %v", err)
+	}
+	if stale.Theme != settings.ThemeDark {
+		t.Fatalf("stored theme %q, want %q", stale.Theme, settings.ThemeDark)
+	}
+	fresh := *stale
+
+	light := string(settings.ThemeLight)
+	if err := fresh.Apply(settings.Patch{Theme: &light}); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if err := repo.Save(ctx, &fresh); err != nil {
+		t.Fatalf("second Save: %v", err)
+	}
+
+	if err := stale.Apply(settings.Patch{Theme: &dark}); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if err := repo.Save(ctx, stale); !errors.Is(err, settings.ErrConcurrentModification) {
+		t.Fatalf("stale Save returned %v, want ErrConcurrentModification", err)
+	}
+}
+
+func TestSessionRevokeAndLookupByToken(t *testing.T) {
+	ctx := context.Background()
+	repo := mysql.NewSessionRepository(db)
+	u := createUser(t)
+
+	created, revokeToken, err := session.NewSession(u.ID, "", "integration-test", "203.0.113.7")
+	if err != nil {
+		t.Fatalf("NewSession: %v", err)
+	}
+	if err := repo.Create(ctx, created); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	found, err := repo.GetByRevokeTokenHash(ctx, session.HashToken(revokeToken))
+	if err != nil {
+		t.Fatalf("GetByRevokeTokenHash: %v", err)
+	}
+	if found.ID != created.ID || !found.IsActive() {
+		t.Fatalf("found session %s active %v, want active %s", found.ID, found.IsActive(), created.ID)
+	}
+
+	if err := found.Revoke("integration test"); err != nil {
+		t.Fatalf("Revoke: %v", err)
+	}
+	if err := repo.Update(ctx, found); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	revoked, err := repo.GetByID(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if revoked.IsActive() || revoked.TokenVersion != found.TokenVersion {
+		t.Fatalf("session active %v token version %d, want revoked with version %d", revoked.IsActive(), revoked.TokenVersion, found.TokenVersion)
+	}
+
+	if _, err := repo.GetByID(ctx, ids.New()); !errors.Is(err, session.ErrNotFound) {
+		t.Fatalf("GetByID of a missing session returned %v, want ErrNotFound", err)
+	}
+}
+
+func TestSecurityLogAppendsOnceAndPagesThroughTies(t *testing.T) {
+	ctx := context.Background()
+	repo := mysql.NewSecurityLogRepository(db)
+	u := createUser(t)
+
+	// Three entries share a timestamp, so paging by time alone would skip some
+	tied := time.Now().UTC().Truncate(time.Microsecond)
+	times := []time.Time{tied.Add(time.Second), tied, tied, tied, tied.Add(-time.Second)}
+	for _, occurredAt := range times {
+		entry, err := securitylog.NewEntry(u.ID, securitylog.EventLoginSucceeded, occurredAt)
+		if err != nil {
+			t.Fatalf("NewEntry: %v", err)
+		}
+		if err := repo.Append(ctx, entry); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+
+		// A redelivered event appends the same entry again
+		if err := repo.Append(ctx, entry); err != nil {
+			t.Fatalf("repeated Append: %v", err)
+		}
+	}
+
+	seen := make(map[string]bool)
+	var before *securitylog.Cursor
+	for page := 0; page < len(times); page++ {
+		entries, err := repo.ListByUserID(ctx, u.ID, before, 2)
+		if err != nil {
+			t.Fatalf("ListByUserID: %v", err)
+		}
+		for _, entry := range entries {
+			if seen[entry.ID] {
+				t.Fatalf("entry %s listed twice", entry.ID)
+			}
+			seen[entry.ID] = true
+		}
+		if len(entries) < 2 {
+			break
+		}
+		cursor := securitylog.CursorOf(entries[len(entries)-1])
+		before = &cursor
+	}
+
+	if len(seen) != len(times) {
+		t.Fatalf("listed %d entries, want %d", len(seen), len(times))
+	}
+}
+
+func TestRelationshipExpireSoftDeletesAfterRetention(t *testing.T) {
+	ctx := context.Background()
+	repo := mysql.NewRelationshipRepository(db)
+	partner, other := createUser(t), createUser(t)
+
+	rel, err := relationship.NewRelationship(partner.ID, other.ID, partner.ID)
+	if err != nil {
+		t.Fatalf("NewRelationship: %v", err)
+	}
+	endedAt := time.Now().Add(-relationship.RetentionPeriod - time.Hour).UTC().Truncate(time.Second)
+	rel.Status = relationship.StatusEnded
+	rel.StartedAt = endedAt.Add(-time.Hour)
+	rel.EndedAt = &endedAt
+	if err := repo.Create(ctx, rel); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	now := time.Now()
+	due, err := repo.ListExpired(ctx, now.Add(-relationship.RetentionPeriod), 1000)
+	if err != nil {
+		t.Fatalf("ListExpired: %v", err)
+	}
+	var expired *relationship.Relationship
+	for _, candidate := range due {
+		if candidate.ID == rel.ID {
+			expired = candidate
+		}
+	}
+	if expired == nil {
+		t.Fatalf("relationship %s ended past its retention period is not listed", rel.ID)
+	}
+
+	if err := expired.Expire(now); err != nil {
+		t.Fatalf("Expire: %v", err)
+	}
+	if err := repo.Update(ctx, expired); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	stored, err := repo.GetByID(ctx, rel.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if stored.DeletedAt == nil {
+		t.Fatal("expired relationship has no deleted_at")
+	}
+	listed, err := repo.ListByPartner(ctx, partner.ID)
+	if err != nil {
+		t.Fatalf("ListByPartner: %v", err)
+	}
+	if len(listed) != 0 {
+		t.Fatalf("ListByPartner returned %d relationships, want the deleted one left out", len(listed))
+	}
+}
+
+func TestInviteUpdateRefusesAnAlreadyAnsweredInvite(t *testing.T) {
+	ctx := context.Background()
+	repo := mysql.NewInviteRepository(db)
+	inviter, invitee := createUser(t), createUser(t)
+
+	invite, err := relationship.NewInvite(inviter.ID, invitee.ID, "")
+	if err != nil {
+		t.Fatalf("NewInvite: %v", err)
+	}
+	if err := repo.Create(ctx, invite); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	declined, err := repo.GetByID(ctx, invite.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	cancelled, err := repo.GetByID(ctx, invite.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+
+	if err := declined.Decline(invitee.ID); err != nil {
+		t.Fatalf("Decline: %v", err)
+	}
+	if err := repo.Update(ctx, declined); err != nil {
+		t.Fatalf("first Update: %v", err)
+	}
+
+	if err := cancelled.Cancel(inviter.ID); err != nil {
+		t.Fatalf("Cancel: %v", err)
+	}
+	if err := repo.Update(ctx, cancelled); !errors.Is(err, relationship.ErrInviteNotPending) {
+		t.Fatalf("racing Update returned %v, want ErrInviteNotPending", err)
+	}
+
+	stored, err := repo.GetByID(ctx, invite.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if stored.Status != relationship.InviteDeclined {
+		t.Fatalf("stored status %s, want %s", stored.Status, relationship.InviteDeclined)
+	}
+}
@@ -0,0 +1,93 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/session"
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/persistence/mysql/models"
+	"gorm.io/gorm"
+)
+
+type MFAChallengeRepository struct {
+	db *gorm.DB
+}
+
+func NewMFAChallengeRepository(db *gorm.DB) session.MFAChallengeRepository {
+	return &MFAChallengeRepository{db: db}
+}
+
+func (r *MFAChallengeRepository) Create(ctx context.Context, challenge *session.MFAChallenge) error {
+	// Expired challenges are only ever looked up to be refused, the user's own are dropped on the way
+	err := conn(ctx, r.db).
+		Where("user_id = ? AND expires_at < ?", models.UUID(challenge.UserID), time.Now()).
+		Delete(&models.MFAChallenge{}).Error
+	if err != nil {
+		return fmt.Errorf("failed to drop expired MFA challenges: %w", err)
+	}
+
+	record := models.MFAChallenge{
+		ID:             models.UUID(challenge.ID),
+		UserID:         models.UUID(challenge.UserID),
+		Fingerprint:    challenge.Fingerprint,
+		FailedAttempts: challenge.FailedAttempts,
+		ExpiresAt:      challenge.ExpiresAt,
+		CompletedAt:    challenge.CompletedAt,
+	}
+	if err := conn(ctx, r.db).Create(&record).Error; err != nil {
+		return fmt.Errorf("failed to create MFA challenge: %w", err)
+	}
+
+	return nil
+}
+
+func (r *MFAChallengeRepository) GetByID(ctx context.Context, id string) (*session.MFAChallenge, error) {
+	var record models.MFAChallenge
+	err := conn(ctx, r.db).Where("id = ?", models.UUID(id)).Take(&record).Error
+	if isNotFound(err) {
+		return nil, session.ErrChallengeNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load MFA challenge: %w", err)
+	}
+
+	return &session.MFAChallenge{
+		ID:             string(record.ID),
+		UserID:         string(record.UserID),
+		Fingerprint:    record.Fingerprint,
+		FailedAttempts: record.FailedAttempts,
+		ExpiresAt:      record.ExpiresAt,
+		CompletedAt:    record.CompletedAt,
+	}, nil
+}
+
+func (r *MFAChallengeRepository) RecordFailure(ctx context.Context, id string) error {
+	result := conn(ctx, r.db).Model(&models.MFAChallenge{}).
+		Where("id = ?", models.UUID(id)).
+		Update("failed_attempts", gorm.Expr("failed_attempts + 1"))
+	if result.Error != nil {
+		return fmt.Errorf("failed to record MFA failure: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return session.ErrChallengeNotFound
+	}
+
+	return nil
+}
+
+func (r *MFAChallengeRepository) Complete(ctx context.Context, id string, at time.Time) error {
+	// The conditions repeat MFAChallenge.IsOpen, checked and set in one statement
+	result := conn(ctx, r.db).Model(&models.MFAChallenge{}).
+		Where("id = ? AND completed_at IS NULL AND failed_attempts < ? AND expires_at > ?",
+			models.UUID(id), session.MaxMFAAttempts, at).
+		Update("completed_at", at)
+	if result.Error != nil {
+		return fmt.Errorf("failed to complete MFA challenge: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return session.ErrChallengeClosed
+	}
+
+	return nil
+}
@@ -0,0 +1,324 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"time"
+
+	mysqlDriver "github.com/go-sql-driver/mysql"
+)
+
+const (
+	// lockName serializes migrations between replicas applying them on boot
+	lockName        = "amora_schema_migrations"
+	lockTimeoutSecs = 60
+)
+
+var (
+	ErrDirty            = errors.New("database is dirty, fix the failed migration and run force")
+	ErrChecksumMismatch = errors.New("applied migration was modified")
+	ErrUnknownVersion   = errors.New("unknown migration version")
+)
+
+// Status describes a migration and whether it is applied
+type Status struct {
+	Version   int
+	Name      string
+	Applied   bool
+	AppliedAt *time.Time
+	Dirty     bool
+
+	// Modified is set when the applied script no longer matches the embedded one
+	Modified bool
+}
+
+// applied is a row of the schema_migrations table
+type applied struct {
+	version   int
+	checksum  string
+	dirty     bool
+	appliedAt time.Time
+}
+
+// Migrator applies and reverts embedded migrations, tracking them in schema_migrations
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+	logger     *slog.Logger
+}
+
+func NewMigrator(db *sql.DB, fsys fs.FS, logger *slog.Logger) (*Migrator, error) {
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{
+		db:         db,
+		migrations: migrations,
+		logger:     logger,
+	}, nil
+}
+
+// Open connects with multi statement support, which migration scripts need
+func Open(dsn string) (*sql.DB, error) {
+	cfg, err := mysqlDriver.ParseDSN(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("invalid database DSN: %w", err)
+	}
+	cfg.MultiStatements = true
+	cfg.ParseTime = true
+
+	db, err := sql.Open("mysql", cfg.FormatDSN())
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	return db, nil
+}
+
+// Up applies up to steps pending migrations, all of them when steps <= 0
+func (m *Migrator) Up(ctx context.Context, steps int) (int, error) {
+	count := 0
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		state, err := m.load(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if err := m.verify(state); err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := state[migration.Version]; ok {
+				continue
+			}
+			if steps > 0 && count >= steps {
+				break
+			}
+
+			m.logger.Info("Applying migration", "version", migration.Version, "name", migration.Name)
+			if err := m.apply(ctx, conn, migration); err != nil {
+				return err
+			}
+			count++
+		}
+
+		return nil
+	})
+
+	return count, err
+}
+
+// Down reverts the last steps applied migrations
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	count := 0
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		state, err := m.load(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if err := m.verify(state); err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && count < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := state[migration.Version]; !ok {
+				continue
+			}
+
+			m.logger.Info("Reverting migration", "version", migration.Version, "name", migration.Name)
+			if err := m.revert(ctx, conn, migration); err != nil {
+				return err
+			}
+			count++
+		}
+
+		return nil
+	})
+
+	return count, err
+}
+
+// Status lists every known migration with its applied state
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	var statuses []Status
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		state, err := m.load(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		statuses = make([]Status, 0, len(m.migrations))
+		for _, migration := range m.migrations {
+			status := Status{Version: migration.Version, Name: migration.Name}
+			if row, ok := state[migration.Version]; ok {
+				appliedAt := row.appliedAt
+				status.Applied = true
+				status.AppliedAt = &appliedAt
+				status.Dirty = row.dirty
+				status.Modified = row.checksum != migration.Checksum
+			}
+			statuses = append(statuses, status)
+		}
+
+		return nil
+	})
+
+	return statuses, err
+}
+
+// Force records the schema as being exactly at version without running any script.
+// Use it after manually repairing a failed migration, 0 marks nothing as applied.
+func (m *Migrator) Force(ctx context.Context, version int) error {
+	if version != 0 && m.find(version) == nil {
+		return fmt.Errorf("%w: %d", ErrUnknownVersion, version)
+	}
+
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		if _, err := conn.ExecContext(ctx, "DELETE FROM `schema_migrations` WHERE `version` > ?", version); err != nil {
+			return fmt.Errorf("failed to force version: %w", err)
+		}
+
+		for _, migration := range m.migrations {
+			if migration.Version > version {
+				break
+			}
+
+			_, err := conn.ExecContext(ctx,
+				"INSERT INTO `schema_migrations` (`version`, `name`, `checksum`, `dirty`, `applied_at`) VALUES (?, ?, ?, false, ?) "+
+					"ON DUPLICATE KEY UPDATE `name` = VALUES(`name`), `checksum` = VALUES(`checksum`), `dirty` = false",
+				migration.Version, migration.Name, migration.Checksum, time.Now().UTC(),
+			)
+			if err != nil {
+				return fmt.Errorf("failed to force version: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// apply marks the version dirty before running the script, MySQL cannot roll back DDL
+// so a failure leaves it dirty until fixed and forced
+func (m *Migrator) apply(ctx context.Context, conn *sql.Conn, migration Migration) error {
+	_, err := conn.ExecContext(ctx,
+		"INSERT INTO `schema_migrations` (`version`, `name`, `checksum`, `dirty`, `applied_at`) VALUES (?, ?, ?, true, ?)",
+		migration.Version, migration.Name, migration.Checksum, time.Now().UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
+	}
+
+	if hasStatements(migration.Up) {
+		if _, err := conn.ExecContext(ctx, migration.Up); err != nil {
+			return fmt.Errorf("migration %d_%s failed: %w", migration.Version, migration.Name, err)
+		}
+	}
+
+	if _, err := conn.ExecContext(ctx, "UPDATE `schema_migrations` SET `dirty` = false WHERE `version` = ?", migration.Version); err != nil {
+		return fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
+	}
+
+	return nil
+}
+
+func (m *Migrator) revert(ctx context.Context, conn *sql.Conn, migration Migration) error {
+	if _, err := conn.ExecContext(ctx, "UPDATE `schema_migrations` SET `dirty` = true WHERE `version` = ?", migration.Version); err != nil {
+		return fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
+	}
+
+	if hasStatements(migration.Down) {
+		if _, err := conn.ExecContext(ctx, migration.Down); err != nil {
+			return fmt.Errorf("reverting migration %d_%s failed: %w", migration.Version, migration.Name, err)
+		}
+	}
+
+	if _, err := conn.ExecContext(ctx, "DELETE FROM `schema_migrations` WHERE `version` = ?", migration.Version); err != nil {
+		return fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
+	}
+
+	return nil
+}
+
+// verify refuses to continue from a dirty or modified state
+func (m *Migrator) verify(state map[int]applied) error {
+	for version, row := range state {
+		if row.dirty {
+			return fmt.Errorf("%w: version %d", ErrDirty, version)
+		}
+
+		migration := m.find(version)
+		if migration == nil {
+			return fmt.Errorf("%w: version %d is applied but not embedded", ErrUnknownVersion, version)
+		}
+		if migration.Checksum != row.checksum {
+			return fmt.Errorf("%w: %d_%s", ErrChecksumMismatch, migration.Version, migration.Name)
+		}
+	}
+
+	return nil
+}
+
+func (m *Migrator) load(ctx context.Context, conn *sql.Conn) (map[int]applied, error) {
+	rows, err := conn.QueryContext(ctx, "SELECT `version`, `checksum`, `dirty`, `applied_at` FROM `schema_migrations`")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	state := make(map[int]applied)
+	for rows.Next() {
+		var row applied
+		if err := rows.Scan(&row.version, &row.checksum, &row.dirty, &row.appliedAt); err != nil {
+			return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+		}
+		state[row.version] = row
+	}
+
+	return state, rows.Err()
+}
+
+func (m *Migrator) find(version int) *Migration {
+	for i := range m.migrations {
+		if m.migrations[i].Version == version {
+			return &m.migrations[i]
+		}
+	}
+	return nil
+}
+
+// withLock runs fn on a single connection holding the migration lock
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer conn.Close()
+
+	var locked sql.NullInt64
+	if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", lockName, lockTimeoutSecs).Scan(&locked); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	if locked.Int64 != 1 {
+		return errors.New("timed out waiting for the migration lock")
+	}
+	defer conn.ExecContext(context.WithoutCancel(ctx), "SELECT RELEASE_LOCK(?)", lockName)
+
+	_, err = conn.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS `schema_migrations` ("+
+		"`version` int PRIMARY KEY NOT NULL, "+
+		"`name` varchar(255) NOT NULL, "+
+		"`checksum` char(64) NOT NULL COMMENT 'SHA-256 of the up script', "+
+		"`dirty` boolean NOT NULL DEFAULT false, "+
+		"`applied_at` timestamp(6) NOT NULL)")
+	if err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+
+	return fn(conn)
+}
@@ -0,0 +1,103 @@
+package migrate
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var fileRegex = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Migration is a single schema version with its apply and revert scripts
+type Migration struct {
+	Version  int
+	Name     string
+	Up       string
+	Down     string
+	Checksum string
+}
+
+// Load reads the migrations in fsys ordered by version
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		match := fileRegex.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+
+		version, _ := strconv.Atoi(match[1])
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", entry.Name(), err)
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has conflicting names %s and %s", version, migration.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up script", migration.Version, migration.Name)
+		}
+		if migration.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s has no down script", migration.Version, migration.Name)
+		}
+
+		sum := sha256.Sum256([]byte(migration.Up))
+		migration.Checksum = hex.EncodeToString(sum[:])
+		migrations = append(migrations, *migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// hasStatements reports whether a script contains anything besides comments,
+// MySQL rejects empty queries
+func hasStatements(script string) bool {
+	for script != "" {
+		script = strings.TrimSpace(script)
+		switch {
+		case strings.HasPrefix(script, "--"):
+			end := strings.Index(script, "\n")
+			if end < 0 {
+				return false
+			}
+			script = script[end:]
+		case strings.HasPrefix(script, "/*"):
+			end := strings.Index(script, "*/")
+			if end < 0 {
+				return false
+			}
+			script = script[end+2:]
+		default:
+			return script != ""
+		}
+	}
+
+	return false
+}
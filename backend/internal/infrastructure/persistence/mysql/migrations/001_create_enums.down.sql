@@ -0,0 +1,3 @@
+-- Migration: Create enums and base types (down)
+-- Created: 2026-10-18
+-- Description: Nothing to revert, the enums are declared inline on each table
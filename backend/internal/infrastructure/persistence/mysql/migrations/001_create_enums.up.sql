@@ -4,6 +4,8 @@
 
 /*
 account_status: active, deactivated, suspended
+user_role: member, admin
+plan: free, premium
 genders: female, male, something_else, prefer_not_to_say
 invite_status: sent, accepted, declined, expired
 relationship_status: active, paused, ended
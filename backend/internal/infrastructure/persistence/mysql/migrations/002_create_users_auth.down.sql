@@ -0,0 +1,9 @@
+-- Migration: Create users and authentication tables (down)
+-- Created: 2026-10-18
+-- Description: Drop Refresh_Tokens, Sessions, Profile, Credentials, Users tables
+
+DROP TABLE IF EXISTS `Refresh_Tokens`;
+DROP TABLE IF EXISTS `Sessions`;
+DROP TABLE IF EXISTS `Profile`;
+DROP TABLE IF EXISTS `Credentials`;
+DROP TABLE IF EXISTS `Users`;
@@ -3,14 +3,14 @@
 -- Description: Users, Credentials, Profile, Sessions, Refresh_Tokens tables
 
 CREATE TABLE `Users` (
-  `id` uuid PRIMARY KEY,
+  `id` char(36) PRIMARY KEY,
   `created_at` timestamp NOT NULL DEFAULT (now()),
   `updated_at` timestamp NOT NULL DEFAULT (now()),
   `status` ENUM ('active', 'deactivated', 'suspended') NOT NULL DEFAULT 'active'
 );
 
 CREATE TABLE `Credentials` (
-  `user_id` uuid PRIMARY KEY NOT NULL,
+  `user_id` char(36) PRIMARY KEY NOT NULL,
   `email` varchar(255) UNIQUE NOT NULL,
   `password` varchar(255) NOT NULL COMMENT 'It will be hashed',
   `username` varchar(255) UNIQUE NOT NULL,
@@ -21,7 +21,7 @@ CREATE TABLE `Credentials` (
 );
 
 CREATE TABLE `Profile` (
-  `user_id` uuid PRIMARY KEY NOT NULL,
+  `user_id` char(36) PRIMARY KEY NOT NULL,
   `first_name` varchar(255),
   `last_name` varchar(255),
   `gender` ENUM ('female', 'male', 'something_else', 'prefer_not_to_say') NOT NULL DEFAULT 'prefer_not_to_say',
@@ -29,16 +29,16 @@ CREATE TABLE `Profile` (
   `bio` varchar(255),
   `display_name` varchar(255),
   `avatar_photo_id` varchar(255),
-  `relationship` uuid,
+  `relationship_id` char(36),
   `locale` varchar(255) NOT NULL DEFAULT 'en',
   `timezone` varchar(255) NOT NULL DEFAULT 'UTC'
 );
 
 CREATE TABLE `Sessions` (
-  `id` uuid PRIMARY KEY,
-  `user_id` uuid NOT NULL,
-  `user_agent` varchar(255),
-  `ip_address` varchar(255),
+  `id` char(36) PRIMARY KEY,
+  `user_id` char(36) NOT NULL,
+  `user_agent` varchar(512),
+  `ip_address` varchar(45),
   `created_at` timestamp NOT NULL DEFAULT (now()),
   `last_used_at` timestamp NOT NULL DEFAULT (now()),
   `revoked_at` timestamp,
@@ -46,10 +46,10 @@ CREATE TABLE `Sessions` (
 );
 
 CREATE TABLE `Refresh_Tokens` (
-  `id` uuid PRIMARY KEY,
-  `session_id` uuid NOT NULL,
-  `jti` uuid UNIQUE NOT NULL,
-  `family_id` uuid NOT NULL,
+  `id` char(36) PRIMARY KEY,
+  `session_id` char(36) NOT NULL,
+  `jti` char(36) UNIQUE NOT NULL,
+  `family_id` char(36) NOT NULL,
   `token_hash` varchar(255) NOT NULL,
   `expires_at` timestamp NOT NULL,
   `created_at` timestamp NOT NULL DEFAULT (now()),
@@ -58,7 +58,7 @@ CREATE TABLE `Refresh_Tokens` (
   `reason` varchar(255)
 );
 
-ALTER TABLE `Credentials` ADD FOREIGN KEY (`user_id`) REFERENCES `Users` (`id`);
-ALTER TABLE `Profile` ADD FOREIGN KEY (`user_id`) REFERENCES `Users` (`id`);
-ALTER TABLE `Sessions` ADD FOREIGN KEY (`user_id`) REFERENCES `Users` (`id`);
-ALTER TABLE `Refresh_Tokens` ADD FOREIGN KEY (`session_id`) REFERENCES `Sessions` (`id`);
\ No newline at end of file
+ALTER TABLE `Credentials` ADD FOREIGN KEY (`user_id`) REFERENCES `Users` (`id`) ON DELETE CASCADE;
+ALTER TABLE `Profile` ADD FOREIGN KEY (`user_id`) REFERENCES `Users` (`id`) ON DELETE CASCADE;
+ALTER TABLE `Sessions` ADD FOREIGN KEY (`user_id`) REFERENCES `Users` (`id`) ON DELETE CASCADE;
+ALTER TABLE `Refresh_Tokens` ADD FOREIGN KEY (`session_id`) REFERENCES `Sessions` (`id`) ON DELETE CASCADE;
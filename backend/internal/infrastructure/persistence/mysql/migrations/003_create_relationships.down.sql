@@ -0,0 +1,6 @@
+-- Migration: Create relationship tables (down)
+-- Created: 2026-10-18
+-- Description: Drop Relationship, RelationshipInvites tables
+
+DROP TABLE IF EXISTS `Relationship`;
+DROP TABLE IF EXISTS `RelationshipInvites`;
@@ -1,11 +1,11 @@
--- Migration: Create relationship tables  
+-- Migration: Create relationship tables
 -- Created: 2025-09-30
 -- Description: RelationshipInvites, Relationship tables
 
 CREATE TABLE `RelationshipInvites` (
-  `id` uuid PRIMARY KEY NOT NULL,
-  `inviter_id` uuid NOT NULL,
-  `invitee_id` uuid NOT NULL,
+  `id` char(36) PRIMARY KEY NOT NULL,
+  `inviter_id` char(36) NOT NULL,
+  `invitee_id` char(36) NOT NULL,
   `status` ENUM ('sent', 'accepted', 'declined', 'expired') NOT NULL DEFAULT 'sent',
   `message` varchar(255),
   `created_at` timestamp NOT NULL DEFAULT (now()),
@@ -13,9 +13,9 @@ CREATE TABLE `RelationshipInvites` (
 );
 
 CREATE TABLE `Relationship` (
-  `id` uuid PRIMARY KEY NOT NULL,
-  `partner_a_id` uuid NOT NULL COMMENT 'Enforce a != b and a < b in SQL',
-  `partner_b_id` uuid NOT NULL COMMENT 'Enforce a != b and a < b in SQL',
+  `id` char(36) PRIMARY KEY NOT NULL,
+  `partner_a_id` char(36) NOT NULL COMMENT 'Enforce a != b and a < b in SQL',
+  `partner_b_id` char(36) NOT NULL COMMENT 'Enforce a != b and a < b in SQL',
   `status` ENUM ('active', 'paused', 'ended') NOT NULL DEFAULT 'active',
   `visibility` ENUM ('private', 'friends', 'public') NOT NULL DEFAULT 'private',
   `title` varchar(255),
@@ -23,9 +23,9 @@ CREATE TABLE `Relationship` (
   `started_at` timestamp NOT NULL DEFAULT (now()),
   `anniversary_at` date,
   `ended_at` timestamp,
-  `avatar_photo_id` uuid,
-  `banner_photo_id` uuid,
-  `created_by` uuid,
+  `avatar_photo_id` char(36),
+  `banner_photo_id` char(36),
+  `created_by` char(36),
   `created_at` timestamp NOT NULL DEFAULT (now()),
   `updated_at` timestamp NOT NULL DEFAULT (now()),
   `last_activity_at` timestamp,
@@ -46,4 +46,4 @@ CREATE TABLE `Relationship` (
 ALTER TABLE `RelationshipInvites` ADD FOREIGN KEY (`inviter_id`) REFERENCES `Users` (`id`);
 ALTER TABLE `RelationshipInvites` ADD FOREIGN KEY (`invitee_id`) REFERENCES `Users` (`id`);
 ALTER TABLE `Relationship` ADD FOREIGN KEY (`partner_a_id`) REFERENCES `Users` (`id`);
-ALTER TABLE `Relationship` ADD FOREIGN KEY (`partner_b_id`) REFERENCES `Users` (`id`);
\ No newline at end of file
+ALTER TABLE `Relationship` ADD FOREIGN KEY (`partner_b_id`) REFERENCES `Users` (`id`);
@@ -0,0 +1,6 @@
+-- Migration: Create calendar and events tables (down)
+-- Created: 2026-10-18
+-- Description: Drop CalendarIntegrations, CalendarEvents tables
+
+DROP TABLE IF EXISTS `CalendarIntegrations`;
+DROP TABLE IF EXISTS `CalendarEvents`;
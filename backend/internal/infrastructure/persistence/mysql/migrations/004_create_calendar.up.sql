@@ -1,10 +1,10 @@
 -- Migration: Create calendar and events tables
--- Created: 2025-09-30  
+-- Created: 2025-09-30
 -- Description: CalendarEvents, CalendarIntegrations tables
 
 CREATE TABLE `CalendarEvents` (
-  `id` uuid PRIMARY KEY NOT NULL,
-  `relationship_id` uuid NOT NULL,
+  `id` char(36) PRIMARY KEY NOT NULL,
+  `relationship_id` char(36) NOT NULL,
   `title` varchar(255) NOT NULL,
   `description` varchar(255),
   `category` ENUM ('lecture', 'appointment', 'date', 'travel', 'celebration', 'health', 'chore', 'occupied', 'other') NOT NULL DEFAULT 'other',
@@ -26,9 +26,9 @@ CREATE TABLE `CalendarEvents` (
 );
 
 CREATE TABLE `CalendarIntegrations` (
-  `id` uuid PRIMARY KEY,
-  `relationship_id` uuid NOT NULL,
-  `user_id` uuid NOT NULL,
+  `id` char(36) PRIMARY KEY,
+  `relationship_id` char(36) NOT NULL,
+  `user_id` char(36) NOT NULL,
   `provider` varchar(255) NOT NULL,
   `external_calendar_id` varchar(255) NOT NULL,
   `sync_cursor` varchar(255),
@@ -47,4 +47,4 @@ CREATE INDEX `idx_events_relationship_timerange` ON `CalendarEvents` (`relations
 CREATE INDEX `idx_events_relationship_category` ON `CalendarEvents` (`relationship_id`, `category`, `start_at`);
 CREATE INDEX `idx_events_global_timerange` ON `CalendarEvents` (`start_at`, `end_at`);
 CREATE INDEX `idx_integrations_relationship_provider` ON `CalendarIntegrations` (`relationship_id`, `provider`);
-CREATE INDEX `idx_integrations_user_provider` ON `CalendarIntegrations` (`user_id`, `provider`);
\ No newline at end of file
+CREATE INDEX `idx_integrations_user_provider` ON `CalendarIntegrations` (`user_id`, `provider`);
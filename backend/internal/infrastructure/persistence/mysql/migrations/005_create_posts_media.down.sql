@@ -0,0 +1,9 @@
+-- Migration: Create wall posts and media tables (down)
+-- Created: 2026-10-18
+-- Description: Drop WallComment, WallReaction, WallPostMention, WallPostMedia, WallPost tables
+
+DROP TABLE IF EXISTS `WallComment`;
+DROP TABLE IF EXISTS `WallReaction`;
+DROP TABLE IF EXISTS `WallPostMention`;
+DROP TABLE IF EXISTS `WallPostMedia`;
+DROP TABLE IF EXISTS `WallPost`;
@@ -1,11 +1,11 @@
 -- Migration: Create wall posts and media tables
 -- Created: 2025-09-30
--- Description: WallPost, WallPostMedia, WallPostMention, WallReaction, WallComment tables  
+-- Description: WallPost, WallPostMedia, WallPostMention, WallReaction, WallComment tables
 
 CREATE TABLE `WallPost` (
-  `id` uuid PRIMARY KEY,
-  `relationship_id` uuid NOT NULL,
-  `author_user_id` uuid NOT NULL,
+  `id` char(36) PRIMARY KEY,
+  `relationship_id` char(36) NOT NULL,
+  `author_user_id` char(36) NOT NULL,
   `status` ENUM ('published', 'archived', 'deleted') NOT NULL DEFAULT 'published',
   `title` varchar(255),
   `body` text,
@@ -29,8 +29,8 @@ CREATE TABLE `WallPost` (
 );
 
 CREATE TABLE `WallPostMedia` (
-  `id` uuid PRIMARY KEY,
-  `post_id` uuid NOT NULL,
+  `id` char(36) PRIMARY KEY,
+  `post_id` char(36) NOT NULL,
   `kind` ENUM ('image', 'video', 'audio', 'file') NOT NULL DEFAULT 'image',
   `storage_key` varchar(255) NOT NULL,
   `mime_type` varchar(255) NOT NULL,
@@ -48,24 +48,24 @@ CREATE TABLE `WallPostMedia` (
 );
 
 CREATE TABLE `WallPostMention` (
-  `id` uuid PRIMARY KEY,
-  `post_id` uuid NOT NULL,
-  `mentioned_user_id` uuid NOT NULL
+  `id` char(36) PRIMARY KEY,
+  `post_id` char(36) NOT NULL,
+  `mentioned_user_id` char(36) NOT NULL
 );
 
 CREATE TABLE `WallReaction` (
-  `post_id` uuid NOT NULL,
-  `user_id` uuid NOT NULL,
+  `post_id` char(36) NOT NULL,
+  `user_id` char(36) NOT NULL,
   `type` ENUM ('like', 'love', 'laugh', 'sad') NOT NULL DEFAULT 'like',
   `created_at` timestamp NOT NULL DEFAULT (now()),
   PRIMARY KEY (`post_id`, `user_id`)
 );
 
 CREATE TABLE `WallComment` (
-  `id` uuid PRIMARY KEY,
-  `post_id` uuid NOT NULL,
-  `author_user_id` uuid NOT NULL,
-  `parent_id` uuid,
+  `id` char(36) PRIMARY KEY,
+  `post_id` char(36) NOT NULL,
+  `author_user_id` char(36) NOT NULL,
+  `parent_id` char(36),
   `body` text NOT NULL,
   `created_at` timestamp NOT NULL DEFAULT (now()),
   `updated_at` timestamp NOT NULL DEFAULT (now()),
@@ -0,0 +1,6 @@
+-- Migration: Create notes and mood tracking tables (down)
+-- Created: 2026-10-18
+-- Description: Drop MoodCheck, CoupleNote tables
+
+DROP TABLE IF EXISTS `MoodCheck`;
+DROP TABLE IF EXISTS `CoupleNote`;
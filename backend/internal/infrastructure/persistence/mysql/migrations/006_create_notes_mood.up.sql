@@ -3,21 +3,21 @@
 -- Description: CoupleNote, MoodCheck tables
 
 CREATE TABLE `CoupleNote` (
-  `id` uuid PRIMARY KEY,
-  `relationship_id` uuid NOT NULL,
-  `author_user_id` uuid NOT NULL,
+  `id` char(36) PRIMARY KEY,
+  `relationship_id` char(36) NOT NULL,
+  `author_user_id` char(36) NOT NULL,
   `title` varchar(255),
   `pinned` boolean NOT NULL DEFAULT false,
   `color` varchar(255) COMMENT 'UI accent hex (optional)',
-  `last_edited_by` uuid,
+  `last_edited_by` char(36),
   `created_at` timestamp NOT NULL DEFAULT (now()),
   `updated_at` timestamp NOT NULL DEFAULT (now())
 );
 
 CREATE TABLE `MoodCheck` (
-  `id` uuid PRIMARY KEY,
-  `relationship_id` uuid NOT NULL,
-  `user_id` uuid NOT NULL,
+  `id` char(36) PRIMARY KEY,
+  `relationship_id` char(36) NOT NULL,
+  `user_id` char(36) NOT NULL,
   `score` int NOT NULL COMMENT '1-5 range (1=very low, 5=very high). Add CHECK constraint in SQL',
   `note` varchar(255),
   `created_at` timestamp NOT NULL DEFAULT (now()),
@@ -42,4 +42,4 @@ CREATE INDEX `idx_notes_relationship` ON `CoupleNote` (`relationship_id`);
 CREATE INDEX `idx_mood_relationship_time` ON `MoodCheck` (`relationship_id`, `created_at`);
 CREATE INDEX `idx_mood_user_time` ON `MoodCheck` (`user_id`, `created_at`);
 CREATE INDEX `idx_mood_user_relationship` ON `MoodCheck` (`relationship_id`, `user_id`, `created_at`);
-CREATE INDEX `idx_mood_global_time` ON `MoodCheck` (`created_at`);
\ No newline at end of file
+CREATE INDEX `idx_mood_global_time` ON `MoodCheck` (`created_at`);
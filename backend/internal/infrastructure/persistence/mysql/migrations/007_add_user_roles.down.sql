@@ -0,0 +1,6 @@
+-- Migration: Add user roles (down)
+-- Created: 2026-10-18
+-- Description: Drop the role column from Users
+
+DROP INDEX `idx_users_role` ON `Users`;
+ALTER TABLE `Users` DROP COLUMN `role`;
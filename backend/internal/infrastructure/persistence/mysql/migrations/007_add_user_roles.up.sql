@@ -0,0 +1,8 @@
+-- Migration: Add user roles
+-- Created: 2026-10-18
+-- Description: Role column on Users used for role-based access control
+
+ALTER TABLE `Users`
+  ADD COLUMN `role` ENUM ('member', 'admin') NOT NULL DEFAULT 'member' AFTER `status`;
+
+CREATE INDEX `idx_users_role` ON `Users` (`role`);
@@ -0,0 +1,6 @@
+-- Migration: Create feature flag and plan tables (down)
+-- Created: 2026-10-18
+-- Description: Drop UserPlans, FeatureFlags tables
+
+DROP TABLE IF EXISTS `UserPlans`;
+DROP TABLE IF EXISTS `FeatureFlags`;
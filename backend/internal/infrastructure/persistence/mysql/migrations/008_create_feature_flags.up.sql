@@ -0,0 +1,29 @@
+-- Migration: Create feature flag and plan tables
+-- Created: 2026-10-18
+-- Description: FeatureFlags, UserPlans tables
+
+CREATE TABLE `FeatureFlags` (
+  `key` varchar(100) PRIMARY KEY NOT NULL,
+  `description` varchar(255),
+  `enabled` boolean NOT NULL DEFAULT false,
+  `environments` json COMMENT 'Environments the flag is limited to, null means all',
+  `user_ids` json COMMENT 'Users that always receive the flag',
+  `relationship_ids` json COMMENT 'Relationships that always receive the flag',
+  `rollout_percentage` int NOT NULL DEFAULT 0,
+  `required_entitlement` varchar(100),
+  `created_at` timestamp NOT NULL DEFAULT (now()),
+  `updated_at` timestamp NOT NULL DEFAULT (now()),
+
+  -- Flag constraints
+  CHECK (`rollout_percentage` >= 0 AND `rollout_percentage` <= 100)
+);
+
+CREATE TABLE `UserPlans` (
+  `user_id` char(36) PRIMARY KEY NOT NULL,
+  `plan` ENUM ('free', 'premium') NOT NULL DEFAULT 'free',
+  `started_at` timestamp NOT NULL DEFAULT (now()),
+  `expires_at` timestamp
+);
+
+-- Add foreign keys
+ALTER TABLE `UserPlans` ADD FOREIGN KEY (`user_id`) REFERENCES `Users` (`id`);
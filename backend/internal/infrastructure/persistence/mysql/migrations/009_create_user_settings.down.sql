@@ -0,0 +1,5 @@
+-- Migration: Create user settings table (down)
+-- Created: 2026-10-18
+-- Description: Drop UserSettings table
+
+DROP TABLE IF EXISTS `UserSettings`;
@@ -0,0 +1,14 @@
+-- Migration: Create user settings table
+-- Created: 2026-10-18
+-- Description: UserSettings table storing versioned per-user app settings
+
+CREATE TABLE `UserSettings` (
+  `user_id` char(36) PRIMARY KEY NOT NULL,
+  `schema_version` int NOT NULL DEFAULT 1,
+  `data` json NOT NULL COMMENT 'Settings document matching schema_version',
+  `revision` bigint NOT NULL DEFAULT 0 COMMENT 'Incremented on every change for device sync',
+  `updated_at` timestamp NOT NULL DEFAULT (now())
+);
+
+-- Add foreign keys
+ALTER TABLE `UserSettings` ADD FOREIGN KEY (`user_id`) REFERENCES `Users` (`id`) ON DELETE CASCADE;
@@ -0,0 +1,5 @@
+-- Migration: Create security log table (down)
+-- Created: 2026-10-18
+-- Description: Drop SecurityLog table
+
+DROP TABLE IF EXISTS `SecurityLog`;
@@ -0,0 +1,22 @@
+-- Migration: Create security log table
+-- Created: 2026-10-18
+-- Description: Append-only SecurityLog of authentication events
+
+CREATE TABLE `SecurityLog` (
+  `id` char(36) PRIMARY KEY NOT NULL,
+  `user_id` char(36) NOT NULL,
+  `event_type` ENUM ('login_succeeded', 'login_failed', 'mfa_enabled', 'mfa_disabled', 'password_changed', 'session_revoked') NOT NULL,
+  `ip_address` varchar(45),
+  `user_agent` varchar(512),
+  `country` char(2) COMMENT 'ISO 3166-1 alpha-2 from the GeoIP database',
+  `city` varchar(255),
+  `request_id` varchar(255),
+  `metadata` json,
+  `occurred_at` timestamp(6) NOT NULL DEFAULT (now(6))
+);
+
+-- Add foreign keys
+ALTER TABLE `SecurityLog` ADD FOREIGN KEY (`user_id`) REFERENCES `Users` (`id`) ON DELETE CASCADE;
+
+-- Add indexes for better performance
+CREATE INDEX `idx_security_log_user_time` ON `SecurityLog` (`user_id`, `occurred_at`);
@@ -0,0 +1,11 @@
+-- Migration: Create devices and link them to sessions (down)
+-- Created: 2026-10-18
+-- Description: Drop device columns from Sessions and the Devices table
+
+ALTER TABLE `Sessions` DROP FOREIGN KEY `fk_sessions_device`;
+DROP INDEX `idx_sessions_revoke_token` ON `Sessions`;
+ALTER TABLE `Sessions`
+  DROP COLUMN `device_id`,
+  DROP COLUMN `revoke_token_hash`;
+
+DROP TABLE IF EXISTS `Devices`;
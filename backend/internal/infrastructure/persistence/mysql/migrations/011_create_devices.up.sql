@@ -0,0 +1,26 @@
+-- Migration: Create devices and link them to sessions
+-- Created: 2026-10-18
+-- Description: Devices table, device and revoke token columns on Sessions
+
+CREATE TABLE `Devices` (
+  `id` char(36) PRIMARY KEY NOT NULL,
+  `user_id` char(36) NOT NULL,
+  `fingerprint` char(64) NOT NULL COMMENT 'SHA-256 of user agent family and IP block',
+  `user_agent_family` varchar(255) NOT NULL,
+  `ip_block` varchar(64) NOT NULL,
+  `first_seen_at` timestamp NOT NULL DEFAULT (now()),
+  `last_seen_at` timestamp NOT NULL DEFAULT (now()),
+  `trusted_until` timestamp COMMENT 'Device may skip the MFA step until then'
+);
+
+ALTER TABLE `Sessions`
+  ADD COLUMN `device_id` char(36) AFTER `user_id`,
+  ADD COLUMN `revoke_token_hash` char(64) COMMENT 'SHA-256 of the "this wasn''t me" link token';
+
+-- Add foreign keys
+ALTER TABLE `Devices` ADD FOREIGN KEY (`user_id`) REFERENCES `Users` (`id`) ON DELETE CASCADE;
+ALTER TABLE `Sessions` ADD CONSTRAINT `fk_sessions_device` FOREIGN KEY (`device_id`) REFERENCES `Devices` (`id`) ON DELETE SET NULL;
+
+-- Add indexes for better performance
+CREATE UNIQUE INDEX `idx_devices_user_fingerprint` ON `Devices` (`user_id`, `fingerprint`);
+CREATE UNIQUE INDEX `idx_sessions_revoke_token` ON `Sessions` (`revoke_token_hash`);
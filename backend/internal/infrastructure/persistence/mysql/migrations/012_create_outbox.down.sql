@@ -0,0 +1,5 @@
+-- Migration: Create transactional outbox (down)
+-- Created: 2026-10-18
+-- Description: Drop Outbox table
+
+DROP TABLE IF EXISTS `Outbox`;
@@ -0,0 +1,25 @@
+-- Migration: Create transactional outbox
+-- Created: 2026-10-18
+-- Description: Domain events written in the same transaction as their aggregate, published by the relay
+
+CREATE TABLE `Outbox` (
+  `id` bigint unsigned PRIMARY KEY NOT NULL AUTO_INCREMENT,
+  `event_id` varchar(64) NOT NULL,
+  `event_type` varchar(100) NOT NULL,
+  `aggregate_id` varchar(64),
+  `payload` json NOT NULL,
+  `occurred_at` timestamp(6) NOT NULL,
+  `status` ENUM ('pending', 'published', 'dead') NOT NULL DEFAULT 'pending',
+  `attempts` int NOT NULL DEFAULT 0,
+  `next_attempt_at` timestamp(6) NOT NULL,
+  `last_error` varchar(1024),
+  `locked_until` timestamp(6) NULL COMMENT 'Lease of the relay that claimed the row',
+  `lock_token` char(36),
+  `published_at` timestamp(6) NULL,
+  `created_at` timestamp(6) NOT NULL DEFAULT (now(6))
+);
+
+-- Add indexes for better performance
+CREATE UNIQUE INDEX `idx_outbox_event_id` ON `Outbox` (`event_id`);
+CREATE INDEX `idx_outbox_due` ON `Outbox` (`status`, `next_attempt_at`);
+CREATE INDEX `idx_outbox_lock_token` ON `Outbox` (`lock_token`);
@@ -0,0 +1,145 @@
+-- Migration: Store UUID keys in binary form
+-- Created: 2026-10-18
+-- Description: Convert char(36) keys and references to binary(16), the application generates UUIDv7 keys
+
+-- Drop foreign keys while the key columns change type
+ALTER TABLE `Credentials` DROP FOREIGN KEY `Credentials_ibfk_1`;
+ALTER TABLE `Profile` DROP FOREIGN KEY `Profile_ibfk_1`;
+ALTER TABLE `Sessions` DROP FOREIGN KEY `Sessions_ibfk_1`, DROP FOREIGN KEY `fk_sessions_device`;
+ALTER TABLE `Refresh_Tokens` DROP FOREIGN KEY `Refresh_Tokens_ibfk_1`;
+ALTER TABLE `RelationshipInvites` DROP FOREIGN KEY `RelationshipInvites_ibfk_1`, DROP FOREIGN KEY `RelationshipInvites_ibfk_2`;
+ALTER TABLE `Relationship` DROP FOREIGN KEY `Relationship_ibfk_1`, DROP FOREIGN KEY `Relationship_ibfk_2`;
+ALTER TABLE `CalendarEvents` DROP FOREIGN KEY `CalendarEvents_ibfk_1`;
+ALTER TABLE `CalendarIntegrations` DROP FOREIGN KEY `CalendarIntegrations_ibfk_1`, DROP FOREIGN KEY `CalendarIntegrations_ibfk_2`;
+ALTER TABLE `WallPost` DROP FOREIGN KEY `WallPost_ibfk_1`, DROP FOREIGN KEY `WallPost_ibfk_2`;
+ALTER TABLE `WallPostMedia` DROP FOREIGN KEY `WallPostMedia_ibfk_1`;
+ALTER TABLE `WallPostMention` DROP FOREIGN KEY `WallPostMention_ibfk_1`, DROP FOREIGN KEY `WallPostMention_ibfk_2`;
+ALTER TABLE `WallReaction` DROP FOREIGN KEY `WallReaction_ibfk_1`, DROP FOREIGN KEY `WallReaction_ibfk_2`;
+ALTER TABLE `WallComment` DROP FOREIGN KEY `WallComment_ibfk_1`, DROP FOREIGN KEY `WallComment_ibfk_2`, DROP FOREIGN KEY `WallComment_ibfk_3`;
+ALTER TABLE `CoupleNote` DROP FOREIGN KEY `CoupleNote_ibfk_1`, DROP FOREIGN KEY `CoupleNote_ibfk_2`, DROP FOREIGN KEY `CoupleNote_ibfk_3`;
+ALTER TABLE `MoodCheck` DROP FOREIGN KEY `MoodCheck_ibfk_1`, DROP FOREIGN KEY `MoodCheck_ibfk_2`;
+ALTER TABLE `UserPlans` DROP FOREIGN KEY `UserPlans_ibfk_1`;
+ALTER TABLE `UserSettings` DROP FOREIGN KEY `UserSettings_ibfk_1`;
+ALTER TABLE `SecurityLog` DROP FOREIGN KEY `SecurityLog_ibfk_1`;
+ALTER TABLE `Devices` DROP FOREIGN KEY `Devices_ibfk_1`;
+
+-- Old event IDs were not UUIDs, replace them before converting
+UPDATE `Outbox` SET `event_id` = UUID() WHERE NOT IS_UUID(`event_id`);
+
+-- Convert the stored text to binary through an untyped column
+ALTER TABLE `Users` MODIFY `id` varbinary(36) NOT NULL;
+UPDATE `Users` SET `id` = UUID_TO_BIN(`id`);
+ALTER TABLE `Users` MODIFY `id` binary(16) NOT NULL;
+
+ALTER TABLE `Credentials` MODIFY `user_id` varbinary(36) NOT NULL;
+UPDATE `Credentials` SET `user_id` = UUID_TO_BIN(`user_id`);
+ALTER TABLE `Credentials` MODIFY `user_id` binary(16) NOT NULL;
+
+ALTER TABLE `Profile` MODIFY `user_id` varbinary(36) NOT NULL, MODIFY `relationship_id` varbinary(36);
+UPDATE `Profile` SET `user_id` = UUID_TO_BIN(`user_id`), `relationship_id` = UUID_TO_BIN(`relationship_id`);
+ALTER TABLE `Profile` MODIFY `user_id` binary(16) NOT NULL, MODIFY `relationship_id` binary(16);
+
+ALTER TABLE `Sessions` MODIFY `id` varbinary(36) NOT NULL, MODIFY `user_id` varbinary(36) NOT NULL, MODIFY `device_id` varbinary(36);
+UPDATE `Sessions` SET `id` = UUID_TO_BIN(`id`), `user_id` = UUID_TO_BIN(`user_id`), `device_id` = UUID_TO_BIN(`device_id`);
+ALTER TABLE `Sessions` MODIFY `id` binary(16) NOT NULL, MODIFY `user_id` binary(16) NOT NULL, MODIFY `device_id` binary(16);
+
+ALTER TABLE `Refresh_Tokens` MODIFY `id` varbinary(36) NOT NULL, MODIFY `session_id` varbinary(36) NOT NULL, MODIFY `family_id` varbinary(36) NOT NULL;
+UPDATE `Refresh_Tokens` SET `id` = UUID_TO_BIN(`id`), `session_id` = UUID_TO_BIN(`session_id`), `family_id` = UUID_TO_BIN(`family_id`);
+ALTER TABLE `Refresh_Tokens` MODIFY `id` binary(16) NOT NULL, MODIFY `session_id` binary(16) NOT NULL, MODIFY `family_id` binary(16) NOT NULL;
+
+ALTER TABLE `RelationshipInvites` MODIFY `id` varbinary(36) NOT NULL, MODIFY `inviter_id` varbinary(36) NOT NULL, MODIFY `invitee_id` varbinary(36) NOT NULL;
+UPDATE `RelationshipInvites` SET `id` = UUID_TO_BIN(`id`), `inviter_id` = UUID_TO_BIN(`inviter_id`), `invitee_id` = UUID_TO_BIN(`invitee_id`);
+ALTER TABLE `RelationshipInvites` MODIFY `id` binary(16) NOT NULL, MODIFY `inviter_id` binary(16) NOT NULL, MODIFY `invitee_id` binary(16) NOT NULL;
+
+ALTER TABLE `Relationship` MODIFY `id` varbinary(36) NOT NULL, MODIFY `partner_a_id` varbinary(36) NOT NULL, MODIFY `partner_b_id` varbinary(36) NOT NULL, MODIFY `avatar_photo_id` varbinary(36), MODIFY `banner_photo_id` varbinary(36), MODIFY `created_by` varbinary(36);
+UPDATE `Relationship` SET `id` = UUID_TO_BIN(`id`), `partner_a_id` = UUID_TO_BIN(`partner_a_id`), `partner_b_id` = UUID_TO_BIN(`partner_b_id`), `avatar_photo_id` = UUID_TO_BIN(`avatar_photo_id`), `banner_photo_id` = UUID_TO_BIN(`banner_photo_id`), `created_by` = UUID_TO_BIN(`created_by`);
+ALTER TABLE `Relationship` MODIFY `id` binary(16) NOT NULL, MODIFY `partner_a_id` binary(16) NOT NULL COMMENT 'Enforce a != b and a < b in SQL', MODIFY `partner_b_id` binary(16) NOT NULL COMMENT 'Enforce a != b and a < b in SQL', MODIFY `avatar_photo_id` binary(16), MODIFY `banner_photo_id` binary(16), MODIFY `created_by` binary(16);
+
+ALTER TABLE `CalendarEvents` MODIFY `id` varbinary(36) NOT NULL, MODIFY `relationship_id` varbinary(36) NOT NULL;
+UPDATE `CalendarEvents` SET `id` = UUID_TO_BIN(`id`), `relationship_id` = UUID_TO_BIN(`relationship_id`);
+ALTER TABLE `CalendarEvents` MODIFY `id` binary(16) NOT NULL, MODIFY `relationship_id` binary(16) NOT NULL;
+
+ALTER TABLE `CalendarIntegrations` MODIFY `id` varbinary(36) NOT NULL, MODIFY `relationship_id` varbinary(36) NOT NULL, MODIFY `user_id` varbinary(36) NOT NULL;
+UPDATE `CalendarIntegrations` SET `id` = UUID_TO_BIN(`id`), `relationship_id` = UUID_TO_BIN(`relationship_id`), `user_id` = UUID_TO_BIN(`user_id`);
+ALTER TABLE `CalendarIntegrations` MODIFY `id` binary(16) NOT NULL, MODIFY `relationship_id` binary(16) NOT NULL, MODIFY `user_id` binary(16) NOT NULL;
+
+ALTER TABLE `WallPost` MODIFY `id` varbinary(36) NOT NULL, MODIFY `relationship_id` varbinary(36) NOT NULL, MODIFY `author_user_id` varbinary(36) NOT NULL;
+UPDATE `WallPost` SET `id` = UUID_TO_BIN(`id`), `relationship_id` = UUID_TO_BIN(`relationship_id`), `author_user_id` = UUID_TO_BIN(`author_user_id`);
+ALTER TABLE `WallPost` MODIFY `id` binary(16) NOT NULL, MODIFY `relationship_id` binary(16) NOT NULL, MODIFY `author_user_id` binary(16) NOT NULL;
+
+ALTER TABLE `WallPostMedia` MODIFY `id` varbinary(36) NOT NULL, MODIFY `post_id` varbinary(36) NOT NULL;
+UPDATE `WallPostMedia` SET `id` = UUID_TO_BIN(`id`), `post_id` = UUID_TO_BIN(`post_id`);
+ALTER TABLE `WallPostMedia` MODIFY `id` binary(16) NOT NULL, MODIFY `post_id` binary(16) NOT NULL;
+
+ALTER TABLE `WallPostMention` MODIFY `id` varbinary(36) NOT NULL, MODIFY `post_id` varbinary(36) NOT NULL, MODIFY `mentioned_user_id` varbinary(36) NOT NULL;
+UPDATE `WallPostMention` SET `id` = UUID_TO_BIN(`id`), `post_id` = UUID_TO_BIN(`post_id`), `mentioned_user_id` = UUID_TO_BIN(`mentioned_user_id`);
+ALTER TABLE `WallPostMention` MODIFY `id` binary(16) NOT NULL, MODIFY `post_id` binary(16) NOT NULL, MODIFY `mentioned_user_id` binary(16) NOT NULL;
+
+ALTER TABLE `WallReaction` MODIFY `post_id` varbinary(36) NOT NULL, MODIFY `user_id` varbinary(36) NOT NULL;
+UPDATE `WallReaction` SET `post_id` = UUID_TO_BIN(`post_id`), `user_id` = UUID_TO_BIN(`user_id`);
+ALTER TABLE `WallReaction` MODIFY `post_id` binary(16) NOT NULL, MODIFY `user_id` binary(16) NOT NULL;
+
+ALTER TABLE `WallComment` MODIFY `id` varbinary(36) NOT NULL, MODIFY `post_id` varbinary(36) NOT NULL, MODIFY `author_user_id` varbinary(36) NOT NULL, MODIFY `parent_id` varbinary(36);
+UPDATE `WallComment` SET `id` = UUID_TO_BIN(`id`), `post_id` = UUID_TO_BIN(`post_id`), `author_user_id` = UUID_TO_BIN(`author_user_id`), `parent_id` = UUID_TO_BIN(`parent_id`);
+ALTER TABLE `WallComment` MODIFY `id` binary(16) NOT NULL, MODIFY `post_id` binary(16) NOT NULL, MODIFY `author_user_id` binary(16) NOT NULL, MODIFY `parent_id` binary(16);
+
+ALTER TABLE `CoupleNote` MODIFY `id` varbinary(36) NOT NULL, MODIFY `relationship_id` varbinary(36) NOT NULL, MODIFY `author_user_id` varbinary(36) NOT NULL, MODIFY `last_edited_by` varbinary(36);
+UPDATE `CoupleNote` SET `id` = UUID_TO_BIN(`id`), `relationship_id` = UUID_TO_BIN(`relationship_id`), `author_user_id` = UUID_TO_BIN(`author_user_id`), `last_edited_by` = UUID_TO_BIN(`last_edited_by`);
+ALTER TABLE `CoupleNote` MODIFY `id` binary(16) NOT NULL, MODIFY `relationship_id` binary(16) NOT NULL, MODIFY `author_user_id` binary(16) NOT NULL, MODIFY `last_edited_by` binary(16);
+
+ALTER TABLE `MoodCheck` MODIFY `id` varbinary(36) NOT NULL, MODIFY `relationship_id` varbinary(36) NOT NULL, MODIFY `user_id` varbinary(36) NOT NULL;
+UPDATE `MoodCheck` SET `id` = UUID_TO_BIN(`id`), `relationship_id` = UUID_TO_BIN(`relationship_id`), `user_id` = UUID_TO_BIN(`user_id`);
+ALTER TABLE `MoodCheck` MODIFY `id` binary(16) NOT NULL, MODIFY `relationship_id` binary(16) NOT NULL, MODIFY `user_id` binary(16) NOT NULL;
+
+ALTER TABLE `UserPlans` MODIFY `user_id` varbinary(36) NOT NULL;
+UPDATE `UserPlans` SET `user_id` = UUID_TO_BIN(`user_id`);
+ALTER TABLE `UserPlans` MODIFY `user_id` binary(16) NOT NULL;
+
+ALTER TABLE `UserSettings` MODIFY `user_id` varbinary(36) NOT NULL;
+UPDATE `UserSettings` SET `user_id` = UUID_TO_BIN(`user_id`);
+ALTER TABLE `UserSettings` MODIFY `user_id` binary(16) NOT NULL;
+
+ALTER TABLE `SecurityLog` MODIFY `id` varbinary(36) NOT NULL, MODIFY `user_id` varbinary(36) NOT NULL;
+UPDATE `SecurityLog` SET `id` = UUID_TO_BIN(`id`), `user_id` = UUID_TO_BIN(`user_id`);
+ALTER TABLE `SecurityLog` MODIFY `id` binary(16) NOT NULL, MODIFY `user_id` binary(16) NOT NULL;
+
+ALTER TABLE `Devices` MODIFY `id` varbinary(36) NOT NULL, MODIFY `user_id` varbinary(36) NOT NULL;
+UPDATE `Devices` SET `id` = UUID_TO_BIN(`id`), `user_id` = UUID_TO_BIN(`user_id`);
+ALTER TABLE `Devices` MODIFY `id` binary(16) NOT NULL, MODIFY `user_id` binary(16) NOT NULL;
+
+ALTER TABLE `Outbox` MODIFY `event_id` varbinary(64) NOT NULL;
+UPDATE `Outbox` SET `event_id` = UUID_TO_BIN(`event_id`);
+ALTER TABLE `Outbox` MODIFY `event_id` binary(16) NOT NULL;
+
+-- Restore foreign keys under their original names
+ALTER TABLE `Credentials` ADD CONSTRAINT `Credentials_ibfk_1` FOREIGN KEY (`user_id`) REFERENCES `Users` (`id`) ON DELETE CASCADE;
+ALTER TABLE `Profile` ADD CONSTRAINT `Profile_ibfk_1` FOREIGN KEY (`user_id`) REFERENCES `Users` (`id`) ON DELETE CASCADE;
+ALTER TABLE `Sessions` ADD CONSTRAINT `Sessions_ibfk_1` FOREIGN KEY (`user_id`) REFERENCES `Users` (`id`) ON DELETE CASCADE;
+ALTER TABLE `Refresh_Tokens` ADD CONSTRAINT `Refresh_Tokens_ibfk_1` FOREIGN KEY (`session_id`) REFERENCES `Sessions` (`id`) ON DELETE CASCADE;
+ALTER TABLE `RelationshipInvites` ADD CONSTRAINT `RelationshipInvites_ibfk_1` FOREIGN KEY (`inviter_id`) REFERENCES `Users` (`id`);
+ALTER TABLE `RelationshipInvites` ADD CONSTRAINT `RelationshipInvites_ibfk_2` FOREIGN KEY (`invitee_id`) REFERENCES `Users` (`id`);
+ALTER TABLE `Relationship` ADD CONSTRAINT `Relationship_ibfk_1` FOREIGN KEY (`partner_a_id`) REFERENCES `Users` (`id`);
+ALTER TABLE `Relationship` ADD CONSTRAINT `Relationship_ibfk_2` FOREIGN KEY (`partner_b_id`) REFERENCES `Users` (`id`);
+ALTER TABLE `CalendarEvents` ADD CONSTRAINT `CalendarEvents_ibfk_1` FOREIGN KEY (`relationship_id`) REFERENCES `Relationship` (`id`);
+ALTER TABLE `CalendarIntegrations` ADD CONSTRAINT `CalendarIntegrations_ibfk_1` FOREIGN KEY (`relationship_id`) REFERENCES `Relationship` (`id`);
+ALTER TABLE `CalendarIntegrations` ADD CONSTRAINT `CalendarIntegrations_ibfk_2` FOREIGN KEY (`user_id`) REFERENCES `Users` (`id`);
+ALTER TABLE `WallPost` ADD CONSTRAINT `WallPost_ibfk_1` FOREIGN KEY (`relationship_id`) REFERENCES `Relationship` (`id`);
+ALTER TABLE `WallPost` ADD CONSTRAINT `WallPost_ibfk_2` FOREIGN KEY (`author_user_id`) REFERENCES `Users` (`id`);
+ALTER TABLE `WallPostMedia` ADD CONSTRAINT `WallPostMedia_ibfk_1` FOREIGN KEY (`post_id`) REFERENCES `WallPost` (`id`) ON DELETE CASCADE;
+ALTER TABLE `WallPostMention` ADD CONSTRAINT `WallPostMention_ibfk_1` FOREIGN KEY (`post_id`) REFERENCES `WallPost` (`id`) ON DELETE CASCADE;
+ALTER TABLE `WallPostMention` ADD CONSTRAINT `WallPostMention_ibfk_2` FOREIGN KEY (`mentioned_user_id`) REFERENCES `Users` (`id`);
+ALTER TABLE `WallReaction` ADD CONSTRAINT `WallReaction_ibfk_1` FOREIGN KEY (`post_id`) REFERENCES `WallPost` (`id`) ON DELETE CASCADE;
+ALTER TABLE `WallReaction` ADD CONSTRAINT `WallReaction_ibfk_2` FOREIGN KEY (`user_id`) REFERENCES `Users` (`id`);
+ALTER TABLE `WallComment` ADD CONSTRAINT `WallComment_ibfk_1` FOREIGN KEY (`post_id`) REFERENCES `WallPost` (`id`) ON DELETE CASCADE;
+ALTER TABLE `WallComment` ADD CONSTRAINT `WallComment_ibfk_2` FOREIGN KEY (`author_user_id`) REFERENCES `Users` (`id`);
+ALTER TABLE `WallComment` ADD CONSTRAINT `WallComment_ibfk_3` FOREIGN KEY (`parent_id`) REFERENCES `WallComment` (`id`);
+ALTER TABLE `CoupleNote` ADD CONSTRAINT `CoupleNote_ibfk_1` FOREIGN KEY (`relationship_id`) REFERENCES `Relationship` (`id`);
+ALTER TABLE `CoupleNote` ADD CONSTRAINT `CoupleNote_ibfk_2` FOREIGN KEY (`author_user_id`) REFERENCES `Users` (`id`);
+ALTER TABLE `CoupleNote` ADD CONSTRAINT `CoupleNote_ibfk_3` FOREIGN KEY (`last_edited_by`) REFERENCES `Users` (`id`);
+ALTER TABLE `MoodCheck` ADD CONSTRAINT `MoodCheck_ibfk_1` FOREIGN KEY (`relationship_id`) REFERENCES `Relationship` (`id`);
+ALTER TABLE `MoodCheck` ADD CONSTRAINT `MoodCheck_ibfk_2` FOREIGN KEY (`user_id`) REFERENCES `Users` (`id`);
+ALTER TABLE `UserPlans` ADD CONSTRAINT `UserPlans_ibfk_1` FOREIGN KEY (`user_id`) REFERENCES `Users` (`id`);
+ALTER TABLE `UserSettings` ADD CONSTRAINT `UserSettings_ibfk_1` FOREIGN KEY (`user_id`) REFERENCES `Users` (`id`) ON DELETE CASCADE;
+ALTER TABLE `SecurityLog` ADD CONSTRAINT `SecurityLog_ibfk_1` FOREIGN KEY (`user_id`) REFERENCES `Users` (`id`) ON DELETE CASCADE;
+ALTER TABLE `Devices` ADD CONSTRAINT `Devices_ibfk_1` FOREIGN KEY (`user_id`) REFERENCES `Users` (`id`) ON DELETE CASCADE;
+ALTER TABLE `Sessions` ADD CONSTRAINT `fk_sessions_device` FOREIGN KEY (`device_id`) REFERENCES `Devices` (`id`) ON DELETE SET NULL;
@@ -0,0 +1,11 @@
+-- Migration: Add event envelope columns to the outbox (down)
+-- Created: 2026-10-18
+-- Description: Drop event envelope columns from Outbox
+
+DROP INDEX `idx_outbox_correlation` ON `Outbox`;
+ALTER TABLE `Outbox`
+  DROP COLUMN `schema_version`,
+  DROP COLUMN `aggregate_type`,
+  DROP COLUMN `actor_id`,
+  DROP COLUMN `correlation_id`,
+  DROP COLUMN `causation_id`;
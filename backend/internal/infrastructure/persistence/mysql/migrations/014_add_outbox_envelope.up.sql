@@ -0,0 +1,16 @@
+-- Migration: Add event envelope columns to the outbox
+-- Created: 2026-10-18
+-- Description: Schema version, aggregate type, actor, correlation and causation of stored events
+
+ALTER TABLE `Outbox`
+  ADD COLUMN `schema_version` int NOT NULL DEFAULT 1 AFTER `event_type`,
+  ADD COLUMN `aggregate_type` varchar(50) NOT NULL DEFAULT '' AFTER `schema_version`,
+  ADD COLUMN `actor_id` binary(16) COMMENT 'User whose request raised the event' AFTER `aggregate_id`,
+  ADD COLUMN `correlation_id` varchar(255) COMMENT 'Request ID shared by every event of one operation' AFTER `actor_id`,
+  ADD COLUMN `causation_id` binary(16) COMMENT 'Event whose handling raised this one' AFTER `correlation_id`;
+
+-- Every event stored so far belongs to a user
+UPDATE `Outbox` SET `aggregate_type` = 'user';
+
+-- Add indexes for better performance
+CREATE INDEX `idx_outbox_correlation` ON `Outbox` (`correlation_id`);
@@ -0,0 +1,5 @@
+-- Migration: Add version to users (down)
+-- Created: 2026-10-18
+-- Description: Drop version from Users
+
+ALTER TABLE `Users` DROP COLUMN `version`;
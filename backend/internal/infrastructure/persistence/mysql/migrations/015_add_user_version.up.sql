@@ -0,0 +1,6 @@
+-- Migration: Add version to users
+-- Created: 2026-10-18
+-- Description: Version checked and incremented by every update for optimistic concurrency
+
+ALTER TABLE `Users`
+  ADD COLUMN `version` bigint NOT NULL DEFAULT 1 COMMENT 'Incremented on every update, stale writes are rejected' AFTER `role`;
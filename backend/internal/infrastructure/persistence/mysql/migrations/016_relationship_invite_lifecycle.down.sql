@@ -0,0 +1,13 @@
+-- Migration: Relationship invite lifecycle (down)
+-- Created: 2026-10-18
+-- Description: Drop invite expiry and the cancelled status
+
+DROP INDEX `idx_invites_invitee_status` ON `RelationshipInvites`;
+DROP INDEX `idx_invites_inviter_status` ON `RelationshipInvites`;
+
+-- Cancelled invites read as declined once the status is gone
+UPDATE `RelationshipInvites` SET `status` = 'declined' WHERE `status` = 'cancelled';
+
+ALTER TABLE `RelationshipInvites`
+  MODIFY `status` ENUM ('sent', 'accepted', 'declined', 'expired') NOT NULL DEFAULT 'sent',
+  DROP COLUMN `expires_at`;
@@ -0,0 +1,15 @@
+-- Migration: Relationship invite lifecycle
+-- Created: 2026-10-18
+-- Description: Let inviters cancel invites, expire unanswered invites and index the pending lookups
+
+ALTER TABLE `RelationshipInvites`
+  MODIFY `status` ENUM ('sent', 'accepted', 'declined', 'expired', 'cancelled') NOT NULL DEFAULT 'sent',
+  ADD COLUMN `expires_at` timestamp NULL AFTER `created_at`;
+
+-- Invites sent before expiry existed get the same two weeks to be answered
+UPDATE `RelationshipInvites` SET `expires_at` = `created_at` + INTERVAL 14 DAY;
+
+ALTER TABLE `RelationshipInvites` MODIFY `expires_at` timestamp NOT NULL COMMENT 'Unanswered invites past this are expired';
+
+CREATE INDEX `idx_invites_inviter_status` ON `RelationshipInvites` (`inviter_id`, `status`);
+CREATE INDEX `idx_invites_invitee_status` ON `RelationshipInvites` (`invitee_id`, `status`);
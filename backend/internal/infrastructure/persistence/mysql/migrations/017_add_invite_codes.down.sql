@@ -0,0 +1,10 @@
+-- Migration: Add invite codes (down)
+-- Created: 2026-10-18
+-- Description: Drop invite codes, unredeemed code invites are deleted
+
+DELETE FROM `RelationshipInvites` WHERE `invitee_id` IS NULL;
+
+ALTER TABLE `RelationshipInvites`
+  DROP INDEX `idx_invites_code_hash`,
+  DROP COLUMN `code_hash`,
+  MODIFY `invitee_id` binary(16) NOT NULL;
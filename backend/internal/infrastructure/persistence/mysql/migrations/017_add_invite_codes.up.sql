@@ -0,0 +1,8 @@
+-- Migration: Add invite codes
+-- Created: 2026-10-18
+-- Description: Invites shared as a code have no invitee until the code is redeemed
+
+ALTER TABLE `RelationshipInvites`
+  MODIFY `invitee_id` binary(16) NULL COMMENT 'Unknown until an invite code is redeemed',
+  ADD COLUMN `code_hash` char(64) NULL COMMENT 'SHA-256 of the invite code, the code itself is never stored' AFTER `message`,
+  ADD UNIQUE INDEX `idx_invites_code_hash` (`code_hash`);
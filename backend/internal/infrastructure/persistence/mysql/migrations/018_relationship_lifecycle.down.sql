@@ -0,0 +1,12 @@
+-- Migration: Relationship lifecycle (down)
+-- Created: 2026-10-18
+-- Description: Drop the relationship version and pending requests
+
+ALTER TABLE `Relationship` DROP FOREIGN KEY `fk_relationship_pending_requested_by`;
+
+ALTER TABLE `Relationship`
+  DROP CHECK `chk_relationship_pending_request`,
+  DROP COLUMN `pending_requested_at`,
+  DROP COLUMN `pending_requested_by`,
+  DROP COLUMN `pending_request`,
+  DROP COLUMN `version`;
@@ -0,0 +1,15 @@
+-- Migration: Relationship lifecycle
+-- Created: 2026-10-18
+-- Description: Version relationships for optimistic concurrency and store the end or reconnect request waiting for confirmation
+
+ALTER TABLE `Relationship`
+  ADD COLUMN `version` bigint NOT NULL DEFAULT 1 COMMENT 'Incremented on every update, stale writes are rejected' AFTER `visibility`,
+  ADD COLUMN `pending_request` ENUM ('end', 'reconnect') NULL COMMENT 'Asked for by one partner, waiting for confirmation' AFTER `ended_at`,
+  ADD COLUMN `pending_requested_by` binary(16) NULL AFTER `pending_request`,
+  ADD COLUMN `pending_requested_at` timestamp NULL AFTER `pending_requested_by`,
+  ADD CONSTRAINT `chk_relationship_pending_request` CHECK (
+    (`pending_request` IS NULL AND `pending_requested_by` IS NULL AND `pending_requested_at` IS NULL) OR
+    (`pending_request` IS NOT NULL AND `pending_requested_by` IS NOT NULL AND `pending_requested_at` IS NOT NULL)
+  );
+
+ALTER TABLE `Relationship` ADD CONSTRAINT `fk_relationship_pending_requested_by` FOREIGN KEY (`pending_requested_by`) REFERENCES `Users` (`id`);
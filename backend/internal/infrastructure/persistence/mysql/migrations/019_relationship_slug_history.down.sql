@@ -0,0 +1,5 @@
+-- Migration: Relationship slug history (down)
+-- Created: 2026-10-18
+-- Description: Drop the slug history, current slugs stay on Relationship
+
+DROP TABLE `RelationshipSlugs`;
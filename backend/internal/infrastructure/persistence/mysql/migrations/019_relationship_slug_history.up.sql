@@ -0,0 +1,17 @@
+-- Migration: Relationship slug history
+-- Created: 2026-10-18
+-- Description: Keep every slug a relationship used, so old links redirect and no other couple can take them over
+
+CREATE TABLE `RelationshipSlugs` (
+  `slug` varchar(255) PRIMARY KEY NOT NULL,
+  `relationship_id` binary(16) NOT NULL,
+  `created_at` timestamp NOT NULL DEFAULT (now()),
+  `retired_at` timestamp NULL COMMENT 'Set once replaced, the current slug has none'
+);
+
+CREATE INDEX `idx_relationship_slugs_relationship` ON `RelationshipSlugs` (`relationship_id`);
+ALTER TABLE `RelationshipSlugs` ADD CONSTRAINT `fk_relationship_slugs_relationship` FOREIGN KEY (`relationship_id`) REFERENCES `Relationship` (`id`);
+
+-- Slugs set before the history existed are current
+INSERT INTO `RelationshipSlugs` (`slug`, `relationship_id`, `created_at`)
+SELECT LOWER(`slug`), `id`, `updated_at` FROM `Relationship` WHERE `slug` IS NOT NULL;
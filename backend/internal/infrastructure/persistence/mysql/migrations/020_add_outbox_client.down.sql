@@ -0,0 +1,7 @@
+-- Migration: Add the client of the raising request to the outbox (down)
+-- Created: 2026-10-18
+-- Description: Drop client columns from Outbox
+
+ALTER TABLE `Outbox`
+  DROP COLUMN `ip_address`,
+  DROP COLUMN `user_agent`;
@@ -0,0 +1,7 @@
+-- Migration: Add the client of the raising request to the outbox
+-- Created: 2026-10-18
+-- Description: IP address and user agent of the request that raised an event, so handlers running after it can still log them
+
+ALTER TABLE `Outbox`
+  ADD COLUMN `ip_address` varchar(45) COMMENT 'Client IP of the request that raised the event' AFTER `causation_id`,
+  ADD COLUMN `user_agent` varchar(512) COMMENT 'Client user agent of the request that raised the event' AFTER `ip_address`;
@@ -0,0 +1,5 @@
+-- Migration: Add device trust tokens (down)
+-- Created: 2026-10-18
+-- Description: Drop the trust token column from Devices
+
+ALTER TABLE `Devices` DROP COLUMN `trust_token_hash`;
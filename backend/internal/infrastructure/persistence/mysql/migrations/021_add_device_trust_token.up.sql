@@ -0,0 +1,9 @@
+-- Migration: Add device trust tokens
+-- Created: 2026-10-18
+-- Description: Trusted devices skip the MFA step only with the random token handed out when they were trusted
+
+ALTER TABLE `Devices`
+  ADD COLUMN `trust_token_hash` char(64) COMMENT 'SHA-256 of the token the trusted client presents' AFTER `trusted_until`;
+
+-- The fingerprint alone no longer vouches for a device, earlier trust has no token to check
+UPDATE `Devices` SET `trusted_until` = NULL;
@@ -0,0 +1,11 @@
+-- Migration: Single-use MFA step (down)
+-- Created: 2026-10-18
+-- Description: Drop MFA challenges, the last TOTP step and failed MFA entries
+
+DELETE FROM `SecurityLog` WHERE `event_type` = 'mfa_failed';
+ALTER TABLE `SecurityLog`
+  MODIFY `event_type` ENUM ('login_succeeded', 'login_failed', 'mfa_enabled', 'mfa_disabled', 'password_changed', 'session_revoked') NOT NULL;
+
+ALTER TABLE `Credentials` DROP COLUMN `mfa_last_step`;
+
+DROP TABLE `MFAChallenges`;
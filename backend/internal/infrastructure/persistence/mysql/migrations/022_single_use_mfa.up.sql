@@ -0,0 +1,21 @@
+-- Migration: Single-use MFA step
+-- Created: 2026-10-18
+-- Description: MFA challenges answered once, the last accepted TOTP step and failed MFA entries in the security log
+
+CREATE TABLE `MFAChallenges` (
+  `id` binary(16) PRIMARY KEY NOT NULL COMMENT 'ID of the MFA token handed out for the challenge',
+  `user_id` binary(16) NOT NULL,
+  `fingerprint` char(64) NOT NULL COMMENT 'Device that passed the password step',
+  `failed_attempts` int NOT NULL DEFAULT 0,
+  `expires_at` timestamp NOT NULL,
+  `completed_at` timestamp NULL
+);
+
+ALTER TABLE `MFAChallenges` ADD CONSTRAINT `fk_mfa_challenges_user` FOREIGN KEY (`user_id`) REFERENCES `Users` (`id`) ON DELETE CASCADE;
+CREATE INDEX `idx_mfa_challenges_expires` ON `MFAChallenges` (`expires_at`);
+
+ALTER TABLE `Credentials`
+  ADD COLUMN `mfa_last_step` bigint NOT NULL DEFAULT 0 COMMENT 'TOTP time step of the last accepted code' AFTER `mfa_secret`;
+
+ALTER TABLE `SecurityLog`
+  MODIFY `event_type` ENUM ('login_succeeded', 'login_failed', 'mfa_failed', 'mfa_enabled', 'mfa_disabled', 'password_changed', 'session_revoked') NOT NULL;
@@ -0,0 +1,5 @@
+-- Migration: Relationship retention (down)
+-- Created: 2026-10-18
+-- Description: Drop the index of the retention sweep
+
+DROP INDEX `idx_relationship_retention` ON `Relationship`;
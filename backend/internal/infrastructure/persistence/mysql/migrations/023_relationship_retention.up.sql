@@ -0,0 +1,5 @@
+-- Migration: Relationship retention
+-- Created: 2026-10-18
+-- Description: Index the ended relationships the retention sweep soft-deletes
+
+CREATE INDEX `idx_relationship_retention` ON `Relationship` (`status`, `ended_at`);
@@ -0,0 +1,5 @@
+-- Migration: Create event deliveries (down)
+-- Created: 2026-10-18
+-- Description: Drop event deliveries
+
+DROP TABLE `EventDeliveries`;
@@ -0,0 +1,10 @@
+-- Migration: Create event deliveries
+-- Created: 2026-10-18
+-- Description: Handlers that handled an event, so a redelivered event only reaches the ones that failed
+
+CREATE TABLE `EventDeliveries` (
+  `event_id` binary(16) NOT NULL,
+  `handler` varchar(191) NOT NULL COMMENT 'Go type of the handler',
+  `handled_at` timestamp(6) NOT NULL,
+  PRIMARY KEY (`event_id`, `handler`)
+);
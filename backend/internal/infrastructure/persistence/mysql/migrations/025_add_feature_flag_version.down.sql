@@ -0,0 +1,5 @@
+-- Migration: Add version to feature flags (down)
+-- Created: 2026-10-18
+-- Description: Drop version from FeatureFlags
+
+ALTER TABLE `FeatureFlags` DROP COLUMN `version`;
@@ -0,0 +1,6 @@
+-- Migration: Add version to feature flags
+-- Created: 2026-10-18
+-- Description: Version checked by administrators saving a flag with If-Match
+
+ALTER TABLE `FeatureFlags`
+  ADD COLUMN `version` bigint NOT NULL DEFAULT 1 COMMENT 'Incremented on every save, stale saves are rejected' AFTER `required_entitlement`;
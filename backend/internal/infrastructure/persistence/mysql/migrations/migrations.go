@@ -0,0 +1,8 @@
+// Package migrations embeds the versioned MySQL schema scripts. Every version has
+// a NNN_name.up.sql script and a NNN_name.down.sql script reverting it.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
@@ -8,3 +8,10 @@ const (
 	Other          Gender = "something_else"
 	PreferNotToSay Gender = "prefer_not_to_say"
 )
+
+type Role string
+
+const (
+	Member Role = "member"
+	Admin  Role = "admin"
+)
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type FeatureFlag struct {
+	Key                 string          `gorm:"column:key;size:100;primaryKey" json:"key"`
+	Description         *string         `gorm:"column:description;size:255" json:"description,omitempty"`
+	Enabled             bool            `gorm:"column:enabled;not null;default:false" json:"enabled"`
+	Environments        json.RawMessage `gorm:"column:environments;type:json" json:"environments,omitempty"`
+	UserIDs             json.RawMessage `gorm:"column:user_ids;type:json" json:"user_ids,omitempty"`
+	RelationshipIDs     json.RawMessage `gorm:"column:relationship_ids;type:json" json:"relationship_ids,omitempty"`
+	RolloutPercentage   int             `gorm:"column:rollout_percentage;not null;default:0" json:"rollout_percentage"`
+	RequiredEntitlement *string         `gorm:"column:required_entitlement;size:100" json:"required_entitlement,omitempty"`
+	Version             int64           `gorm:"column:version;not null;default:1" json:"version"`
+	CreatedAt           time.Time       `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt           time.Time       `gorm:"column:updated_at" json:"updated_at"`
+}
+
+type UserPlan struct {
+	UserID    UUID       `gorm:"type:binary(16);primaryKey;column:user_id" json:"user_id"`
+	Plan      string     `gorm:"column:plan;type:enum('free','premium');not null;default:free" json:"plan"`
+	StartedAt time.Time  `gorm:"column:started_at;not null" json:"started_at"`
+	ExpiresAt *time.Time `gorm:"column:expires_at" json:"expires_at,omitempty"`
+}
+
+func (FeatureFlag) TableName() string { return "FeatureFlags" }
+func (UserPlan) TableName() string    { return "UserPlans" }
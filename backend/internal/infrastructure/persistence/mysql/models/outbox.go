@@ -0,0 +1,41 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type OutboxMessage struct {
+	ID            uint64          `gorm:"primaryKey;autoIncrement;column:id" json:"id"`
+	EventID       UUID            `gorm:"column:event_id;type:binary(16);not null;uniqueIndex" json:"event_id"`
+	EventType     string          `gorm:"column:event_type;size:100;not null" json:"event_type"`
+	SchemaVersion int             `gorm:"column:schema_version;not null;default:1" json:"schema_version"`
+	AggregateType string          `gorm:"column:aggregate_type;size:50;not null" json:"aggregate_type"`
+	AggregateID   *string         `gorm:"column:aggregate_id;size:64" json:"aggregate_id,omitempty"`
+	ActorID       *UUID           `gorm:"column:actor_id;type:binary(16)" json:"actor_id,omitempty"`
+	CorrelationID *string         `gorm:"column:correlation_id;size:255;index:idx_outbox_correlation" json:"correlation_id,omitempty"`
+	CausationID   *UUID           `gorm:"column:causation_id;type:binary(16)" json:"causation_id,omitempty"`
+	IPAddress     *string         `gorm:"column:ip_address;size:45" json:"ip_address,omitempty"`
+	UserAgent     *string         `gorm:"column:user_agent;size:512" json:"user_agent,omitempty"`
+	Payload       json.RawMessage `gorm:"column:payload;type:json;not null" json:"payload"`
+	OccurredAt    time.Time       `gorm:"column:occurred_at;type:timestamp(6);not null" json:"occurred_at"`
+	Status        string          `gorm:"column:status;type:enum('pending','published','dead');not null;default:pending;index:idx_outbox_due" json:"status"`
+	Attempts      int             `gorm:"column:attempts;not null;default:0" json:"attempts"`
+	NextAttemptAt time.Time       `gorm:"column:next_attempt_at;type:timestamp(6);not null;index:idx_outbox_due" json:"next_attempt_at"`
+	LastError     *string         `gorm:"column:last_error;size:1024" json:"last_error,omitempty"`
+	LockedUntil   *time.Time      `gorm:"column:locked_until;type:timestamp(6)" json:"-"`
+	LockToken     *string         `gorm:"type:char(36);column:lock_token;index" json:"-"`
+	PublishedAt   *time.Time      `gorm:"column:published_at;type:timestamp(6)" json:"published_at,omitempty"`
+	CreatedAt     time.Time       `gorm:"column:created_at;type:timestamp(6);not null" json:"created_at"`
+}
+
+func (OutboxMessage) TableName() string { return "Outbox" }
+
+// EventDelivery records a handler that handled an event, redeliveries skip it
+type EventDelivery struct {
+	EventID   UUID      `gorm:"column:event_id;type:binary(16);primaryKey" json:"event_id"`
+	Handler   string    `gorm:"column:handler;size:191;primaryKey" json:"handler"`
+	HandledAt time.Time `gorm:"column:handled_at;type:timestamp(6);not null" json:"handled_at"`
+}
+
+func (EventDelivery) TableName() string { return "EventDeliveries" }
@@ -0,0 +1,68 @@
+package models
+
+import "time"
+
+type InviteStatus string
+
+const (
+	InviteSent      InviteStatus = "sent"
+	InviteAccepted  InviteStatus = "accepted"
+	InviteDeclined  InviteStatus = "declined"
+	InviteExpired   InviteStatus = "expired"
+	InviteCancelled InviteStatus = "cancelled"
+)
+
+type RelationshipStatus string
+
+const (
+	RelationshipActive RelationshipStatus = "active"
+	RelationshipPaused RelationshipStatus = "paused"
+	RelationshipEnded  RelationshipStatus = "ended"
+)
+
+type RelationshipInvite struct {
+	ID          UUID         `gorm:"type:binary(16);primaryKey;column:id" json:"id"`
+	InviterID   UUID         `gorm:"type:binary(16);not null;column:inviter_id;index:idx_invites_inviter_status" json:"inviter_id"`
+	InviteeID   *UUID        `gorm:"type:binary(16);column:invitee_id;index:idx_invites_invitee_status" json:"invitee_id,omitempty"`
+	Status      InviteStatus `gorm:"column:status;type:enum('sent','accepted','declined','expired','cancelled');not null;default:sent;index:idx_invites_inviter_status;index:idx_invites_invitee_status" json:"status"`
+	Message     *string      `gorm:"column:message;size:255" json:"message,omitempty"`
+	CodeHash    *string      `gorm:"column:code_hash;type:char(64);uniqueIndex:idx_invites_code_hash" json:"-"`
+	CreatedAt   time.Time    `gorm:"column:created_at;not null" json:"created_at"`
+	ExpiresAt   time.Time    `gorm:"column:expires_at;not null" json:"expires_at"`
+	RespondedAt *time.Time   `gorm:"column:responded_at" json:"responded_at,omitempty"`
+}
+
+type Relationship struct {
+	ID                 UUID               `gorm:"type:binary(16);primaryKey;column:id" json:"id"`
+	PartnerAID         UUID               `gorm:"type:binary(16);not null;column:partner_a_id" json:"partner_a_id"`
+	PartnerBID         UUID               `gorm:"type:binary(16);not null;column:partner_b_id" json:"partner_b_id"`
+	Status             RelationshipStatus `gorm:"column:status;type:enum('active','paused','ended');not null;default:active" json:"status"`
+	Visibility         string             `gorm:"column:visibility;type:enum('private','friends','public');not null;default:private" json:"visibility"`
+	Version            int64              `gorm:"column:version;not null;default:1" json:"version"`
+	Title              *string            `gorm:"column:title;size:255" json:"title,omitempty"`
+	Slug               *string            `gorm:"column:slug;size:255;unique" json:"slug,omitempty"`
+	AnniversaryAt      *time.Time         `gorm:"column:anniversary_at;type:date" json:"anniversary_at,omitempty"`
+	AvatarPhotoID      *UUID              `gorm:"type:binary(16);column:avatar_photo_id" json:"avatar_photo_id,omitempty"`
+	BannerPhotoID      *UUID              `gorm:"type:binary(16);column:banner_photo_id" json:"banner_photo_id,omitempty"`
+	StartedAt          time.Time          `gorm:"column:started_at;not null" json:"started_at"`
+	EndedAt            *time.Time         `gorm:"column:ended_at" json:"ended_at,omitempty"`
+	PendingRequest     *string            `gorm:"column:pending_request;type:enum('end','reconnect')" json:"pending_request,omitempty"`
+	PendingRequestedBy *UUID              `gorm:"type:binary(16);column:pending_requested_by" json:"pending_requested_by,omitempty"`
+	PendingRequestedAt *time.Time         `gorm:"column:pending_requested_at" json:"pending_requested_at,omitempty"`
+	CreatedBy          *UUID              `gorm:"type:binary(16);column:created_by" json:"created_by,omitempty"`
+	CreatedAt          time.Time          `gorm:"column:created_at;not null" json:"created_at"`
+	UpdatedAt          time.Time          `gorm:"column:updated_at;not null" json:"updated_at"`
+	DeletedAt          *time.Time         `gorm:"column:deleted_at" json:"deleted_at,omitempty"`
+}
+
+// RelationshipSlug is a slug a relationship uses, or used before it was replaced
+type RelationshipSlug struct {
+	Slug           string     `gorm:"column:slug;primaryKey;size:255" json:"slug"`
+	RelationshipID UUID       `gorm:"type:binary(16);not null;column:relationship_id;index:idx_relationship_slugs_relationship" json:"relationship_id"`
+	CreatedAt      time.Time  `gorm:"column:created_at;not null" json:"created_at"`
+	RetiredAt      *time.Time `gorm:"column:retired_at" json:"retired_at,omitempty"`
+}
+
+func (RelationshipInvite) TableName() string { return "RelationshipInvites" }
+func (Relationship) TableName() string       { return "Relationship" }
+func (RelationshipSlug) TableName() string   { return "RelationshipSlugs" }
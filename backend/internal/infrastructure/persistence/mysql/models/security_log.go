@@ -0,0 +1,21 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type SecurityLogEntry struct {
+	ID         UUID            `gorm:"type:binary(16);primaryKey;column:id" json:"id"`
+	UserID     UUID            `gorm:"type:binary(16);not null;column:user_id;index:idx_security_log_user_time" json:"user_id"`
+	EventType  string          `gorm:"column:event_type;not null" json:"event_type"`
+	IPAddress  *string         `gorm:"column:ip_address;size:45" json:"ip_address,omitempty"`
+	UserAgent  *string         `gorm:"column:user_agent;size:512" json:"user_agent,omitempty"`
+	Country    *string         `gorm:"column:country;type:char(2)" json:"country,omitempty"`
+	City       *string         `gorm:"column:city;size:255" json:"city,omitempty"`
+	RequestID  *string         `gorm:"column:request_id;size:255" json:"request_id,omitempty"`
+	Metadata   json.RawMessage `gorm:"column:metadata;type:json" json:"metadata,omitempty"`
+	OccurredAt time.Time       `gorm:"column:occurred_at;type:timestamp(6);not null;index:idx_security_log_user_time" json:"occurred_at"`
+}
+
+func (SecurityLogEntry) TableName() string { return "SecurityLog" }
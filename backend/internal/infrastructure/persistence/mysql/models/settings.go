@@ -0,0 +1,16 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type UserSettings struct {
+	UserID        UUID            `gorm:"type:binary(16);primaryKey;column:user_id" json:"user_id"`
+	SchemaVersion int             `gorm:"column:schema_version;not null;default:1" json:"schema_version"`
+	Data          json.RawMessage `gorm:"column:data;type:json;not null" json:"data"`
+	Revision      int64           `gorm:"column:revision;not null;default:0" json:"revision"`
+	UpdatedAt     time.Time       `gorm:"column:updated_at;not null" json:"updated_at"`
+}
+
+func (UserSettings) TableName() string { return "UserSettings" }
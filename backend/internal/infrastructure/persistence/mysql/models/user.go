@@ -3,7 +3,9 @@ package models
 import "time"
 
 type User struct {
-	ID        string    `gorm:"type:char(36);primaryKey;column:id;default:(uuid())" json:"id"`
+	ID        UUID      `gorm:"type:binary(16);primaryKey;column:id" json:"id"`
+	Role      Role      `gorm:"column:role;type:enum('member','admin');not null;default:member;index" json:"role"`
+	Version   int64     `gorm:"column:version;not null;default:1" json:"version"`
 	CreatedAt time.Time `gorm:"column:created_at" json:"created_at"`
 	UpdatedAt time.Time `gorm:"column:updated_at" json:"updated_at"`
 
@@ -13,52 +15,77 @@ type User struct {
 }
 
 type Credentials struct {
-	UserID string `gorm:"type:char(36);primaryKey;column:user_id"`
-	User   *User  `gorm:"foreignKey:UserID;references:ID" json:"-"`
+	UserID UUID  `gorm:"type:binary(16);primaryKey;column:user_id"`
+	User   *User `gorm:"foreignKey:UserID;references:ID" json:"-"`
 
 	Email         string     `gorm:"column:email;size:320;unique;not null;index" json:"email"`
-	Password      string     `gorm:"column:password;size:255;not null;->:false;<-:create" json:"-"`
+	Password      string     `gorm:"column:password;size:255;not null" json:"-"`
 	Username      string     `gorm:"column:username;size:50;unique;not null;index" json:"username"`
 	EmailVerified bool       `gorm:"column:email_verified;not null;default:false" json:"email_verified"`
 	MfaEnabled    bool       `gorm:"column:mfa_enabled;not null;default:false" json:"mfa_enabled"`
 	MfaSecret     *string    `gorm:"column:mfa_secret;size:64" json:"mfa_secret,omitempty"`
+	MfaLastStep   int64      `gorm:"column:mfa_last_step;not null;default:0" json:"-"`
 	LastLoginAt   *time.Time `gorm:"column:last_login_at" json:"last_login_at,omitempty"`
 }
 
 type Profile struct {
-	UserID string `gorm:"type:char(36);primaryKey;column:user_id"`
-	User   *User  `gorm:"foreignKey:UserID;references:ID"`
+	UserID UUID  `gorm:"type:binary(16);primaryKey;column:user_id"`
+	User   *User `gorm:"foreignKey:UserID;references:ID"`
 
 	FirstName      string     `gorm:"column:first_name;size:100;not null" json:"first_name"`
 	LastName       string     `gorm:"column:last_name;size:100;not null" json:"last_name"`
-	Gender         Gender     `gorm:"type:genders;not null;default:prefer_not_to_say" json:"gender"`
+	Gender         Gender     `gorm:"column:gender;type:enum('female','male','something_else','prefer_not_to_say');not null;default:prefer_not_to_say" json:"gender"`
 	DateOfBirth    *time.Time `gorm:"column:date_of_birth;type:date" json:"date_of_birth,omitempty"`
 	Bio            *string    `gorm:"column:bio;size:500" json:"bio,omitempty"`
 	DisplayName    *string    `gorm:"column:display_name;size:100;index" json:"display_name,omitempty"`
 	AvatarPhotoID  *string    `gorm:"column:avatar_photo_id;type:char(36)" json:"avatar_photo_id,omitempty"`
-	RelationshipID *string    `gorm:"column:relationship_id;type:char(36);index" json:"relationship_id,omitempty"`
+	RelationshipID *UUID      `gorm:"column:relationship_id;type:binary(16);index" json:"relationship_id,omitempty"`
 	Locale         string     `gorm:"column:locale;size:10;not null;default:'en'" json:"locale"`
 	Timezone       string     `gorm:"column:timezone;size:50;not null;default:'UTC'" json:"timezone"`
 }
+
 type Session struct {
-	ID           string     `gorm:"type:char(36);primaryKey;column:id;default:(uuid())" json:"id"`
-	UserID       string     `gorm:"type:char(36);not null;column:user_id;index:idx_sessions_user_active;index:idx_sessions_user_revoked" json:"user_id"`
+	ID           UUID       `gorm:"type:binary(16);primaryKey;column:id" json:"id"`
+	UserID       UUID       `gorm:"type:binary(16);not null;column:user_id;index:idx_sessions_user_active;index:idx_sessions_user_revoked" json:"user_id"`
+	DeviceID     *UUID      `gorm:"type:binary(16);column:device_id" json:"device_id,omitempty"`
 	UserAgent    *string    `gorm:"column:user_agent;size:512" json:"user_agent,omitempty"`
 	IPAddress    *string    `gorm:"column:ip_address;size:45;index" json:"ip_address,omitempty"`
 	CreatedAt    time.Time  `gorm:"column:created_at;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
 	LastUsedAt   time.Time  `gorm:"column:last_used_at;not null;default:CURRENT_TIMESTAMP;index:idx_sessions_user_active;index:idx_sessions_cleanup" json:"last_used_at"`
 	RevokedAt    *time.Time `gorm:"column:revoked_at;index:idx_sessions_user_revoked" json:"revoked_at,omitempty"`
 	TokenVersion int        `gorm:"column:token_version;not null;default:0" json:"token_version"`
+	RevokeHash   *string    `gorm:"column:revoke_token_hash;type:char(64);uniqueIndex" json:"-"`
 
 	User          User           `gorm:"foreignKey:UserID;references:ID"`
 	RefreshTokens []RefreshToken `gorm:"foreignKey:SessionID;references:ID"`
 }
 
+type Device struct {
+	ID              UUID       `gorm:"type:binary(16);primaryKey;column:id" json:"id"`
+	UserID          UUID       `gorm:"type:binary(16);not null;column:user_id;uniqueIndex:idx_devices_user_fingerprint" json:"user_id"`
+	Fingerprint     string     `gorm:"type:char(64);not null;column:fingerprint;uniqueIndex:idx_devices_user_fingerprint" json:"-"`
+	UserAgentFamily string     `gorm:"column:user_agent_family;size:255;not null" json:"user_agent_family"`
+	IPBlock         string     `gorm:"column:ip_block;size:64;not null" json:"ip_block"`
+	FirstSeenAt     time.Time  `gorm:"column:first_seen_at;not null" json:"first_seen_at"`
+	LastSeenAt      time.Time  `gorm:"column:last_seen_at;not null" json:"last_seen_at"`
+	TrustedUntil    *time.Time `gorm:"column:trusted_until" json:"trusted_until,omitempty"`
+	TrustTokenHash  *string    `gorm:"column:trust_token_hash;type:char(64)" json:"-"`
+}
+
+type MFAChallenge struct {
+	ID             UUID       `gorm:"type:binary(16);primaryKey;column:id" json:"id"`
+	UserID         UUID       `gorm:"type:binary(16);not null;column:user_id" json:"user_id"`
+	Fingerprint    string     `gorm:"type:char(64);not null;column:fingerprint" json:"-"`
+	FailedAttempts int        `gorm:"column:failed_attempts;not null;default:0" json:"failed_attempts"`
+	ExpiresAt      time.Time  `gorm:"column:expires_at;not null;index:idx_mfa_challenges_expires" json:"expires_at"`
+	CompletedAt    *time.Time `gorm:"column:completed_at" json:"completed_at,omitempty"`
+}
+
 type RefreshToken struct {
-	ID        string     `gorm:"type:char(36);primaryKey;column:id;default:(uuid())" json:"id"`
-	SessionID string     `gorm:"type:char(36);not null;column:session_id" json:"session_id"`
-	JTI       string     `gorm:"type:char(36);not null;unique;column:jti;default:(uuid())" json:"jti"`
-	FamilyID  string     `gorm:"type:char(36);not null;column:family_id;default:(uuid())" json:"family_id"`
+	ID        UUID       `gorm:"type:binary(16);primaryKey;column:id" json:"id"`
+	SessionID UUID       `gorm:"type:binary(16);not null;column:session_id" json:"session_id"`
+	JTI       string     `gorm:"type:char(36);not null;unique;column:jti" json:"jti"`
+	FamilyID  UUID       `gorm:"type:binary(16);not null;column:family_id" json:"family_id"`
 	TokenHash string     `gorm:"column:token_hash;size:255;not null;index" json:"-"`
 	ExpiresAt time.Time  `gorm:"column:expires_at;not null" json:"expires_at"`
 	CreatedAt time.Time  `gorm:"column:created_at;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
@@ -68,3 +95,12 @@ type RefreshToken struct {
 
 	Session Session `gorm:"foreignKey:SessionID;references:ID;constraint:OnDelete:CASCADE" json:"-"`
 }
+
+// Table names match the migrations instead of the GORM pluralized defaults
+func (User) TableName() string         { return "Users" }
+func (Credentials) TableName() string  { return "Credentials" }
+func (Profile) TableName() string      { return "Profile" }
+func (Session) TableName() string      { return "Sessions" }
+func (Device) TableName() string       { return "Devices" }
+func (MFAChallenge) TableName() string { return "MFAChallenges" }
+func (RefreshToken) TableName() string { return "Refresh_Tokens" }
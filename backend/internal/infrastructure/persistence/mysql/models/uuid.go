@@ -0,0 +1,57 @@
+package models
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidUUID = errors.New("invalid UUID")
+
+// UUID is a canonical UUID string stored as BINARY(16). UUIDv7 values keep
+// their time order in binary form, so inserts append to the end of indexes.
+type UUID string
+
+func (u UUID) Value() (driver.Value, error) {
+	parsed, err := uuid.Parse(string(u))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidUUID, string(u))
+	}
+	return parsed[:], nil
+}
+
+func (u *UUID) Scan(src interface{}) error {
+	switch value := src.(type) {
+	case nil:
+		*u = ""
+		return nil
+	case []byte:
+		parsed, err := uuid.FromBytes(value)
+		if err != nil {
+			return fmt.Errorf("%w: %d bytes", ErrInvalidUUID, len(value))
+		}
+		*u = UUID(parsed.String())
+		return nil
+	default:
+		return fmt.Errorf("%w: unsupported type %T", ErrInvalidUUID, src)
+	}
+}
+
+// NullableUUID stores empty IDs as NULL
+func NullableUUID(value string) *UUID {
+	if value == "" {
+		return nil
+	}
+	id := UUID(value)
+	return &id
+}
+
+// UUIDValue returns the ID or an empty string for NULL
+func UUIDValue(value *UUID) string {
+	if value == nil {
+		return ""
+	}
+	return string(*value)
+}
@@ -0,0 +1,130 @@
+// Package mysqltest provides a migrated throwaway MySQL database, so the MySQL
+// repositories can be exercised against the real engine. It runs a Docker
+// container unless MYSQL_TEST_DSN names an empty database to use instead.
+package mysqltest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/persistence/mysql/migrate"
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/persistence/mysql/migrations"
+)
+
+const (
+	image    = "mysql:8.4"
+	password = "amora"
+	database = "amora_test"
+
+	// startTimeout covers pulling the image and the first boot of the server
+	startTimeout = 3 * time.Minute
+)
+
+// ErrUnavailable is returned when there is neither MYSQL_TEST_DSN nor Docker, tests skip on it
+var ErrUnavailable = errors.New("no MySQL available, set MYSQL_TEST_DSN or install Docker")
+
+// Server is a migrated database, dropped with its container on Shutdown
+type Server struct {
+	// DSN connects in UTC like the application does
+	DSN string
+
+	containerID string
+}
+
+// Start migrates the database in MYSQL_TEST_DSN, or a fresh container when it is unset
+func Start(ctx context.Context) (*Server, error) {
+	srv := &Server{DSN: os.Getenv("MYSQL_TEST_DSN")}
+	if srv.DSN == "" {
+		if err := srv.runContainer(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, startTimeout)
+	defer cancel()
+
+	if err := srv.migrate(ctx); err != nil {
+		srv.Shutdown()
+		return nil, err
+	}
+
+	return srv, nil
+}
+
+// Shutdown removes the container, a database from MYSQL_TEST_DSN is left as it is
+func (s *Server) Shutdown() {
+	if s.containerID != "" {
+		exec.Command("docker", "rm", "--force", "--volumes", s.containerID).Run()
+	}
+}
+
+func (s *Server) runContainer(ctx context.Context) error {
+	if _, err := exec.LookPath("docker"); err != nil {
+		return ErrUnavailable
+	}
+	if err := exec.CommandContext(ctx, "docker", "info").Run(); err != nil {
+		return ErrUnavailable
+	}
+
+	output, err := exec.CommandContext(ctx, "docker", "run", "--detach", "--rm",
+		"--env", "MYSQL_ROOT_PASSWORD="+password,
+		"--env", "MYSQL_DATABASE="+database,
+		"--publish", "127.0.0.1::3306",
+		image,
+	).Output()
+	if err != nil {
+		return fmt.Errorf("failed to start MySQL container: %w", err)
+	}
+	s.containerID = strings.TrimSpace(string(output))
+
+	// The port is picked by Docker, e.g. "127.0.0.1:49153"
+	output, err = exec.CommandContext(ctx, "docker", "port", s.containerID, "3306/tcp").Output()
+	if err != nil {
+		s.Shutdown()
+		return fmt.Errorf("failed to read MySQL container port: %w", err)
+	}
+	address := strings.TrimSpace(strings.SplitN(string(output), "\n", 2)[0])
+
+	s.DSN = fmt.Sprintf("root:%s@tcp(%s)/%s?charset=utf8mb4&parseTime=True&loc=UTC&time_zone=%s",
+		password, address, database, url.QueryEscape("'+00:00'"))
+	return nil
+}
+
+// migrate waits for the server to accept connections and applies every migration
+func (s *Server) migrate(ctx context.Context) error {
+	db, err := migrate.Open(s.DSN)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	for {
+		err := db.PingContext(ctx)
+		if err == nil {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("MySQL did not start: %w", err)
+		case <-time.After(time.Second):
+		}
+	}
+
+	migrator, err := migrate.NewMigrator(db, migrations.FS, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		return err
+	}
+	if _, err := migrator.Up(ctx, 0); err != nil {
+		return fmt.Errorf("failed to apply migrations: %w", err)
+	}
+
+	return nil
+}
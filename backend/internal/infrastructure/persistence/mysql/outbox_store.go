@@ -0,0 +1,158 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/application/events"
+	"github.com/StefanPenchev05/Amora/backend/internal/application/outbox"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/user"
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/persistence/mysql/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/plugin/dbresolver"
+)
+
+// OutboxStore keeps outbox messages in the Outbox table
+type OutboxStore struct {
+	db *gorm.DB
+}
+
+func NewOutboxStore(db *gorm.DB) outbox.Store {
+	return &OutboxStore{db: db}
+}
+
+// Append joins the unit of work in ctx, so events published from one commit with its writes
+func (s *OutboxStore) Append(ctx context.Context, messages ...outbox.Message) error {
+	return insertOutbox(conn(ctx, s.db), messages)
+}
+
+func (s *OutboxStore) Claim(ctx context.Context, limit int, lease time.Duration) ([]outbox.Message, error) {
+	now := time.Now().UTC()
+	token := uuid.NewString()
+
+	// Lease due messages with a single update so concurrent relays never claim the same rows
+	err := s.db.WithContext(ctx).Exec(
+		"UPDATE `Outbox` SET `lock_token` = ?, `locked_until` = ? "+
+			"WHERE `status` = ? AND `next_attempt_at` <= ? AND (`locked_until` IS NULL OR `locked_until` < ?) "+
+			"ORDER BY `id` LIMIT ?",
+		token, now.Add(lease), outbox.StatusPending, now, now, limit,
+	).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim outbox messages: %w", err)
+	}
+
+	var records []models.OutboxMessage
+	// Read the claim back from the primary, a replica may not have seen the update yet
+	if err := s.db.WithContext(ctx).Clauses(dbresolver.Write).Where("lock_token = ?", token).Order("id").Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("failed to load claimed outbox messages: %w", err)
+	}
+
+	messages := make([]outbox.Message, 0, len(records))
+	for _, record := range records {
+		messages = append(messages, outbox.Message{
+			Envelope: events.Envelope{
+				EventID:       string(record.EventID),
+				EventType:     record.EventType,
+				SchemaVersion: record.SchemaVersion,
+				AggregateType: record.AggregateType,
+				AggregateID:   valueOf(record.AggregateID),
+				ActorID:       models.UUIDValue(record.ActorID),
+				CorrelationID: valueOf(record.CorrelationID),
+				CausationID:   models.UUIDValue(record.CausationID),
+				IPAddress:     valueOf(record.IPAddress),
+				UserAgent:     valueOf(record.UserAgent),
+				OccurredAt:    record.OccurredAt,
+				Payload:       record.Payload,
+			},
+			Status:        outbox.Status(record.Status),
+			Attempts:      record.Attempts,
+			NextAttemptAt: record.NextAttemptAt,
+			LastError:     valueOf(record.LastError),
+		})
+	}
+
+	return messages, nil
+}
+
+func (s *OutboxStore) MarkPublished(ctx context.Context, eventID string) error {
+	err := s.db.WithContext(ctx).Model(&models.OutboxMessage{}).Where("event_id = ?", models.UUID(eventID)).Updates(map[string]interface{}{
+		"status":       outbox.StatusPublished,
+		"published_at": time.Now().UTC(),
+		"lock_token":   nil,
+		"locked_until": nil,
+	}).Error
+	if err != nil {
+		return fmt.Errorf("failed to mark outbox message published: %w", err)
+	}
+
+	return nil
+}
+
+func (s *OutboxStore) MarkFailed(ctx context.Context, message outbox.Message) error {
+	err := s.db.WithContext(ctx).Model(&models.OutboxMessage{}).Where("event_id = ?", models.UUID(message.EventID)).Updates(map[string]interface{}{
+		"status":          message.Status,
+		"attempts":        message.Attempts,
+		"next_attempt_at": message.NextAttemptAt.UTC(),
+		"last_error":      nullable(message.LastError),
+		"lock_token":      nil,
+		"locked_until":    nil,
+	}).Error
+	if err != nil {
+		return fmt.Errorf("failed to record outbox failure: %w", err)
+	}
+
+	return nil
+}
+
+// appendEvents writes the events raised by an aggregate, call it inside the
+// transaction saving the aggregate
+func appendEvents(ctx context.Context, tx *gorm.DB, domainEvents []user.DomainEvent) error {
+	if len(domainEvents) == 0 {
+		return nil
+	}
+
+	messages, err := outbox.NewMessages(ctx, domainEvents...)
+	if err != nil {
+		return err
+	}
+
+	return insertOutbox(tx, messages)
+}
+
+func insertOutbox(tx *gorm.DB, messages []outbox.Message) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	records := make([]models.OutboxMessage, 0, len(messages))
+	for _, message := range messages {
+		records = append(records, models.OutboxMessage{
+			EventID:       models.UUID(message.EventID),
+			EventType:     message.EventType,
+			SchemaVersion: message.SchemaVersion,
+			AggregateType: message.AggregateType,
+			AggregateID:   nullable(message.AggregateID),
+			ActorID:       models.NullableUUID(message.ActorID),
+			CorrelationID: nullable(message.CorrelationID),
+			CausationID:   models.NullableUUID(message.CausationID),
+			IPAddress:     nullable(message.IPAddress),
+			UserAgent:     nullable(message.UserAgent),
+			Payload:       message.Payload,
+			OccurredAt:    message.OccurredAt.UTC(),
+			Status:        string(outbox.StatusPending),
+			NextAttemptAt: message.NextAttemptAt.UTC(),
+			CreatedAt:     now,
+		})
+	}
+
+	// Appending an event twice is a no-op, the event ID is the idempotency key
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&records).Error; err != nil {
+		return fmt.Errorf("failed to append outbox messages: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,449 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/ids"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/relationship"
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/persistence/mysql/models"
+	"gorm.io/gorm"
+)
+
+// RelationshipRepository stores relationships and their pending domain events in one transaction
+type RelationshipRepository struct {
+	db *gorm.DB
+}
+
+func NewRelationshipRepository(db *gorm.DB) relationship.Repository {
+	return &RelationshipRepository{db: db}
+}
+
+func (r *RelationshipRepository) Create(ctx context.Context, rel *relationship.Relationship) error {
+	if rel.ID == "" {
+		rel.ID = ids.New()
+	}
+
+	record := toRelationshipModel(rel)
+	record.Version = 1
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&record).Error; err != nil {
+			return err
+		}
+		if err := r.moveSlug(tx, rel.ID, "", rel.Slug, rel.CreatedAt); err != nil {
+			return err
+		}
+		return appendEvents(ctx, tx, rel.GetEvents())
+	})
+	if errors.Is(err, relationship.ErrSlugTaken) {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create relationship: %w", err)
+	}
+
+	rel.Version = record.Version
+	return nil
+}
+
+func (r *RelationshipRepository) GetByID(ctx context.Context, id string) (*relationship.Relationship, error) {
+	var record models.Relationship
+	err := conn(ctx, r.db).Where("id = ?", models.UUID(id)).Take(&record).Error
+	if isNotFound(err) {
+		return nil, relationship.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load relationship: %w", err)
+	}
+
+	return toRelationshipDomain(record), nil
+}
+
+func (r *RelationshipRepository) GetBySlug(ctx context.Context, slug string) (*relationship.Relationship, error) {
+	var held models.RelationshipSlug
+	err := conn(ctx, r.db).Where("slug = ?", slug).Take(&held).Error
+	if isNotFound(err) {
+		return nil, relationship.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load slug: %w", err)
+	}
+
+	return r.GetByID(ctx, string(held.RelationshipID))
+}
+
+func (r *RelationshipRepository) TakenSlugs(ctx context.Context, relationshipID string, slugs []string) ([]string, error) {
+	if len(slugs) == 0 {
+		return nil, nil
+	}
+
+	var taken []string
+	err := conn(ctx, r.db).Model(&models.RelationshipSlug{}).
+		Where("slug IN ? AND relationship_id <> ?", slugs, models.UUID(relationshipID)).
+		Pluck("slug", &taken).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to check slugs: %w", err)
+	}
+
+	return taken, nil
+}
+
+func (r *RelationshipRepository) Update(ctx context.Context, rel *relationship.Relationship) error {
+	record := toRelationshipModel(rel)
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var previous models.Relationship
+		if err := tx.Select("slug").Where("id = ?", models.UUID(rel.ID)).Take(&previous).Error; err != nil {
+			if isNotFound(err) {
+				return relationship.ErrNotFound
+			}
+			return err
+		}
+
+		result := tx.Model(&models.Relationship{}).
+			Where("id = ? AND version = ?", models.UUID(rel.ID), rel.Version).
+			Updates(map[string]interface{}{
+				"status":               record.Status,
+				"visibility":           record.Visibility,
+				"title":                record.Title,
+				"slug":                 record.Slug,
+				"anniversary_at":       record.AnniversaryAt,
+				"avatar_photo_id":      record.AvatarPhotoID,
+				"banner_photo_id":      record.BannerPhotoID,
+				"version":              rel.Version + 1,
+				"ended_at":             record.EndedAt,
+				"pending_request":      record.PendingRequest,
+				"pending_requested_by": record.PendingRequestedBy,
+				"pending_requested_at": record.PendingRequestedAt,
+				"updated_at":           record.UpdatedAt,
+				"deleted_at":           record.DeletedAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return r.missingOrStale(tx, rel.ID)
+		}
+		if err := r.moveSlug(tx, rel.ID, valueOf(previous.Slug), rel.Slug, rel.UpdatedAt); err != nil {
+			return err
+		}
+		return appendEvents(ctx, tx, rel.GetEvents())
+	})
+	if isDuplicate(err) {
+		return relationship.ErrSlugTaken
+	}
+	if errors.Is(err, relationship.ErrNotFound) ||
+		errors.Is(err, relationship.ErrConcurrentModification) ||
+		errors.Is(err, relationship.ErrSlugTaken) {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update relationship: %w", err)
+	}
+
+	rel.Version++
+	return nil
+}
+
+// missingOrStale tells why an update matched no row
+func (r *RelationshipRepository) missingOrStale(tx *gorm.DB, id string) error {
+	var count int64
+	if err := tx.Model(&models.Relationship{}).Where("id = ?", models.UUID(id)).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return relationship.ErrNotFound
+	}
+	return relationship.ErrConcurrentModification
+}
+
+// moveSlug retires the previous slug and claims the current one. A relationship
+// may go back to a slug it used before, the slugs of others stay theirs.
+func (r *RelationshipRepository) moveSlug(tx *gorm.DB, relationshipID, previous, current string, now time.Time) error {
+	if previous == current {
+		return nil
+	}
+
+	if previous != "" {
+		err := tx.Model(&models.RelationshipSlug{}).
+			Where("slug = ? AND relationship_id = ?", previous, models.UUID(relationshipID)).
+			Update("retired_at", now).Error
+		if err != nil {
+			return err
+		}
+	}
+	if current == "" {
+		return nil
+	}
+
+	var held models.RelationshipSlug
+	err := tx.Where("slug = ?", current).Take(&held).Error
+	switch {
+	case isNotFound(err):
+		return tx.Create(&models.RelationshipSlug{
+			Slug:           current,
+			RelationshipID: models.UUID(relationshipID),
+			CreatedAt:      now,
+		}).Error
+	case err != nil:
+		return err
+	case string(held.RelationshipID) != relationshipID:
+		return relationship.ErrSlugTaken
+	default:
+		return tx.Model(&held).Update("retired_at", nil).Error
+	}
+}
+
+func (r *RelationshipRepository) ListByPartner(ctx context.Context, userID string) ([]*relationship.Relationship, error) {
+	// Each side of the OR is served by the index of its partner foreign key
+	var records []models.Relationship
+	err := conn(ctx, r.db).
+		Where("partner_a_id = ? AND deleted_at IS NULL", models.UUID(userID)).
+		Or("partner_b_id = ? AND deleted_at IS NULL", models.UUID(userID)).
+		Order("created_at DESC").
+		Find(&records).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list relationships: %w", err)
+	}
+
+	relationships := make([]*relationship.Relationship, 0, len(records))
+	for _, record := range records {
+		relationships = append(relationships, toRelationshipDomain(record))
+	}
+
+	return relationships, nil
+}
+
+func (r *RelationshipRepository) ListExpired(ctx context.Context, endedBefore time.Time, limit int) ([]*relationship.Relationship, error) {
+	var records []models.Relationship
+	err := conn(ctx, r.db).
+		Where("status = ? AND ended_at < ? AND deleted_at IS NULL", models.RelationshipEnded, endedBefore).
+		Order("ended_at").
+		Limit(limit).
+		Find(&records).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list expired relationships: %w", err)
+	}
+
+	relationships := make([]*relationship.Relationship, 0, len(records))
+	for _, record := range records {
+		relationships = append(relationships, toRelationshipDomain(record))
+	}
+
+	return relationships, nil
+}
+
+func toRelationshipModel(rel *relationship.Relationship) models.Relationship {
+	record := models.Relationship{
+		ID:            models.UUID(rel.ID),
+		PartnerAID:    models.UUID(rel.PartnerAID),
+		PartnerBID:    models.UUID(rel.PartnerBID),
+		Status:        models.RelationshipStatus(rel.Status),
+		Visibility:    string(rel.Visibility),
+		Title:         nullable(rel.Title),
+		Slug:          nullable(rel.Slug),
+		AnniversaryAt: rel.AnniversaryAt,
+		AvatarPhotoID: models.NullableUUID(rel.AvatarPhotoID),
+		BannerPhotoID: models.NullableUUID(rel.BannerPhotoID),
+		Version:       rel.Version,
+		StartedAt:     rel.StartedAt,
+		EndedAt:       rel.EndedAt,
+		CreatedBy:     models.NullableUUID(rel.CreatedBy),
+		CreatedAt:     rel.CreatedAt,
+		UpdatedAt:     rel.UpdatedAt,
+		DeletedAt:     rel.DeletedAt,
+	}
+	if rel.Pending != nil {
+		record.PendingRequest = nullable(string(rel.Pending.Kind))
+		record.PendingRequestedBy = models.NullableUUID(rel.Pending.RequestedBy)
+		record.PendingRequestedAt = &rel.Pending.RequestedAt
+	}
+
+	return record
+}
+
+func toRelationshipDomain(record models.Relationship) *relationship.Relationship {
+	rel := &relationship.Relationship{
+		ID:            string(record.ID),
+		PartnerAID:    string(record.PartnerAID),
+		PartnerBID:    string(record.PartnerBID),
+		Status:        relationship.Status(record.Status),
+		Visibility:    relationship.Visibility(record.Visibility),
+		Title:         valueOf(record.Title),
+		Slug:          valueOf(record.Slug),
+		AnniversaryAt: record.AnniversaryAt,
+		AvatarPhotoID: models.UUIDValue(record.AvatarPhotoID),
+		BannerPhotoID: models.UUIDValue(record.BannerPhotoID),
+		Version:       record.Version,
+		CreatedBy:     models.UUIDValue(record.CreatedBy),
+		StartedAt:     record.StartedAt,
+		EndedAt:       record.EndedAt,
+		CreatedAt:     record.CreatedAt,
+		UpdatedAt:     record.UpdatedAt,
+		DeletedAt:     record.DeletedAt,
+	}
+	if record.PendingRequest != nil && record.PendingRequestedAt != nil {
+		rel.Pending = &relationship.Request{
+			Kind:        relationship.RequestKind(*record.PendingRequest),
+			RequestedBy: models.UUIDValue(record.PendingRequestedBy),
+			RequestedAt: *record.PendingRequestedAt,
+		}
+	}
+	rel.ClearEvents()
+
+	return rel
+}
+
+// InviteRepository stores relationship invites and their pending domain events in one transaction
+type InviteRepository struct {
+	db *gorm.DB
+}
+
+func NewInviteRepository(db *gorm.DB) relationship.InviteRepository {
+	return &InviteRepository{db: db}
+}
+
+func (r *InviteRepository) Create(ctx context.Context, invite *relationship.Invite) error {
+	if invite.ID == "" {
+		invite.ID = ids.New()
+	}
+
+	record := toInviteModel(invite)
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&record).Error; err != nil {
+			return err
+		}
+		return appendEvents(ctx, tx, invite.GetEvents())
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create invite: %w", err)
+	}
+
+	return nil
+}
+
+func (r *InviteRepository) GetByID(ctx context.Context, id string) (*relationship.Invite, error) {
+	return r.findOne(ctx, "id = ?", models.UUID(id))
+}
+
+func (r *InviteRepository) GetByCodeHash(ctx context.Context, codeHash string) (*relationship.Invite, error) {
+	return r.findOne(ctx, "code_hash = ?", codeHash)
+}
+
+func (r *InviteRepository) findOne(ctx context.Context, query string, arg interface{}) (*relationship.Invite, error) {
+	var record models.RelationshipInvite
+	err := conn(ctx, r.db).Where(query, arg).Take(&record).Error
+	if isNotFound(err) {
+		return nil, relationship.ErrInviteNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load invite: %w", err)
+	}
+
+	return toInviteDomain(record), nil
+}
+
+func (r *InviteRepository) Update(ctx context.Context, invite *relationship.Invite) error {
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		// Only a pending invite can be answered, a racing answer or cancel matches no row
+		result := tx.Model(&models.RelationshipInvite{}).
+			Where("id = ? AND status = ?", models.UUID(invite.ID), models.InviteSent).
+			Updates(map[string]interface{}{
+				"invitee_id":   models.NullableUUID(invite.InviteeID),
+				"status":       models.InviteStatus(invite.Status),
+				"responded_at": invite.RespondedAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return r.missingOrAnswered(tx, invite.ID)
+		}
+		return appendEvents(ctx, tx, invite.GetEvents())
+	})
+	if errors.Is(err, relationship.ErrInviteNotFound) || errors.Is(err, relationship.ErrInviteNotPending) {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update invite: %w", err)
+	}
+
+	return nil
+}
+
+// missingOrAnswered tells why an update matched no row
+func (r *InviteRepository) missingOrAnswered(tx *gorm.DB, id string) error {
+	var count int64
+	if err := tx.Model(&models.RelationshipInvite{}).Where("id = ?", models.UUID(id)).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return relationship.ErrInviteNotFound
+	}
+	return relationship.ErrInviteNotPending
+}
+
+func (r *InviteRepository) ListPending(ctx context.Context, userID string, now time.Time) ([]*relationship.Invite, error) {
+	// Each side of the OR is served by its own status index
+	var records []models.RelationshipInvite
+	err := conn(ctx, r.db).
+		Where("inviter_id = ? AND status = ? AND expires_at > ?", models.UUID(userID), models.InviteSent, now).
+		Or("invitee_id = ? AND status = ? AND expires_at > ?", models.UUID(userID), models.InviteSent, now).
+		Order("created_at DESC").
+		Find(&records).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list invites: %w", err)
+	}
+
+	invites := make([]*relationship.Invite, 0, len(records))
+	for _, record := range records {
+		invites = append(invites, toInviteDomain(record))
+	}
+
+	return invites, nil
+}
+
+func (r *InviteRepository) HasPendingBetween(ctx context.Context, userID, otherUserID string, now time.Time) (bool, error) {
+	var count int64
+	err := conn(ctx, r.db).Model(&models.RelationshipInvite{}).
+		Where("inviter_id = ? AND invitee_id = ? AND status = ? AND expires_at > ?", models.UUID(userID), models.UUID(otherUserID), models.InviteSent, now).
+		Or("inviter_id = ? AND invitee_id = ? AND status = ? AND expires_at > ?", models.UUID(otherUserID), models.UUID(userID), models.InviteSent, now).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to check pending invites: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+func toInviteModel(invite *relationship.Invite) models.RelationshipInvite {
+	return models.RelationshipInvite{
+		ID:          models.UUID(invite.ID),
+		InviterID:   models.UUID(invite.InviterID),
+		InviteeID:   models.NullableUUID(invite.InviteeID),
+		Status:      models.InviteStatus(invite.Status),
+		Message:     nullable(invite.Message),
+		CodeHash:    nullable(invite.CodeHash),
+		CreatedAt:   invite.CreatedAt,
+		ExpiresAt:   invite.ExpiresAt,
+		RespondedAt: invite.RespondedAt,
+	}
+}
+
+func toInviteDomain(record models.RelationshipInvite) *relationship.Invite {
+	invite := &relationship.Invite{
+		ID:          string(record.ID),
+		InviterID:   string(record.InviterID),
+		InviteeID:   models.UUIDValue(record.InviteeID),
+		CodeHash:    valueOf(record.CodeHash),
+		Status:      relationship.InviteStatus(record.Status),
+		Message:     valueOf(record.Message),
+		CreatedAt:   record.CreatedAt,
+		ExpiresAt:   record.ExpiresAt,
+		RespondedAt: record.RespondedAt,
+	}
+	invite.ClearEvents()
+
+	return invite
+}
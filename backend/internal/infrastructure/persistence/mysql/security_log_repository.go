@@ -0,0 +1,94 @@
+package mysql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/ids"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/securitylog"
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/persistence/mysql/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SecurityLogRepository only ever inserts and reads, matching the append-only contract
+type SecurityLogRepository struct {
+	db *gorm.DB
+}
+
+func NewSecurityLogRepository(db *gorm.DB) securitylog.Repository {
+	return &SecurityLogRepository{db: db}
+}
+
+func (r *SecurityLogRepository) Append(ctx context.Context, entry *securitylog.Entry) error {
+	if entry.ID == "" {
+		entry.ID = ids.New()
+	}
+
+	var metadata json.RawMessage
+	if len(entry.Metadata) > 0 {
+		encoded, err := json.Marshal(entry.Metadata)
+		if err != nil {
+			return fmt.Errorf("failed to encode security log metadata: %w", err)
+		}
+		metadata = encoded
+	}
+
+	record := models.SecurityLogEntry{
+		ID:         models.UUID(entry.ID),
+		UserID:     models.UUID(entry.UserID),
+		EventType:  string(entry.EventType),
+		IPAddress:  nullable(entry.IPAddress),
+		UserAgent:  nullable(entry.UserAgent),
+		Country:    nullable(entry.Country),
+		City:       nullable(entry.City),
+		RequestID:  nullable(entry.RequestID),
+		Metadata:   metadata,
+		OccurredAt: entry.OccurredAt,
+	}
+	// Entries take the ID of their event, a redelivered event finds its row and is skipped
+	if err := conn(ctx, r.db).Clauses(clause.OnConflict{DoNothing: true}).Create(&record).Error; err != nil {
+		return fmt.Errorf("failed to append security log entry: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SecurityLogRepository) ListByUserID(ctx context.Context, userID string, before *securitylog.Cursor, limit int) ([]*securitylog.Entry, error) {
+	query := conn(ctx, r.db).Where("user_id = ?", models.UUID(userID))
+	if before != nil {
+		query = query.Where("occurred_at < ? OR (occurred_at = ? AND id < ?)",
+			before.OccurredAt, before.OccurredAt, models.UUID(before.ID))
+	}
+
+	var records []models.SecurityLogEntry
+	if err := query.Order("occurred_at DESC, id DESC").Limit(limit).Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("failed to list security log: %w", err)
+	}
+
+	entries := make([]*securitylog.Entry, 0, len(records))
+	for _, record := range records {
+		metadata := make(map[string]string)
+		if len(record.Metadata) > 0 {
+			if err := json.Unmarshal(record.Metadata, &metadata); err != nil {
+				return nil, fmt.Errorf("failed to decode security log metadata: %w", err)
+			}
+		}
+
+		entries = append(entries, &securitylog.Entry{
+			ID:         string(record.ID),
+			UserID:     string(record.UserID),
+			EventType:  securitylog.EventType(record.EventType),
+			IPAddress:  valueOf(record.IPAddress),
+			UserAgent:  valueOf(record.UserAgent),
+			RequestID:  valueOf(record.RequestID),
+			Country:    valueOf(record.Country),
+			City:       valueOf(record.City),
+			Metadata:   metadata,
+			OccurredAt: record.OccurredAt,
+		})
+	}
+
+	return entries, nil
+}
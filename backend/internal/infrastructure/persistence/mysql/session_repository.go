@@ -0,0 +1,117 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/ids"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/session"
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/persistence/mysql/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SessionRepository stores sessions and their pending domain events in one transaction
+type SessionRepository struct {
+	db *gorm.DB
+}
+
+func NewSessionRepository(db *gorm.DB) session.Repository {
+	return &SessionRepository{db: db}
+}
+
+func (r *SessionRepository) Create(ctx context.Context, s *session.Session) error {
+	if s.ID == "" {
+		s.ID = ids.New()
+	}
+
+	record := toSessionModel(s)
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(&record).Error; err != nil {
+			return err
+		}
+		return appendEvents(ctx, tx, s.GetEvents())
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SessionRepository) GetByID(ctx context.Context, id string) (*session.Session, error) {
+	return r.findOne(ctx, "id = ?", models.UUID(id))
+}
+
+func (r *SessionRepository) GetByRevokeTokenHash(ctx context.Context, tokenHash string) (*session.Session, error) {
+	return r.findOne(ctx, "revoke_token_hash = ?", tokenHash)
+}
+
+func (r *SessionRepository) Update(ctx context.Context, s *session.Session) error {
+	record := toSessionModel(s)
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Session{}).Where("id = ?", models.UUID(s.ID)).Updates(map[string]interface{}{
+			"last_used_at":  record.LastUsedAt,
+			"revoked_at":    record.RevokedAt,
+			"token_version": record.TokenVersion,
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return session.ErrNotFound
+		}
+		return appendEvents(ctx, tx, s.GetEvents())
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update session: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SessionRepository) findOne(ctx context.Context, query string, arg interface{}) (*session.Session, error) {
+	var record models.Session
+	err := conn(ctx, r.db).Where(query, arg).Take(&record).Error
+	if isNotFound(err) {
+		return nil, session.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load session: %w", err)
+	}
+
+	return toSessionDomain(record), nil
+}
+
+func toSessionModel(s *session.Session) models.Session {
+	return models.Session{
+		ID:           models.UUID(s.ID),
+		UserID:       models.UUID(s.UserID),
+		DeviceID:     models.NullableUUID(s.DeviceID),
+		UserAgent:    nullable(s.UserAgent),
+		IPAddress:    nullable(s.IPAddress),
+		CreatedAt:    s.CreatedAt,
+		LastUsedAt:   s.LastUsedAt,
+		RevokedAt:    s.RevokedAt,
+		TokenVersion: s.TokenVersion,
+		RevokeHash:   nullable(s.RevokeTokenHash),
+	}
+}
+
+func toSessionDomain(record models.Session) *session.Session {
+	s := &session.Session{
+		ID:              string(record.ID),
+		UserID:          string(record.UserID),
+		DeviceID:        models.UUIDValue(record.DeviceID),
+		UserAgent:       valueOf(record.UserAgent),
+		IPAddress:       valueOf(record.IPAddress),
+		CreatedAt:       record.CreatedAt,
+		LastUsedAt:      record.LastUsedAt,
+		RevokedAt:       record.RevokedAt,
+		TokenVersion:    record.TokenVersion,
+		RevokeTokenHash: valueOf(record.RevokeHash),
+	}
+	s.ClearEvents()
+
+	return s
+}
@@ -0,0 +1,87 @@
+package mysql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/settings"
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/persistence/mysql/models"
+	"gorm.io/gorm"
+)
+
+// SettingsRepository stores the settings document as JSON next to its schema version
+type SettingsRepository struct {
+	db *gorm.DB
+}
+
+func NewSettingsRepository(db *gorm.DB) settings.Repository {
+	return &SettingsRepository{db: db}
+}
+
+func (r *SettingsRepository) GetByUserID(ctx context.Context, userID string) (*settings.UserSettings, error) {
+	var record models.UserSettings
+	err := conn(ctx, r.db).Where("user_id = ?", models.UUID(userID)).Take(&record).Error
+	if isNotFound(err) {
+		return nil, settings.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load settings: %w", err)
+	}
+
+	userSettings := &settings.UserSettings{}
+	if err := json.Unmarshal(record.Data, userSettings); err != nil {
+		return nil, fmt.Errorf("failed to decode settings: %w", err)
+	}
+	userSettings.UserID = string(record.UserID)
+	userSettings.SchemaVersion = record.SchemaVersion
+	userSettings.Revision = record.Revision
+	userSettings.UpdatedAt = record.UpdatedAt
+
+	return userSettings, nil
+}
+
+func (r *SettingsRepository) Save(ctx context.Context, userSettings *settings.UserSettings) error {
+	data, err := json.Marshal(userSettings)
+	if err != nil {
+		return fmt.Errorf("failed to encode settings: %w", err)
+	}
+
+	record := models.UserSettings{
+		UserID:        models.UUID(userSettings.UserID),
+		SchemaVersion: userSettings.SchemaVersion,
+		Data:          data,
+		Revision:      userSettings.Revision,
+		UpdatedAt:     userSettings.UpdatedAt,
+	}
+
+	// Apply increments the revision once, so the stored revision must be the previous one
+	expected := userSettings.Revision - 1
+	if expected == 0 {
+		err = conn(ctx, r.db).Create(&record).Error
+		if isDuplicate(err) {
+			return settings.ErrConcurrentModification
+		}
+		if err != nil {
+			return fmt.Errorf("failed to save settings: %w", err)
+		}
+		return nil
+	}
+
+	result := conn(ctx, r.db).Model(&models.UserSettings{}).
+		Where("user_id = ? AND revision = ?", record.UserID, expected).
+		Updates(map[string]interface{}{
+			"schema_version": record.SchemaVersion,
+			"data":           record.Data,
+			"revision":       record.Revision,
+			"updated_at":     record.UpdatedAt,
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to save settings: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return settings.ErrConcurrentModification
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/application/interfaces"
+	mysqlDriver "github.com/go-sql-driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+const (
+	// MySQL error numbers of a transaction chosen as deadlock victim and of a lock wait timeout
+	errLockDeadlock    = 1213
+	errLockWaitTimeout = 1205
+
+	maxUnitAttempts = 3
+	retryBackoff    = 20 * time.Millisecond
+)
+
+type txKey struct{}
+
+// UnitOfWork runs a unit in one database transaction, retried when MySQL aborts it on a deadlock
+type UnitOfWork struct {
+	db *gorm.DB
+}
+
+func NewUnitOfWork(db *gorm.DB) interfaces.UnitOfWork {
+	return &UnitOfWork{db: db}
+}
+
+func (u *UnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	// Nested units join the outer transaction, only the outer one retries
+	if _, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return fn(ctx)
+	}
+
+	for attempt := 1; ; attempt++ {
+		hooks := &interfaces.UnitHooks{}
+		err := u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			return fn(interfaces.WithUnitHooks(context.WithValue(ctx, txKey{}, tx), hooks))
+		})
+		if err == nil {
+			hooks.Committed(ctx)
+			return nil
+		}
+		if !isRetryable(err) || attempt == maxUnitAttempts {
+			return err
+		}
+
+		// Back off with jitter so the competing transactions do not collide again
+		delay := retryBackoff*time.Duration(attempt) + rand.N(retryBackoff)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// conn returns the transaction of the unit of work in ctx, or the plain connection
+// outside of one. Reads asking for the primary skip the replicas.
+func conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	if interfaces.ReadsFromPrimary(ctx) {
+		return db.WithContext(ctx).Clauses(dbresolver.Write)
+	}
+	return db.WithContext(ctx)
+}
+
+// isRetryable reports whether MySQL rolled back the transaction because of lock contention
+func isRetryable(err error) bool {
+	var mysqlErr *mysqlDriver.MySQLError
+	if !errors.As(err, &mysqlErr) {
+		return false
+	}
+	return mysqlErr.Number == errLockDeadlock || mysqlErr.Number == errLockWaitTimeout
+}
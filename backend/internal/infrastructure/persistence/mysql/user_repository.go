@@ -0,0 +1,301 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/ids"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/user"
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/persistence/mysql/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// UserRepository stores the User aggregate across the Users, Credentials and Profile
+// tables, and its pending domain events in the Outbox table of the same transaction
+type UserRepository struct {
+	db *gorm.DB
+}
+
+func NewUserRepository(db *gorm.DB) user.Repository {
+	return &UserRepository{db: db}
+}
+
+func (r *UserRepository) Create(ctx context.Context, u *user.User) error {
+	if u.ID == "" {
+		u.ID = ids.New()
+	}
+
+	record, credentials, profile := toUserModels(u)
+	record.Version = 1
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(&record).Error; err != nil {
+			return err
+		}
+		if err := tx.Omit(clause.Associations).Create(&credentials).Error; err != nil {
+			return err
+		}
+		if err := tx.Omit(clause.Associations).Create(&profile).Error; err != nil {
+			return err
+		}
+		return appendEvents(ctx, tx, u.GetEvents())
+	})
+	if isDuplicate(err) {
+		return fmt.Errorf("%w: %v", user.ErrConflict, err)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create user: %w", err)
+	}
+
+	u.Credentials.UserID = u.ID
+	u.Profile.UserID = u.ID
+	u.Version = record.Version
+	return nil
+}
+
+func (r *UserRepository) GetByID(ctx context.Context, id string) (*user.User, error) {
+	return r.findOne(ctx, "`Users`.`id` = ?", models.UUID(id))
+}
+
+func (r *UserRepository) GetByEmail(ctx context.Context, email user.Email) (*user.User, error) {
+	return r.findOne(ctx, "`Credentials`.`email` = ?", email.String())
+}
+
+func (r *UserRepository) GetByUsername(ctx context.Context, username user.Username) (*user.User, error) {
+	return r.findOne(ctx, "`Credentials`.`username` = ?", username.String())
+}
+
+func (r *UserRepository) Update(ctx context.Context, u *user.User) error {
+	record, credentials, profile := toUserModels(u)
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		// The version guards the whole aggregate, credentials and profile included
+		result := tx.Model(&models.User{}).
+			Where("id = ? AND version = ?", models.UUID(u.ID), u.Version).
+			Updates(map[string]interface{}{
+				"role":       record.Role,
+				"version":    u.Version + 1,
+				"updated_at": record.UpdatedAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return r.missingOrStale(tx, u.ID)
+		}
+
+		if err := tx.Omit(clause.Associations).Save(&credentials).Error; err != nil {
+			return err
+		}
+		if err := tx.Omit(clause.Associations).Save(&profile).Error; err != nil {
+			return err
+		}
+		return appendEvents(ctx, tx, u.GetEvents())
+	})
+	if isDuplicate(err) {
+		return fmt.Errorf("%w: %v", user.ErrConflict, err)
+	}
+	if errors.Is(err, user.ErrNotFound) || errors.Is(err, user.ErrConcurrentModification) {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+
+	u.Version++
+	return nil
+}
+
+// missingOrStale tells why an update matched no row
+func (r *UserRepository) missingOrStale(tx *gorm.DB, id string) error {
+	var count int64
+	if err := tx.Model(&models.User{}).Where("id = ?", models.UUID(id)).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return user.ErrNotFound
+	}
+	return user.ErrConcurrentModification
+}
+
+func (r *UserRepository) DeleteByID(ctx context.Context, id string) error {
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", models.UUID(id)).Delete(&models.Profile{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", models.UUID(id)).Delete(&models.Credentials{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Where("id = ?", models.UUID(id)).Delete(&models.User{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return user.ErrNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+
+	return nil
+}
+
+func (r *UserRepository) Exists(ctx context.Context, email user.Email, username user.Username) (bool, error) {
+	var count int64
+	err := conn(ctx, r.db).Model(&models.Credentials{}).
+		Where("email = ? OR username = ?", email.String(), username.String()).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to check user existence: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+func (r *UserRepository) ExistingUsernames(ctx context.Context, usernames []user.Username) ([]user.Username, error) {
+	if len(usernames) == 0 {
+		return nil, nil
+	}
+
+	values := make([]string, len(usernames))
+	for i, username := range usernames {
+		values[i] = username.String()
+	}
+
+	var taken []string
+	err := conn(ctx, r.db).Model(&models.Credentials{}).
+		Where("username IN ?", values).
+		Pluck("username", &taken).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to check usernames: %w", err)
+	}
+
+	// The column collation ignores case, so match the stored names back the same way
+	existing := make([]user.Username, 0, len(taken))
+	for _, username := range usernames {
+		for _, stored := range taken {
+			if strings.EqualFold(username.String(), stored) {
+				existing = append(existing, username)
+				break
+			}
+		}
+	}
+
+	return existing, nil
+}
+
+func (r *UserRepository) findOne(ctx context.Context, query string, arg interface{}) (*user.User, error) {
+	var record models.User
+	err := conn(ctx, r.db).
+		Joins("Credentials").
+		Joins("Profile").
+		Where(query, arg).
+		Take(&record).Error
+	if isNotFound(err) {
+		return nil, user.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load user: %w", err)
+	}
+
+	return toUserDomain(record)
+}
+
+func toUserModels(u *user.User) (models.User, models.Credentials, models.Profile) {
+	gender := models.Gender(u.Profile.Gender)
+	if gender == "" {
+		gender = models.PreferNotToSay
+	}
+
+	record := models.User{
+		ID:        models.UUID(u.ID),
+		Role:      models.Role(u.Role),
+		Version:   u.Version,
+		CreatedAt: u.CreatedAt,
+		UpdatedAt: u.UpdatedAt,
+	}
+
+	credentials := models.Credentials{
+		UserID:        models.UUID(u.ID),
+		Email:         u.Credentials.Email.String(),
+		Password:      u.Credentials.PasswordHash.String(),
+		Username:      u.Credentials.Username.String(),
+		EmailVerified: u.Credentials.EmailVerified,
+		MfaEnabled:    u.Credentials.MfaEnabled,
+		MfaSecret:     u.Credentials.MfaSecret,
+		MfaLastStep:   u.Credentials.MfaLastStep,
+		LastLoginAt:   u.Credentials.LastLoginAt,
+	}
+
+	profile := models.Profile{
+		UserID:         models.UUID(u.ID),
+		FirstName:      u.Profile.FirstName,
+		LastName:       u.Profile.LastName,
+		Gender:         gender,
+		DateOfBirth:    u.Profile.DateOfBirth,
+		Bio:            u.Profile.Bio,
+		DisplayName:    u.Profile.DisplayName,
+		AvatarPhotoID:  u.Profile.AvatarPhotoID,
+		RelationshipID: models.NullableUUID(valueOf(u.Profile.RelationshipID)),
+		Locale:         u.Profile.Locale,
+		Timezone:       u.Profile.Timezone,
+	}
+
+	return record, credentials, profile
+}
+
+func toUserDomain(record models.User) (*user.User, error) {
+	email, err := user.NewEmail(record.Credentials.Email)
+	if err != nil {
+		return nil, fmt.Errorf("stored email of user %s is invalid: %w", record.ID, err)
+	}
+
+	username, err := user.NewUsername(record.Credentials.Username)
+	if err != nil {
+		return nil, fmt.Errorf("stored username of user %s is invalid: %w", record.ID, err)
+	}
+
+	role := user.Role(record.Role)
+	if !role.IsValid() {
+		role = user.RoleMember
+	}
+
+	u := &user.User{
+		ID:        string(record.ID),
+		Role:      role,
+		Version:   record.Version,
+		CreatedAt: record.CreatedAt,
+		UpdatedAt: record.UpdatedAt,
+		Credentials: user.Credentials{
+			UserID:        string(record.ID),
+			Email:         email,
+			Username:      username,
+			PasswordHash:  user.PasswordHashFromStorage(record.Credentials.Password),
+			EmailVerified: record.Credentials.EmailVerified,
+			MfaEnabled:    record.Credentials.MfaEnabled,
+			MfaSecret:     record.Credentials.MfaSecret,
+			MfaLastStep:   record.Credentials.MfaLastStep,
+			LastLoginAt:   record.Credentials.LastLoginAt,
+		},
+		Profile: user.Profile{
+			UserID:         string(record.ID),
+			FirstName:      record.Profile.FirstName,
+			LastName:       record.Profile.LastName,
+			Gender:         user.Gender(record.Profile.Gender),
+			DateOfBirth:    record.Profile.DateOfBirth,
+			Bio:            record.Profile.Bio,
+			DisplayName:    record.Profile.DisplayName,
+			AvatarPhotoID:  record.Profile.AvatarPhotoID,
+			RelationshipID: nullable(models.UUIDValue(record.Profile.RelationshipID)),
+			Locale:         record.Profile.Locale,
+			Timezone:       record.Profile.Timezone,
+		},
+	}
+	u.ClearEvents()
+
+	return u, nil
+}
@@ -0,0 +1,60 @@
+package qrcode
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/application/interfaces"
+	"github.com/skip2/go-qrcode"
+)
+
+// DefaultSize is the width and height in pixels of PNG codes
+const DefaultSize = 512
+
+// Renderer draws QR codes with medium error correction, enough for a code
+// photographed off another phone's screen
+type Renderer struct {
+	size int
+}
+
+func NewRenderer(size int) interfaces.QRCodeRenderer {
+	return &Renderer{size: size}
+}
+
+func (r *Renderer) Render(content string, format interfaces.QRCodeFormat) ([]byte, error) {
+	code, err := qrcode.New(content, qrcode.Medium)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode QR code: %w", err)
+	}
+
+	switch format {
+	case interfaces.QRCodePNG:
+		png, err := code.PNG(r.size)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render QR code: %w", err)
+		}
+		return png, nil
+	case interfaces.QRCodeSVG:
+		return renderSVG(code.Bitmap()), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", interfaces.ErrUnsupportedQRFormat, format)
+	}
+}
+
+// renderSVG draws one square per dark module, the bitmap already includes the quiet zone.
+// The image scales to any size, so it is drawn in module units.
+func renderSVG(bitmap [][]bool) []byte {
+	var svg bytes.Buffer
+	fmt.Fprintf(&svg, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %[1]d %[1]d" shape-rendering="crispEdges">`, len(bitmap))
+	fmt.Fprintf(&svg, `<rect width="%[1]d" height="%[1]d" fill="#fff"/><path fill="#000" d="`, len(bitmap))
+	for y, row := range bitmap {
+		for x, dark := range row {
+			if dark {
+				fmt.Fprintf(&svg, "M%d %dh1v1h-1z", x, y)
+			}
+		}
+	}
+	svg.WriteString(`"/></svg>`)
+
+	return svg.Bytes()
+}
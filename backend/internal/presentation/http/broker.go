@@ -0,0 +1,56 @@
+package http
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	appEvents "github.com/StefanPenchev05/Amora/backend/internal/application/events"
+	"github.com/StefanPenchev05/Amora/backend/internal/config"
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/broker"
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/events"
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/health"
+)
+
+// brokerTimeout bounds connecting to the broker or the cache on boot
+const brokerTimeout = 10 * time.Second
+
+// newRelayPublisher returns where the outbox relay publishes events: the in-process
+// bus, behind JetStream when a NATS server is configured. close releases the broker.
+func newRelayPublisher(
+	cfg config.EventsConfig,
+	registry *appEvents.Registry,
+	bus *events.Bus,
+	checks *health.Registry,
+	logger *slog.Logger,
+) (publisher appEvents.Publisher, close func(), err error) {
+	if cfg.NATSURL == "" {
+		return bus, func() {}, nil
+	}
+
+	conn, js, err := broker.Connect(cfg.NATSURL)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), brokerTimeout)
+	defer cancel()
+
+	streamOptions := broker.DefaultStreamOptions()
+	streamOptions.Name = cfg.Stream
+	if _, err := broker.EnsureStream(ctx, js, streamOptions); err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+	logger.Info("Publishing domain events to JetStream", "stream", cfg.Stream)
+
+	// Events wait in the outbox while the broker is away, so it only degrades the service
+	checks.Register(health.Check{
+		Name: "broker",
+		Run:  conn.FlushWithContext,
+	})
+
+	// JetStream drops duplicates by event ID, so a retry after the bus failed is harmless
+	publisher = events.NewFanOutPublisher(broker.NewJetStreamPublisher(js, registry), bus)
+	return publisher, func() { _ = conn.Drain() }, nil
+}
@@ -0,0 +1,54 @@
+package http
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/application/interfaces"
+	"github.com/StefanPenchev05/Amora/backend/internal/config"
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/cache"
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/health"
+)
+
+// cacheKeyPrefix namespaces the keys of this service in a shared Redis
+const cacheKeyPrefix = "amora:"
+
+// cacheBackend is what the container keeps on the cache server
+type cacheBackend struct {
+	cache   interfaces.Cache
+	limiter interfaces.AttemptLimiter
+	close   func()
+}
+
+// newCache returns the cache of hot reads and the attempt limiter: Redis when
+// configured, otherwise in process. close releases the connection.
+func newCache(cfg config.CacheConfig, checks *health.Registry, logger *slog.Logger) (*cacheBackend, error) {
+	if cfg.RedisURL == "" {
+		return &cacheBackend{
+			cache:   cache.NewLRUCache(cfg.Size),
+			limiter: cache.NewMemoryAttemptLimiter(cfg.Size),
+			close:   func() {},
+		}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), brokerTimeout)
+	defer cancel()
+
+	client, err := cache.Connect(ctx, cfg.RedisURL)
+	if err != nil {
+		return nil, err
+	}
+	logger.Info("Caching hot reads in Redis", "ttl", cfg.TTL.String())
+
+	// Reads fall back to the database while Redis is away
+	checks.Register(health.Check{
+		Name: "cache",
+		Run:  func(ctx context.Context) error { return client.Ping(ctx).Err() },
+	})
+
+	return &cacheBackend{
+		cache:   cache.NewRedisCache(client, cacheKeyPrefix),
+		limiter: cache.NewRedisAttemptLimiter(client, cacheKeyPrefix),
+		close:   func() { _ = client.Close() },
+	}, nil
+}
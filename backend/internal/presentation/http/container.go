@@ -1,19 +1,179 @@
 package http
 
 import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	appEvents "github.com/StefanPenchev05/Amora/backend/internal/application/events"
+	"github.com/StefanPenchev05/Amora/backend/internal/application/features"
+	"github.com/StefanPenchev05/Amora/backend/internal/application/interfaces"
+	"github.com/StefanPenchev05/Amora/backend/internal/application/outbox"
+	"github.com/StefanPenchev05/Amora/backend/internal/application/subscribers"
+	featureCase "github.com/StefanPenchev05/Amora/backend/internal/application/usecases/feature"
+	relationshipCase "github.com/StefanPenchev05/Amora/backend/internal/application/usecases/relationship"
+	securityLogCase "github.com/StefanPenchev05/Amora/backend/internal/application/usecases/securitylog"
+	sessionCase "github.com/StefanPenchev05/Amora/backend/internal/application/usecases/session"
+	settingsCase "github.com/StefanPenchev05/Amora/backend/internal/application/usecases/settings"
+	userCase "github.com/StefanPenchev05/Amora/backend/internal/application/usecases/user"
 	"github.com/StefanPenchev05/Amora/backend/internal/config"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/feature"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/relationship"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/securitylog"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/session"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/settings"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/user"
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/auth"
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/cache"
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/email"
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/events"
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/featureflags"
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/geoip"
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/health"
 	httpInfra "github.com/StefanPenchev05/Amora/backend/internal/infrastructure/http"
 	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/http/middleware"
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/qrcode"
 	"github.com/StefanPenchev05/Amora/backend/internal/presentation/http/routes"
 )
 
 // Presentaion layer
 type Container struct {
-	config *config.Config
+	config         *config.Config
+	logger         *slog.Logger
+	jwtService     interfaces.JWTService
+	featureService interfaces.FeatureService
+	flags          feature.FlagRepository
+	storedFlags    feature.FlagStore
+	emailService   interfaces.EmailService
+	geoLocator     interfaces.GeoLocator
+	eventPublisher interfaces.EventPublisher
+	eventBus       *events.Bus
+	outboxRelay    *outbox.Relay
+	attemptLimiter interfaces.AttemptLimiter
+	closers        []func()
+	healthChecks   *health.Registry
+
+	// Repositories
+	userRepo         user.Repository
+	settingsRepo     settings.Repository
+	sessionRepo      session.Repository
+	deviceRepo       session.DeviceRepository
+	mfaChallengeRepo session.MFAChallengeRepository
+	securityLogRepo  securitylog.Repository
+	relationshipRepo relationship.Repository
+	inviteRepo       relationship.InviteRepository
+	unitOfWork       interfaces.UnitOfWork
 }
 
-func NewContainer(cfg *config.Config) *Container {
-	return &Container{cfg}
+func NewContainer(cfg *config.Config) (*Container, error) {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+		Level: slog.LevelInfo,
+	}))
+
+	healthChecks := health.NewRegistry()
+
+	repos, err := newRepositories(cfg, healthChecks, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	jwtService, err := auth.NewJWTService(cfg.JWT)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.JWT.LegacyHS256Until != nil {
+		logger.Warn("Accepting legacy HS256 tokens until the sunset", "until", cfg.JWT.LegacyHS256Until.Format(time.RFC3339))
+	}
+
+	geoLocator, err := geoip.NewLocator(cfg.GeoIP.DatabasePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open GeoIP database: %w", err)
+	}
+
+	emailService := email.NewLogEmailService(logger)
+
+	cacheBackend, err := newCache(cfg.Cache, healthChecks, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the cache: %w", err)
+	}
+	readThrough := cache.NewReadThrough(cacheBackend.cache, cfg.Cache.TTL, logger)
+	userRepo := cache.NewUserRepository(repos.user, readThrough)
+
+	// Build feature flags from defaults and the optional flags file, stored flags take precedence
+	fileFlagStore, err := featureflags.NewFileFlagStore(cfg.Features.FlagsFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load feature flags: %w", err)
+	}
+	storedFlags := cache.NewFlagStore(repos.flags, readThrough)
+	flags := featureflags.NewCompositeFlagStore(fileFlagStore, storedFlags)
+
+	// Side effects of domain events subscribe here, the use cases only raise the events
+	eventRegistry := appEvents.DefaultRegistry()
+	eventBus := events.NewBus(eventRegistry, repos.deliveries, events.DefaultBusOptions(), logger)
+	eventBus.Subscribe(subscribers.NewSecurityLogSubscriber(repos.securityLog, geoLocator, logger), events.Async())
+	eventBus.Subscribe(subscribers.NewWelcomeEmailSubscriber(emailService, logger), events.Async())
+	eventBus.Subscribe(subscribers.NewRelationshipInviteSubscriber(userRepo, emailService, logger), events.Async())
+	eventBus.Subscribe(cache.NewUserInvalidationSubscriber(readThrough), events.Sync())
+
+	relayPublisher, closeBroker, err := newRelayPublisher(cfg.Events, eventRegistry, eventBus, healthChecks, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the message broker: %w", err)
+	}
+
+	relayOptions := outbox.DefaultRelayOptions()
+	outboxRelay := outbox.NewRelay(repos.outbox, relayPublisher, relayOptions, logger)
+	healthChecks.Register(
+		health.Heartbeat("outbox_relay", outboxRelay.LastPoll, max(10*relayOptions.PollInterval, relayOptions.Lease)),
+		health.Check{Name: "event_bus", Run: func(ctx context.Context) error {
+			if queued, capacity := eventBus.Backlog(); queued >= capacity {
+				return fmt.Errorf("async queue is full with %d deliveries", queued)
+			}
+			return nil
+		}},
+	)
+
+	return &Container{
+		config:         cfg,
+		logger:         logger,
+		jwtService:     jwtService,
+		featureService: features.NewService(flags, repos.plans, cfg.Environment, logger),
+		flags:          flags,
+		storedFlags:    storedFlags,
+		emailService:   emailService,
+		geoLocator:     geoLocator,
+		eventPublisher: outbox.NewPublisher(repos.outbox),
+		eventBus:       eventBus,
+		outboxRelay:    outboxRelay,
+		healthChecks:   healthChecks,
+		attemptLimiter: cacheBackend.limiter,
+		closers:        []func(){closeBroker, cacheBackend.close},
+
+		userRepo:         userRepo,
+		settingsRepo:     repos.settings,
+		sessionRepo:      repos.session,
+		deviceRepo:       repos.device,
+		mfaChallengeRepo: repos.mfaChallenge,
+		securityLogRepo:  repos.securityLog,
+		relationshipRepo: repos.relationship,
+		inviteRepo:       repos.invite,
+		unitOfWork:       repos.unitOfWork,
+	}, nil
+}
+
+// StartWorkers runs the background workers until the context is cancelled
+func (c *Container) StartWorkers(ctx context.Context) {
+	go c.eventBus.Run(ctx)
+	go c.outboxRelay.Run(ctx)
+	go relationshipCase.NewExpireRelationshipsCase(c.relationshipRepo, relationshipCase.DefaultRetentionOptions(), c.logger).Run(ctx)
+
+	go func() {
+		<-ctx.Done()
+		for _, close := range c.closers {
+			close()
+		}
+	}()
 }
 
 func (c *Container) BuildServer() httpInfra.HTTPServer {
@@ -36,8 +196,11 @@ func (c *Container) buildRouter() httpInfra.Router {
 	// }))
 	// loggerMiddlewar := middleware.LoggingMiddleware(loggerOptions)
 
-	// Build router with middleware
-	router := httpInfra.NewRouter(corsMiddleware)
+	clientIPMiddleware := middleware.NewClientIPMiddleware(c.config.Server.TrustedProxies)
+	requestInfoMiddleware := middleware.NewRequestInfoMiddleware()
+
+	// Build router with middleware, the client address is settled before it is read
+	router := httpInfra.NewRouter(corsMiddleware, clientIPMiddleware, requestInfoMiddleware)
 
 	// Register route groups
 	c.registerRoutes(router)
@@ -47,6 +210,95 @@ func (c *Container) buildRouter() httpInfra.Router {
 
 func (c *Container) registerRoutes(router httpInfra.Router) {
 	// Register health routes
-	healthRoutes := routes.NewHealthRoutes()
+	healthRoutes := routes.NewHealthRoutes(c.healthChecks)
 	router.RegisterRoutes(healthRoutes)
+
+	if c.config.Debug {
+		router.RegisterRoutes(routes.NewDebugRoutes())
+	}
+
+	// Register public signing keys
+	wellKnownRoutes := routes.NewWellKnownRoutes(c.jwtService)
+	router.RegisterRoutes(wellKnownRoutes)
+
+	sessionIssuer := userCase.NewSessionIssuer(
+		c.userRepo,
+		c.settingsRepo,
+		c.sessionRepo,
+		c.deviceRepo,
+		c.jwtService,
+		c.featureService,
+		c.emailService,
+		c.geoLocator,
+		c.config.AppURL,
+		c.logger,
+	)
+
+	userService := user.NewUserService(c.userRepo)
+	partnerLinker := relationshipCase.NewPartnerLinker(c.userRepo, c.inviteRepo, c.relationshipRepo)
+	inviteCodeGuard := relationshipCase.NewInviteCodeGuard(c.attemptLimiter, relationshipCase.DefaultInviteCodeGuardOptions(), c.logger)
+
+	// Register auth routes
+	authRoutes := routes.NewAuthRoutes(
+		userCase.NewCreateUserCase(c.userRepo, userService, partnerLinker, inviteCodeGuard, c.unitOfWork, c.emailService, c.logger),
+		userCase.NewAuthenticateUserCase(c.userRepo, c.mfaChallengeRepo, sessionIssuer, c.jwtService, c.eventPublisher, c.logger),
+		userCase.NewVerifyMFALoginCase(c.userRepo, c.mfaChallengeRepo, sessionIssuer, c.jwtService, c.eventPublisher, c.config.Security.TrustedDeviceTTL, c.logger),
+		userCase.NewRefreshTokenCase(c.userRepo, c.sessionRepo, c.jwtService, c.logger),
+		sessionCase.NewRevokeSessionByLinkCase(c.sessionRepo, c.deviceRepo, c.logger),
+		userCase.NewSuggestUsernamesCase(userService, c.logger),
+		userCase.NewCheckUsernameCase(userService, c.logger),
+	)
+	router.RegisterRoutes(authRoutes)
+
+	// Register routes of the authenticated user
+	meRoutes := routes.NewMeRoutes(
+		middleware.NewAuthMiddleware(c.jwtService, c.sessionRepo),
+		settingsCase.NewGetSettingsCase(c.settingsRepo, c.logger),
+		settingsCase.NewUpdateSettingsCase(c.settingsRepo, c.logger),
+		securityLogCase.NewListSecurityLogCase(c.securityLogRepo, c.logger),
+		sessionCase.NewListDevicesCase(c.deviceRepo, c.logger),
+		sessionCase.NewSetDeviceTrustCase(c.sessionRepo, c.deviceRepo, c.config.Security.TrustedDeviceTTL, c.logger),
+	)
+	router.RegisterRoutes(meRoutes)
+
+	// Register user and feature management, gated by the admin permissions
+	adminRoutes := routes.NewAdminRoutes(
+		middleware.NewAuthMiddleware(c.jwtService, c.sessionRepo),
+		userCase.NewGetUserCase(c.userRepo, c.logger),
+		userCase.NewChangeRoleCase(c.userRepo, c.logger),
+		featureCase.NewListFlagsCase(c.flags, c.logger),
+		featureCase.NewSaveFlagCase(c.storedFlags, c.logger),
+	)
+	router.RegisterRoutes(adminRoutes)
+
+	// Register relationship routes
+	relationshipRoutes := routes.NewRelationshipRoutes(
+		middleware.NewAuthMiddleware(c.jwtService, c.sessionRepo),
+		middleware.RequireFeature(c.featureService, c.userRepo, "invite_codes"),
+		relationshipCase.NewSendInviteCase(c.userRepo, c.inviteRepo, c.logger),
+		relationshipCase.NewListInvitesCase(c.inviteRepo, c.logger),
+		relationshipCase.NewAcceptInviteCase(c.userRepo, c.inviteRepo, partnerLinker, c.unitOfWork, c.logger),
+		relationshipCase.NewDeclineInviteCase(c.inviteRepo, c.logger),
+		relationshipCase.NewCancelInviteCase(c.inviteRepo, c.logger),
+		relationshipCase.NewCreateInviteCodeCase(c.userRepo, c.inviteRepo, c.config.AppURL, c.logger),
+		relationshipCase.NewRedeemInviteCodeCase(c.userRepo, partnerLinker, inviteCodeGuard, c.unitOfWork, c.logger),
+		relationshipCase.NewRenderInviteCodeCase(c.inviteRepo, qrcode.NewRenderer(qrcode.DefaultSize), c.config.AppURL, c.logger),
+		relationshipCase.NewListRelationshipsCase(c.relationshipRepo, c.logger),
+		relationshipCase.NewGetRelationshipCase(c.relationshipRepo, c.logger),
+		relationshipCase.NewGetRelationshipBySlugCase(c.relationshipRepo, c.logger),
+		relationshipCase.NewPauseRelationshipCase(c.userRepo, c.relationshipRepo, c.unitOfWork, c.logger),
+		relationshipCase.NewResumeRelationshipCase(c.userRepo, c.relationshipRepo, c.unitOfWork, c.logger),
+		relationshipCase.NewEndRelationshipCase(c.userRepo, c.relationshipRepo, c.unitOfWork, c.logger),
+		relationshipCase.NewReconnectRelationshipCase(c.userRepo, c.relationshipRepo, c.unitOfWork, c.logger),
+		relationshipCase.NewWithdrawRequestCase(c.userRepo, c.relationshipRepo, c.unitOfWork, c.logger),
+	)
+	router.RegisterRoutes(relationshipRoutes)
+
+	// Register the profile of the current relationship
+	relationshipProfileRoutes := routes.NewRelationshipProfileRoutes(
+		middleware.NewAuthMiddleware(c.jwtService, c.sessionRepo),
+		relationshipCase.NewGetCurrentRelationshipCase(c.userRepo, c.relationshipRepo, c.logger),
+		relationshipCase.NewUpdateProfileCase(c.userRepo, c.relationshipRepo, c.logger),
+	)
+	router.RegisterRoutes(relationshipProfileRoutes)
 }
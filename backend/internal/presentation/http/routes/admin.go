@@ -0,0 +1,167 @@
+package routes
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/application/authz"
+	featureDto "github.com/StefanPenchev05/Amora/backend/internal/application/dto/feature"
+	userDto "github.com/StefanPenchev05/Amora/backend/internal/application/dto/user"
+	featureCase "github.com/StefanPenchev05/Amora/backend/internal/application/usecases/feature"
+	userCase "github.com/StefanPenchev05/Amora/backend/internal/application/usecases/user"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/feature"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/user"
+	httpInfra "github.com/StefanPenchev05/Amora/backend/internal/infrastructure/http"
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/http/middleware"
+	"github.com/go-chi/chi/v5"
+)
+
+// AdminRoutes - user and feature management, every route requires an admin permission
+type AdminRoutes struct {
+	authMiddleware httpInfra.Middleware
+	getUser        *userCase.GetUserCase
+	changeRole     *userCase.ChangeRoleCase
+	listFlags      *featureCase.ListFlagsCase
+	saveFlag       *featureCase.SaveFlagCase
+}
+
+func NewAdminRoutes(
+	authMiddleware httpInfra.Middleware,
+	getUser *userCase.GetUserCase,
+	changeRole *userCase.ChangeRoleCase,
+	listFlags *featureCase.ListFlagsCase,
+	saveFlag *featureCase.SaveFlagCase,
+) *AdminRoutes {
+	return &AdminRoutes{
+		authMiddleware: authMiddleware,
+		getUser:        getUser,
+		changeRole:     changeRole,
+		listFlags:      listFlags,
+		saveFlag:       saveFlag,
+	}
+}
+
+func (a *AdminRoutes) Path() string {
+	return "/admin"
+}
+
+func (a *AdminRoutes) RegisterRoutes(router httpInfra.Router) {
+	router.Route(a.Path(), func(r chi.Router) {
+		r.Use(a.authMiddleware.Handle)
+
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.RequirePermission(user.PermissionAdminUsers))
+
+			r.Get("/users/{userID}", a.getManagedUser)
+			r.Put("/users/{userID}/role", a.putUserRole)
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.RequirePermission(user.PermissionAdminSystem))
+
+			r.Get("/features", a.getFeatures)
+			r.Put("/features/{key}", a.putFeature)
+		})
+	})
+}
+
+func (a *AdminRoutes) getManagedUser(w http.ResponseWriter, r *http.Request) {
+	claims, _ := middleware.ClaimsFromContext(r.Context())
+
+	response, err := a.getUser.Execute(r.Context(), claims.UserID, chi.URLParam(r, "userID"))
+	switch {
+	case err == nil:
+		setETag(w, response.Version)
+		writeJSON(w, http.StatusOK, response)
+	case errors.Is(err, authz.ErrForbidden):
+		writeError(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, user.ErrNotFound):
+		writeError(w, http.StatusNotFound, err.Error())
+	default:
+		writeError(w, http.StatusInternalServerError, "failed to load user")
+	}
+}
+
+func (a *AdminRoutes) putUserRole(w http.ResponseWriter, r *http.Request) {
+	claims, _ := middleware.ClaimsFromContext(r.Context())
+
+	// Two administrators may edit the same user, a stale role must not overwrite a newer one
+	expectedVersion, err := requireIfMatch(r)
+	if err != nil {
+		writeIfMatchError(w, err)
+		return
+	}
+
+	var req userDto.ChangeRoleRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	req.ActorID = claims.UserID
+	req.UserID = chi.URLParam(r, "userID")
+	req.ExpectedVersion = expectedVersion
+
+	response, err := a.changeRole.Execute(r.Context(), req)
+	switch {
+	case err == nil:
+		setETag(w, response.Version)
+		writeJSON(w, http.StatusOK, response)
+	case errors.Is(err, authz.ErrForbidden):
+		writeError(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, user.ErrNotFound):
+		writeError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, user.ErrValidation):
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+	case errors.Is(err, user.ErrConcurrentModification):
+		writeError(w, http.StatusPreconditionFailed, "user changed since it was loaded")
+	default:
+		writeError(w, http.StatusInternalServerError, "failed to change role")
+	}
+}
+
+func (a *AdminRoutes) getFeatures(w http.ResponseWriter, r *http.Request) {
+	response, err := a.listFlags.Execute(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list feature flags")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (a *AdminRoutes) putFeature(w http.ResponseWriter, r *http.Request) {
+	claims, _ := middleware.ClaimsFromContext(r.Context())
+
+	// The version of a flag not saved yet is 0, so If-Match: "0" only creates
+	expectedVersion, err := requireIfMatch(r)
+	if err != nil {
+		writeIfMatchError(w, err)
+		return
+	}
+
+	var req featureDto.SaveFlagRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	req.ActorID = claims.UserID
+	req.Key = chi.URLParam(r, "key")
+	req.ExpectedVersion = expectedVersion
+
+	response, err := a.saveFlag.Execute(r.Context(), req)
+	if errors.Is(err, feature.ErrInvalidFlag) {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	if errors.Is(err, feature.ErrConcurrentModification) {
+		writeError(w, http.StatusPreconditionFailed, "feature flag changed since it was loaded")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to save feature flag")
+		return
+	}
+
+	setETag(w, response.Version)
+	writeJSON(w, http.StatusOK, response)
+}
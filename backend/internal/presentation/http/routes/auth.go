@@ -0,0 +1,215 @@
+package routes
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	dto "github.com/StefanPenchev05/Amora/backend/internal/application/dto/user"
+	"github.com/StefanPenchev05/Amora/backend/internal/application/interfaces"
+	sessionCase "github.com/StefanPenchev05/Amora/backend/internal/application/usecases/session"
+	userCase "github.com/StefanPenchev05/Amora/backend/internal/application/usecases/user"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/relationship"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/session"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/user"
+	httpInfra "github.com/StefanPenchev05/Amora/backend/internal/infrastructure/http"
+	"github.com/go-chi/chi/v5"
+)
+
+// AuthRoutes - signup and login route group
+type AuthRoutes struct {
+	createUser       *userCase.CreateUserCase
+	authenticateUser *userCase.AuthenticateUserCase
+	verifyMFALogin   *userCase.VerifyMFALoginCase
+	refreshToken     *userCase.RefreshTokenCase
+	revokeSession    *sessionCase.RevokeSessionByLinkCase
+	suggestUsernames *userCase.SuggestUsernamesCase
+	checkUsername    *userCase.CheckUsernameCase
+}
+
+func NewAuthRoutes(
+	createUser *userCase.CreateUserCase,
+	authenticateUser *userCase.AuthenticateUserCase,
+	verifyMFALogin *userCase.VerifyMFALoginCase,
+	refreshToken *userCase.RefreshTokenCase,
+	revokeSession *sessionCase.RevokeSessionByLinkCase,
+	suggestUsernames *userCase.SuggestUsernamesCase,
+	checkUsername *userCase.CheckUsernameCase,
+) *AuthRoutes {
+	return &AuthRoutes{
+		createUser:       createUser,
+		authenticateUser: authenticateUser,
+		verifyMFALogin:   verifyMFALogin,
+		refreshToken:     refreshToken,
+		revokeSession:    revokeSession,
+		suggestUsernames: suggestUsernames,
+		checkUsername:    checkUsername,
+	}
+}
+
+func (a *AuthRoutes) Path() string {
+	return "/auth"
+}
+
+func (a *AuthRoutes) RegisterRoutes(router httpInfra.Router) {
+	router.Route(a.Path(), func(r chi.Router) {
+		r.Post("/register", a.register)
+		r.Post("/login", a.login)
+		r.Post("/mfa/verify", a.verifyMFA)
+		r.Post("/refresh", a.refresh)
+		r.Post("/sessions/revoke", a.revokeSessionByLink)
+		r.Get("/username-suggestions", a.usernameSuggestions)
+		r.Get("/username-availability", a.usernameAvailability)
+	})
+}
+
+func (a *AuthRoutes) register(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateUserRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	response, err := a.createUser.Execute(r.Context(), req)
+	switch {
+	case err == nil:
+		// Accepted whether or not the email was already registered
+		writeJSON(w, http.StatusAccepted, response)
+	case errors.Is(err, user.ErrUsernameTaken):
+		writeError(w, http.StatusConflict, user.ErrUsernameTaken.Error())
+	case errors.Is(err, user.ErrWeakPassword), errors.Is(err, user.ErrValidation):
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+	case errors.Is(err, relationship.ErrInvalidCode):
+		writeError(w, http.StatusUnprocessableEntity, relationship.ErrInvalidCode.Error())
+	case errors.Is(err, interfaces.ErrTooManyAttempts):
+		writeError(w, http.StatusTooManyRequests, err.Error())
+	default:
+		writeError(w, http.StatusInternalServerError, "failed to create user")
+	}
+}
+
+func (a *AuthRoutes) login(w http.ResponseWriter, r *http.Request) {
+	var req dto.AuthenticateUserRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	// Never trust client supplied connection details
+	req.IPAddress = clientIP(r)
+	req.UserAgent = r.UserAgent()
+
+	response, err := a.authenticateUser.Execute(r.Context(), req)
+	switch {
+	case err == nil:
+		writeJSON(w, http.StatusOK, response)
+	case errors.Is(err, user.ErrInvalidCredentials):
+		writeError(w, http.StatusUnauthorized, user.ErrInvalidCredentials.Error())
+	default:
+		writeError(w, http.StatusInternalServerError, "authentication failed")
+	}
+}
+
+func (a *AuthRoutes) verifyMFA(w http.ResponseWriter, r *http.Request) {
+	var req dto.VerifyMFARequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	req.IPAddress = clientIP(r)
+	req.UserAgent = r.UserAgent()
+
+	response, err := a.verifyMFALogin.Execute(r.Context(), req)
+	switch {
+	case err == nil:
+		writeJSON(w, http.StatusOK, response)
+	case errors.Is(err, user.ErrInvalidCredentials):
+		writeError(w, http.StatusUnauthorized, user.ErrInvalidCredentials.Error())
+	default:
+		writeError(w, http.StatusInternalServerError, "authentication failed")
+	}
+}
+
+func (a *AuthRoutes) refresh(w http.ResponseWriter, r *http.Request) {
+	var req dto.RefreshTokenRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	response, err := a.refreshToken.Execute(r.Context(), req)
+	switch {
+	case err == nil:
+		writeJSON(w, http.StatusOK, response)
+	case errors.Is(err, user.ErrInvalidCredentials):
+		writeError(w, http.StatusUnauthorized, "invalid or expired refresh token")
+	default:
+		writeError(w, http.StatusInternalServerError, "failed to refresh token")
+	}
+}
+
+// revokeSessionByLink handles the "this wasn't me" link from a new device alert
+func (a *AuthRoutes) revokeSessionByLink(w http.ResponseWriter, r *http.Request) {
+	var req dto.RevokeSessionRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	err := a.revokeSession.Execute(r.Context(), req)
+	switch {
+	case err == nil:
+		w.WriteHeader(http.StatusNoContent)
+	case errors.Is(err, session.ErrNotFound):
+		writeError(w, http.StatusNotFound, "link is invalid or has expired")
+	default:
+		writeError(w, http.StatusInternalServerError, "failed to revoke session")
+	}
+}
+
+// usernameSuggestions offers available usernames built from the names on the signup screen
+func (a *AuthRoutes) usernameSuggestions(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	req := dto.SuggestUsernamesRequest{
+		FirstName: query.Get("first"),
+		LastName:  query.Get("last"),
+	}
+	if count := query.Get("count"); count != "" {
+		parsed, err := strconv.Atoi(count)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "count must be a number")
+			return
+		}
+		req.Count = parsed
+	}
+
+	response, err := a.suggestUsernames.Execute(r.Context(), req)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to suggest usernames")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// usernameAvailability answers the check made while the username is typed. Clients
+// debounce the keystrokes, the short cache absorbs repeats of the same name.
+func (a *AuthRoutes) usernameAvailability(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	req := dto.CheckUsernameRequest{
+		Username:  query.Get("username"),
+		FirstName: query.Get("first"),
+		LastName:  query.Get("last"),
+	}
+
+	response, err := a.checkUsername.Execute(r.Context(), req)
+	switch {
+	case err == nil:
+		w.Header().Set("Cache-Control", "private, max-age=5")
+		writeJSON(w, http.StatusOK, response)
+	case errors.Is(err, user.ErrValidation):
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+	default:
+		writeError(w, http.StatusInternalServerError, "failed to check username")
+	}
+}
@@ -0,0 +1,83 @@
+package routes_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	relationshipCase "github.com/StefanPenchev05/Amora/backend/internal/application/usecases/relationship"
+	userCase "github.com/StefanPenchev05/Amora/backend/internal/application/usecases/user"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/user"
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/cache"
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/email"
+	httpInfra "github.com/StefanPenchev05/Amora/backend/internal/infrastructure/http"
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/persistence/memory"
+	"github.com/StefanPenchev05/Amora/backend/internal/presentation/http/routes"
+)
+
+var discardLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+// registerServer serves the signup route on in-memory repositories holding one
+// user registered as alice@example.com
+func registerServer(t *testing.T) http.Handler {
+	t.Helper()
+
+	outbox := memory.NewOutboxStore()
+	userRepo := memory.NewUserRepository(outbox)
+	inviteRepo := memory.NewInviteRepository(outbox)
+	relationshipRepo := memory.NewRelationshipRepository(outbox)
+	unitOfWork := memory.NewUnitOfWork(userRepo, inviteRepo, relationshipRepo, outbox)
+
+	registered, err := user.NewUser("alice@example.com", "alice", "Alice", "Example", "Correct-Horse-9")
+	if err != nil {
+		t.Fatalf("new user: %v", err)
+	}
+	if err := userRepo.Create(context.Background(), registered); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+
+	createUser := userCase.NewCreateUserCase(
+		userRepo,
+		user.NewUserService(userRepo),
+		relationshipCase.NewPartnerLinker(userRepo, inviteRepo, relationshipRepo),
+		relationshipCase.NewInviteCodeGuard(cache.NewMemoryAttemptLimiter(100), relationshipCase.DefaultInviteCodeGuardOptions(), discardLogger),
+		unitOfWork,
+		email.NewLogEmailService(discardLogger),
+		discardLogger,
+	)
+
+	router := httpInfra.NewRouter()
+	router.RegisterRoutes(routes.NewAuthRoutes(createUser, nil, nil, nil, nil, nil, nil))
+	return router.Handler()
+}
+
+func register(handler http.Handler, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/auth/register", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestRegisterWithBadInviteCodeDoesNotRevealRegisteredEmails(t *testing.T) {
+	handler := registerServer(t)
+
+	const signup = `{"email":%q,"username":%q,"first_name":"Test","last_name":"User","password":"Correct-Horse-9","invite_code":"ABCD-EFGH"}`
+	registered := register(handler, fmt.Sprintf(signup, "alice@example.com", "alice2"))
+	unknown := register(handler, fmt.Sprintf(signup, "bob@example.com", "bob"))
+
+	if registered.Code != unknown.Code {
+		t.Errorf("status for a registered email = %d, for an unknown one = %d", registered.Code, unknown.Code)
+	}
+	if registered.Body.String() != unknown.Body.String() {
+		t.Errorf("body for a registered email = %s, for an unknown one = %s", registered.Body, unknown.Body)
+	}
+	if unknown.Code != http.StatusUnprocessableEntity {
+		t.Errorf("status = %d, want %d", unknown.Code, http.StatusUnprocessableEntity)
+	}
+}
@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"expvar"
+
+	httpInfra "github.com/StefanPenchev05/Amora/backend/internal/infrastructure/http"
+	"github.com/go-chi/chi/v5"
+)
+
+// DebugRoutes exposes runtime variables such as the database pool statistics.
+// Only registered in debug mode, the values describe the infrastructure.
+type DebugRoutes struct{}
+
+func NewDebugRoutes() *DebugRoutes {
+	return &DebugRoutes{}
+}
+
+func (d *DebugRoutes) Path() string {
+	return "/debug"
+}
+
+func (d *DebugRoutes) RegisterRoutes(router httpInfra.Router) {
+	router.Route(d.Path(), func(r chi.Router) {
+		r.Handle("/vars", expvar.Handler())
+	})
+}
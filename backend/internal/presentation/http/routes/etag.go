@@ -0,0 +1,51 @@
+package routes
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+var (
+	errMissingIfMatch = errors.New("If-Match header is required")
+	errInvalidIfMatch = errors.New("If-Match header must be an ETag returned by the server")
+)
+
+// setETag exposes the version of the returned resource
+func setETag(w http.ResponseWriter, version int64) {
+	w.Header().Set("ETag", strconv.Quote(strconv.FormatInt(version, 10)))
+}
+
+// requireIfMatch returns the version the client edited. A nil version means "*",
+// the client accepts overwriting whatever version is stored.
+func requireIfMatch(r *http.Request) (*int64, error) {
+	header := strings.TrimSpace(r.Header.Get("If-Match"))
+	if header == "" {
+		return nil, errMissingIfMatch
+	}
+	if header == "*" {
+		return nil, nil
+	}
+
+	// Versions are exact, so a weak validator is compared like a strong one
+	tag, err := strconv.Unquote(strings.TrimPrefix(header, "W/"))
+	if err != nil {
+		return nil, errInvalidIfMatch
+	}
+	version, err := strconv.ParseInt(tag, 10, 64)
+	if err != nil {
+		return nil, errInvalidIfMatch
+	}
+
+	return &version, nil
+}
+
+// writeIfMatchError answers 428 when the precondition is missing and 400 when it is malformed
+func writeIfMatchError(w http.ResponseWriter, err error) {
+	if errors.Is(err, errMissingIfMatch) {
+		writeError(w, http.StatusPreconditionRequired, err.Error())
+		return
+	}
+	writeError(w, http.StatusBadRequest, err.Error())
+}
@@ -1,18 +1,22 @@
 package routes
 
 import (
-	"encoding/json"
 	"net/http"
 
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/health"
 	httpInfra "github.com/StefanPenchev05/Amora/backend/internal/infrastructure/http"
 	"github.com/go-chi/chi/v5"
 )
 
-// HealthRoutes - simple health check route group
-type HealthRoutes struct{}
+// HealthRoutes - liveness and readiness probes
+type HealthRoutes struct {
+	checks *health.Registry
+}
 
-func NewHealthRoutes() *HealthRoutes {
-	return &HealthRoutes{}
+func NewHealthRoutes(checks *health.Registry) *HealthRoutes {
+	return &HealthRoutes{
+		checks: checks,
+	}
 }
 
 func (h *HealthRoutes) Path() string {
@@ -26,28 +30,25 @@ func (h *HealthRoutes) RegisterRoutes(router httpInfra.Router) {
 	})
 }
 
+// healthCheck is the liveness probe, it answers as long as the process serves requests
 func (h *HealthRoutes) healthCheck(w http.ResponseWriter, r *http.Request) {
-	response := map[string]interface{}{
-		"status":  "ok",
-		"service": "amora-backend",
-		"version": "1.0.0",
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(response)
+	build := health.Build()
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"status":     "ok",
+		"service":    "amora-backend",
+		"version":    build.Version,
+		"revision":   build.Revision,
+		"go_version": build.GoVersion,
+	})
 }
 
+// readinessCheck runs the registered dependency checks, failing when a critical one fails
 func (h *HealthRoutes) readinessCheck(w http.ResponseWriter, r *http.Request) {
-	response := map[string]interface{}{
-		"status": "ready",
-		"checks": map[string]string{
-			"database": "ok",
-			"cache":    "ok",
-		},
-	}
+	report := h.checks.Run(r.Context())
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(response)
+	status := http.StatusOK
+	if !report.Ready() {
+		status = http.StatusServiceUnavailable
+	}
+	writeJSON(w, status, report)
 }
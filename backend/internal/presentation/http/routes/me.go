@@ -0,0 +1,190 @@
+package routes
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	securityLogDto "github.com/StefanPenchev05/Amora/backend/internal/application/dto/securitylog"
+	settingsDto "github.com/StefanPenchev05/Amora/backend/internal/application/dto/settings"
+	userDto "github.com/StefanPenchev05/Amora/backend/internal/application/dto/user"
+	securityLogCase "github.com/StefanPenchev05/Amora/backend/internal/application/usecases/securitylog"
+	sessionCase "github.com/StefanPenchev05/Amora/backend/internal/application/usecases/session"
+	settingsCase "github.com/StefanPenchev05/Amora/backend/internal/application/usecases/settings"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/session"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/settings"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/user"
+	httpInfra "github.com/StefanPenchev05/Amora/backend/internal/infrastructure/http"
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/http/middleware"
+	"github.com/go-chi/chi/v5"
+)
+
+// MeRoutes - routes scoped to the authenticated user
+type MeRoutes struct {
+	authMiddleware  httpInfra.Middleware
+	getSettings     *settingsCase.GetSettingsCase
+	updateSettings  *settingsCase.UpdateSettingsCase
+	listSecurityLog *securityLogCase.ListSecurityLogCase
+	listDevices     *sessionCase.ListDevicesCase
+	setDeviceTrust  *sessionCase.SetDeviceTrustCase
+}
+
+func NewMeRoutes(
+	authMiddleware httpInfra.Middleware,
+	getSettings *settingsCase.GetSettingsCase,
+	updateSettings *settingsCase.UpdateSettingsCase,
+	listSecurityLog *securityLogCase.ListSecurityLogCase,
+	listDevices *sessionCase.ListDevicesCase,
+	setDeviceTrust *sessionCase.SetDeviceTrustCase,
+) *MeRoutes {
+	return &MeRoutes{
+		authMiddleware:  authMiddleware,
+		getSettings:     getSettings,
+		updateSettings:  updateSettings,
+		listSecurityLog: listSecurityLog,
+		listDevices:     listDevices,
+		setDeviceTrust:  setDeviceTrust,
+	}
+}
+
+func (m *MeRoutes) Path() string {
+	return "/me"
+}
+
+func (m *MeRoutes) RegisterRoutes(router httpInfra.Router) {
+	router.Route(m.Path(), func(r chi.Router) {
+		r.Use(m.authMiddleware.Handle)
+
+		r.Get("/settings", m.getUserSettings)
+		r.Patch("/settings", m.patchUserSettings)
+		r.Get("/security-log", m.getSecurityLog)
+
+		r.Get("/devices", m.getDevices)
+		r.Post("/devices/{deviceID}/trust", m.trustDevice)
+		r.Delete("/devices/{deviceID}/trust", m.untrustDevice)
+	})
+}
+
+func (m *MeRoutes) getUserSettings(w http.ResponseWriter, r *http.Request) {
+	claims, _ := middleware.ClaimsFromContext(r.Context())
+
+	response, err := m.getSettings.Execute(r.Context(), claims.UserID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to load settings")
+		return
+	}
+
+	setETag(w, response.Revision)
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (m *MeRoutes) patchUserSettings(w http.ResponseWriter, r *http.Request) {
+	claims, _ := middleware.ClaimsFromContext(r.Context())
+
+	// Both partners' phones may edit at once, a stale copy must not overwrite a newer one
+	expectedRevision, err := requireIfMatch(r)
+	if err != nil {
+		writeIfMatchError(w, err)
+		return
+	}
+
+	var req settingsDto.UpdateSettingsRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	req.UserID = claims.UserID
+	req.ExpectedRevision = expectedRevision
+
+	response, err := m.updateSettings.Execute(r.Context(), req)
+	if errors.Is(err, settings.ErrInvalidSettings) {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	if errors.Is(err, settings.ErrConcurrentModification) {
+		writeError(w, http.StatusPreconditionFailed, "settings changed since they were loaded")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to update settings")
+		return
+	}
+
+	setETag(w, response.Revision)
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (m *MeRoutes) getSecurityLog(w http.ResponseWriter, r *http.Request) {
+	claims, _ := middleware.ClaimsFromContext(r.Context())
+
+	req := securityLogDto.ListSecurityLogRequest{
+		UserID: claims.UserID,
+		Before: r.URL.Query().Get("before"),
+	}
+	if limit := r.URL.Query().Get("limit"); limit != "" {
+		parsed, err := strconv.Atoi(limit)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "limit must be a number")
+			return
+		}
+		req.Limit = parsed
+	}
+
+	response, err := m.listSecurityLog.Execute(r.Context(), req)
+	if errors.Is(err, user.ErrValidation) {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to load security log")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (m *MeRoutes) getDevices(w http.ResponseWriter, r *http.Request) {
+	claims, _ := middleware.ClaimsFromContext(r.Context())
+
+	response, err := m.listDevices.Execute(r.Context(), claims.UserID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to load devices")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"devices": response})
+}
+
+func (m *MeRoutes) trustDevice(w http.ResponseWriter, r *http.Request) {
+	m.updateDeviceTrust(w, r, true)
+}
+
+func (m *MeRoutes) untrustDevice(w http.ResponseWriter, r *http.Request) {
+	m.updateDeviceTrust(w, r, false)
+}
+
+func (m *MeRoutes) updateDeviceTrust(w http.ResponseWriter, r *http.Request, trusted bool) {
+	claims, _ := middleware.ClaimsFromContext(r.Context())
+
+	req := userDto.DeviceTrustRequest{
+		UserID:    claims.UserID,
+		SessionID: claims.SessionID,
+		DeviceID:  chi.URLParam(r, "deviceID"),
+	}
+
+	response, err := m.setDeviceTrust.Execute(r.Context(), req, trusted)
+	if errors.Is(err, session.ErrDeviceNotFound) {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if errors.Is(err, session.ErrNotCurrentDevice) {
+		writeError(w, http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to update device")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
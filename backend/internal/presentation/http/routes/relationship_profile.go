@@ -0,0 +1,98 @@
+package routes
+
+import (
+	"errors"
+	"net/http"
+
+	dto "github.com/StefanPenchev05/Amora/backend/internal/application/dto/relationship"
+	relationshipCase "github.com/StefanPenchev05/Amora/backend/internal/application/usecases/relationship"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/relationship"
+	httpInfra "github.com/StefanPenchev05/Amora/backend/internal/infrastructure/http"
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/http/middleware"
+	"github.com/go-chi/chi/v5"
+)
+
+// RelationshipProfileRoutes - the profile of the current relationship of the authenticated user
+type RelationshipProfileRoutes struct {
+	authMiddleware httpInfra.Middleware
+	getCurrent     *relationshipCase.GetCurrentRelationshipCase
+	updateProfile  *relationshipCase.UpdateProfileCase
+}
+
+func NewRelationshipProfileRoutes(
+	authMiddleware httpInfra.Middleware,
+	getCurrent *relationshipCase.GetCurrentRelationshipCase,
+	updateProfile *relationshipCase.UpdateProfileCase,
+) *RelationshipProfileRoutes {
+	return &RelationshipProfileRoutes{
+		authMiddleware: authMiddleware,
+		getCurrent:     getCurrent,
+		updateProfile:  updateProfile,
+	}
+}
+
+func (rp *RelationshipProfileRoutes) Path() string {
+	return "/relationship"
+}
+
+func (rp *RelationshipProfileRoutes) RegisterRoutes(router httpInfra.Router) {
+	router.Route(rp.Path(), func(r chi.Router) {
+		r.Use(rp.authMiddleware.Handle)
+
+		r.Get("/", rp.getRelationship)
+		r.Patch("/", rp.patchRelationship)
+	})
+}
+
+func (rp *RelationshipProfileRoutes) getRelationship(w http.ResponseWriter, r *http.Request) {
+	claims, _ := middleware.ClaimsFromContext(r.Context())
+
+	response, err := rp.getCurrent.Execute(r.Context(), claims.UserID)
+	if errors.Is(err, relationship.ErrNotFound) {
+		writeError(w, http.StatusNotFound, "you are not in a relationship")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to load relationship")
+		return
+	}
+
+	setETag(w, response.Version)
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (rp *RelationshipProfileRoutes) patchRelationship(w http.ResponseWriter, r *http.Request) {
+	claims, _ := middleware.ClaimsFromContext(r.Context())
+
+	// Both partners may edit at once, a stale copy must not overwrite a newer one
+	expectedVersion, err := requireIfMatch(r)
+	if err != nil {
+		writeIfMatchError(w, err)
+		return
+	}
+
+	var req dto.UpdateProfileRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	req.UserID = claims.UserID
+	req.ExpectedVersion = expectedVersion
+
+	response, err := rp.updateProfile.Execute(r.Context(), req)
+	switch {
+	case err == nil:
+		setETag(w, response.Version)
+		writeJSON(w, http.StatusOK, response)
+	case errors.Is(err, relationship.ErrNotFound):
+		writeError(w, http.StatusNotFound, "you are not in a relationship")
+	case errors.Is(err, relationship.ErrInvalidProfile), errors.Is(err, relationship.ErrReservedSlug):
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+	case errors.Is(err, relationship.ErrSlugTaken), errors.Is(err, relationship.ErrReadOnly):
+		writeError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, relationship.ErrConcurrentModification):
+		writeError(w, http.StatusPreconditionFailed, "relationship changed since it was loaded")
+	default:
+		writeError(w, http.StatusInternalServerError, "failed to update relationship")
+	}
+}
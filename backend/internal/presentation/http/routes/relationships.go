@@ -0,0 +1,440 @@
+package routes
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+
+	dto "github.com/StefanPenchev05/Amora/backend/internal/application/dto/relationship"
+	"github.com/StefanPenchev05/Amora/backend/internal/application/interfaces"
+	relationshipCase "github.com/StefanPenchev05/Amora/backend/internal/application/usecases/relationship"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/relationship"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/user"
+	httpInfra "github.com/StefanPenchev05/Amora/backend/internal/infrastructure/http"
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/http/middleware"
+	"github.com/go-chi/chi/v5"
+)
+
+// RelationshipRoutes - invites between users and the relationships they start
+type RelationshipRoutes struct {
+	authMiddleware httpInfra.Middleware
+	codeFeature    func(http.Handler) http.Handler
+	sendInvite     *relationshipCase.SendInviteCase
+	listInvites    *relationshipCase.ListInvitesCase
+	acceptInvite   *relationshipCase.AcceptInviteCase
+	declineInvite  *relationshipCase.DeclineInviteCase
+	cancelInvite   *relationshipCase.CancelInviteCase
+	createCode     *relationshipCase.CreateInviteCodeCase
+	redeemCode     *relationshipCase.RedeemInviteCodeCase
+	renderCode     *relationshipCase.RenderInviteCodeCase
+
+	listRelationships *relationshipCase.ListRelationshipsCase
+	getRelationship   *relationshipCase.GetRelationshipCase
+	getBySlug         *relationshipCase.GetRelationshipBySlugCase
+	pause             *relationshipCase.PauseRelationshipCase
+	resume            *relationshipCase.ResumeRelationshipCase
+	end               *relationshipCase.EndRelationshipCase
+	reconnect         *relationshipCase.ReconnectRelationshipCase
+	withdrawRequest   *relationshipCase.WithdrawRequestCase
+}
+
+func NewRelationshipRoutes(
+	authMiddleware httpInfra.Middleware,
+	codeFeature func(http.Handler) http.Handler,
+	sendInvite *relationshipCase.SendInviteCase,
+	listInvites *relationshipCase.ListInvitesCase,
+	acceptInvite *relationshipCase.AcceptInviteCase,
+	declineInvite *relationshipCase.DeclineInviteCase,
+	cancelInvite *relationshipCase.CancelInviteCase,
+	createCode *relationshipCase.CreateInviteCodeCase,
+	redeemCode *relationshipCase.RedeemInviteCodeCase,
+	renderCode *relationshipCase.RenderInviteCodeCase,
+	listRelationships *relationshipCase.ListRelationshipsCase,
+	getRelationship *relationshipCase.GetRelationshipCase,
+	getBySlug *relationshipCase.GetRelationshipBySlugCase,
+	pause *relationshipCase.PauseRelationshipCase,
+	resume *relationshipCase.ResumeRelationshipCase,
+	end *relationshipCase.EndRelationshipCase,
+	reconnect *relationshipCase.ReconnectRelationshipCase,
+	withdrawRequest *relationshipCase.WithdrawRequestCase,
+) *RelationshipRoutes {
+	return &RelationshipRoutes{
+		authMiddleware: authMiddleware,
+		codeFeature:    codeFeature,
+		sendInvite:     sendInvite,
+		listInvites:    listInvites,
+		acceptInvite:   acceptInvite,
+		declineInvite:  declineInvite,
+		cancelInvite:   cancelInvite,
+		createCode:     createCode,
+		redeemCode:     redeemCode,
+		renderCode:     renderCode,
+
+		listRelationships: listRelationships,
+		getRelationship:   getRelationship,
+		getBySlug:         getBySlug,
+		pause:             pause,
+		resume:            resume,
+		end:               end,
+		reconnect:         reconnect,
+		withdrawRequest:   withdrawRequest,
+	}
+}
+
+func (rr *RelationshipRoutes) Path() string {
+	return "/relationships"
+}
+
+func (rr *RelationshipRoutes) RegisterRoutes(router httpInfra.Router) {
+	router.Route(rr.Path(), func(r chi.Router) {
+		r.Use(rr.authMiddleware.Handle)
+
+		r.Get("/invites", rr.getInvites)
+		r.Post("/invites", rr.postInvite)
+		r.Post("/invites/{inviteID}/accept", rr.postAcceptInvite)
+		r.Post("/invites/{inviteID}/decline", rr.postDeclineInvite)
+		r.Delete("/invites/{inviteID}", rr.deleteInvite)
+
+		// Codes are shared with partners who may not have an account yet, they are
+		// rolled out behind the invite_codes flag
+		r.Group(func(r chi.Router) {
+			r.Use(rr.codeFeature)
+
+			r.Post("/invite-codes", rr.postInviteCode)
+			r.Post("/invite-codes/redeem", rr.postRedeemInviteCode)
+			r.Post("/invite-codes/qr", rr.postInviteCodeQR)
+		})
+
+		// Ending and reconnecting wait for a partner to confirm, repeating the request confirms it
+		r.Get("/", rr.getRelationships)
+		r.Get("/{relationshipID}", rr.getRelationshipByID)
+		r.Get("/by-slug/{slug}", rr.getRelationshipBySlug)
+		r.Post("/{relationshipID}/pause", rr.postPause)
+		r.Post("/{relationshipID}/resume", rr.postResume)
+		r.Post("/{relationshipID}/end", rr.postEnd)
+		r.Delete("/{relationshipID}/end", rr.deleteEndRequest)
+		r.Post("/{relationshipID}/reconnect", rr.postReconnect)
+		r.Delete("/{relationshipID}/reconnect", rr.deleteReconnectRequest)
+	})
+}
+
+func (rr *RelationshipRoutes) getInvites(w http.ResponseWriter, r *http.Request) {
+	claims, _ := middleware.ClaimsFromContext(r.Context())
+
+	response, err := rr.listInvites.Execute(r.Context(), claims.UserID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to load invites")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (rr *RelationshipRoutes) postInvite(w http.ResponseWriter, r *http.Request) {
+	claims, _ := middleware.ClaimsFromContext(r.Context())
+
+	var req dto.SendInviteRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	req.InviterID = claims.UserID
+
+	response, err := rr.sendInvite.Execute(r.Context(), req)
+	switch {
+	case err == nil && response.Invite == nil:
+		// Invites by email are accepted whether or not the account exists
+		writeJSON(w, http.StatusAccepted, response)
+	case err == nil:
+		writeJSON(w, http.StatusCreated, response)
+	case errors.Is(err, user.ErrNotFound):
+		writeError(w, http.StatusNotFound, user.ErrNotFound.Error())
+	case errors.Is(err, relationship.ErrInvalidInvite), errors.Is(err, relationship.ErrSelfInvite):
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+	case errors.Is(err, relationship.ErrInvitePending), errors.Is(err, user.ErrInRelationship):
+		writeError(w, http.StatusConflict, err.Error())
+	default:
+		writeError(w, http.StatusInternalServerError, "failed to send invite")
+	}
+}
+
+func (rr *RelationshipRoutes) postAcceptInvite(w http.ResponseWriter, r *http.Request) {
+	response, err := rr.acceptInvite.Execute(r.Context(), inviteAction(r))
+	switch {
+	case err == nil:
+		writeJSON(w, http.StatusCreated, response)
+	case errors.Is(err, user.ErrConcurrentModification):
+		writeError(w, http.StatusConflict, "a partner changed while accepting, try again")
+	case errors.Is(err, user.ErrInRelationship):
+		writeError(w, http.StatusConflict, err.Error())
+	default:
+		writeInviteError(w, err, "failed to accept invite")
+	}
+}
+
+func (rr *RelationshipRoutes) postDeclineInvite(w http.ResponseWriter, r *http.Request) {
+	response, err := rr.declineInvite.Execute(r.Context(), inviteAction(r))
+	if err != nil {
+		writeInviteError(w, err, "failed to decline invite")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (rr *RelationshipRoutes) deleteInvite(w http.ResponseWriter, r *http.Request) {
+	response, err := rr.cancelInvite.Execute(r.Context(), inviteAction(r))
+	if err != nil {
+		writeInviteError(w, err, "failed to cancel invite")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (rr *RelationshipRoutes) postInviteCode(w http.ResponseWriter, r *http.Request) {
+	claims, _ := middleware.ClaimsFromContext(r.Context())
+
+	var req dto.CreateInviteCodeRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	req.InviterID = claims.UserID
+
+	response, err := rr.createCode.Execute(r.Context(), req)
+	switch {
+	case err == nil:
+		// The code is shown once, keep it out of caches
+		w.Header().Set("Cache-Control", "no-store")
+		writeJSON(w, http.StatusCreated, response)
+	case errors.Is(err, relationship.ErrInvalidInvite):
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+	case errors.Is(err, user.ErrInRelationship):
+		writeError(w, http.StatusConflict, err.Error())
+	default:
+		writeError(w, http.StatusInternalServerError, "failed to create invite code")
+	}
+}
+
+func (rr *RelationshipRoutes) postRedeemInviteCode(w http.ResponseWriter, r *http.Request) {
+	claims, _ := middleware.ClaimsFromContext(r.Context())
+
+	var req dto.RedeemInviteCodeRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	req.UserID = claims.UserID
+
+	response, err := rr.redeemCode.Execute(r.Context(), req)
+	switch {
+	case err == nil:
+		writeJSON(w, http.StatusCreated, response)
+	case errors.Is(err, relationship.ErrInvalidCode):
+		writeError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, interfaces.ErrTooManyAttempts):
+		writeError(w, http.StatusTooManyRequests, err.Error())
+	case errors.Is(err, relationship.ErrSelfInvite):
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+	case errors.Is(err, user.ErrConcurrentModification):
+		writeError(w, http.StatusConflict, "a partner changed while redeeming, try again")
+	case errors.Is(err, user.ErrInRelationship):
+		writeError(w, http.StatusConflict, err.Error())
+	default:
+		writeError(w, http.StatusInternalServerError, "failed to redeem invite code")
+	}
+}
+
+func (rr *RelationshipRoutes) postInviteCodeQR(w http.ResponseWriter, r *http.Request) {
+	claims, _ := middleware.ClaimsFromContext(r.Context())
+
+	var req dto.InviteCodeQRRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	req.UserID = claims.UserID
+
+	image, err := rr.renderCode.Execute(r.Context(), req)
+	switch {
+	case err == nil:
+		w.Header().Set("Content-Type", interfaces.QRCodeFormat(req.Format).ContentType())
+		w.Header().Set("Cache-Control", "private, no-store")
+		w.WriteHeader(http.StatusOK)
+		w.Write(image)
+	case errors.Is(err, relationship.ErrInvalidCode):
+		writeError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, interfaces.ErrUnsupportedQRFormat):
+		writeError(w, http.StatusUnprocessableEntity, "QR codes are available as png or svg")
+	default:
+		writeError(w, http.StatusInternalServerError, "failed to render QR code")
+	}
+}
+
+func (rr *RelationshipRoutes) getRelationships(w http.ResponseWriter, r *http.Request) {
+	claims, _ := middleware.ClaimsFromContext(r.Context())
+
+	response, err := rr.listRelationships.Execute(r.Context(), claims.UserID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to load relationships")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (rr *RelationshipRoutes) getRelationshipByID(w http.ResponseWriter, r *http.Request) {
+	response, err := rr.getRelationship.Execute(r.Context(), relationshipAction(r))
+	if err != nil {
+		writeRelationshipError(w, err, "failed to load relationship")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (rr *RelationshipRoutes) getRelationshipBySlug(w http.ResponseWriter, r *http.Request) {
+	claims, _ := middleware.ClaimsFromContext(r.Context())
+
+	req := dto.RelationshipBySlugRequest{
+		UserID: claims.UserID,
+		Slug:   chi.URLParam(r, "slug"),
+	}
+
+	response, err := rr.getBySlug.Execute(r.Context(), req)
+	if err != nil {
+		writeRelationshipError(w, err, "failed to load relationship")
+		return
+	}
+
+	// Replaced slugs keep working, and point links at the current address. The
+	// redirect is temporary, the slug can change again and caches must not keep it.
+	switch {
+	case response.Slug == "":
+		http.Redirect(w, r, rr.Path()+"/"+url.PathEscape(response.ID), http.StatusFound)
+	case response.Slug != req.Slug:
+		http.Redirect(w, r, rr.Path()+"/by-slug/"+url.PathEscape(response.Slug), http.StatusFound)
+	default:
+		writeJSON(w, http.StatusOK, response)
+	}
+}
+
+func (rr *RelationshipRoutes) postPause(w http.ResponseWriter, r *http.Request) {
+	response, err := rr.pause.Execute(r.Context(), relationshipAction(r))
+	if err != nil {
+		writeRelationshipError(w, err, "failed to pause relationship")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (rr *RelationshipRoutes) postResume(w http.ResponseWriter, r *http.Request) {
+	response, err := rr.resume.Execute(r.Context(), relationshipAction(r))
+	if err != nil {
+		writeRelationshipError(w, err, "failed to resume relationship")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (rr *RelationshipRoutes) postEnd(w http.ResponseWriter, r *http.Request) {
+	response, err := rr.end.Execute(r.Context(), relationshipAction(r))
+	if err != nil {
+		writeRelationshipError(w, err, "failed to end relationship")
+		return
+	}
+
+	writeRequestOutcome(w, response)
+}
+
+func (rr *RelationshipRoutes) deleteEndRequest(w http.ResponseWriter, r *http.Request) {
+	response, err := rr.withdrawRequest.Execute(r.Context(), relationshipAction(r), relationship.RequestEnd)
+	if err != nil {
+		writeRelationshipError(w, err, "failed to withdraw the request to end")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (rr *RelationshipRoutes) postReconnect(w http.ResponseWriter, r *http.Request) {
+	response, err := rr.reconnect.Execute(r.Context(), relationshipAction(r))
+	if err != nil {
+		writeRelationshipError(w, err, "failed to reconnect relationship")
+		return
+	}
+
+	writeRequestOutcome(w, response)
+}
+
+func (rr *RelationshipRoutes) deleteReconnectRequest(w http.ResponseWriter, r *http.Request) {
+	response, err := rr.withdrawRequest.Execute(r.Context(), relationshipAction(r), relationship.RequestReconnect)
+	if err != nil {
+		writeRelationshipError(w, err, "failed to withdraw the request to reconnect")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// writeRequestOutcome answers 202 while the request waits for the partner, and 200 once it is confirmed
+func writeRequestOutcome(w http.ResponseWriter, response *dto.RelationshipResponse) {
+	if response.Pending != nil {
+		writeJSON(w, http.StatusAccepted, response)
+		return
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// relationshipAction reads the relationship of the URL and the acting user
+func relationshipAction(r *http.Request) dto.RelationshipActionRequest {
+	claims, _ := middleware.ClaimsFromContext(r.Context())
+
+	return dto.RelationshipActionRequest{
+		UserID:         claims.UserID,
+		RelationshipID: chi.URLParam(r, "relationshipID"),
+	}
+}
+
+// writeRelationshipError maps the errors about a relationship, anything else is reported with fallback
+func writeRelationshipError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, relationship.ErrNotFound), errors.Is(err, relationship.ErrNoPendingRequest):
+		writeError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, relationship.ErrConcurrentModification), errors.Is(err, user.ErrConcurrentModification):
+		writeError(w, http.StatusConflict, "the relationship changed at the same time, try again")
+	case errors.Is(err, relationship.ErrInvalidTransition),
+		errors.Is(err, relationship.ErrAwaitingConfirmation),
+		errors.Is(err, user.ErrInRelationship):
+		writeError(w, http.StatusConflict, err.Error())
+	default:
+		writeError(w, http.StatusInternalServerError, fallback)
+	}
+}
+
+// inviteAction reads the invite of the URL and the acting user
+func inviteAction(r *http.Request) dto.InviteActionRequest {
+	claims, _ := middleware.ClaimsFromContext(r.Context())
+
+	return dto.InviteActionRequest{
+		UserID:   claims.UserID,
+		InviteID: chi.URLParam(r, "inviteID"),
+	}
+}
+
+// writeInviteError maps the errors about an invite, anything else is reported with fallback
+func writeInviteError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, relationship.ErrInviteNotFound):
+		writeError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, relationship.ErrInviteExpired):
+		writeError(w, http.StatusGone, err.Error())
+	case errors.Is(err, relationship.ErrInviteNotPending):
+		writeError(w, http.StatusConflict, err.Error())
+	default:
+		writeError(w, http.StatusInternalServerError, fallback)
+	}
+}
@@ -0,0 +1,91 @@
+package routes_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/application/features"
+	"github.com/StefanPenchev05/Amora/backend/internal/application/interfaces"
+	relationshipCase "github.com/StefanPenchev05/Amora/backend/internal/application/usecases/relationship"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/feature"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/user"
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/cache"
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/featureflags"
+	httpInfra "github.com/StefanPenchev05/Amora/backend/internal/infrastructure/http"
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/http/middleware"
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/persistence/memory"
+	"github.com/StefanPenchev05/Amora/backend/internal/presentation/http/routes"
+)
+
+// signedIn stands in for AuthMiddleware, every request belongs to one user
+type signedIn struct{ userID string }
+
+func (s signedIn) Handle(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims := &interfaces.TokenClaims{UserID: s.userID}
+		next.ServeHTTP(w, r.WithContext(middleware.WithClaims(r.Context(), claims)))
+	})
+}
+
+func TestInviteCodeRoutesFollowTheInviteCodesFlag(t *testing.T) {
+	outbox := memory.NewOutboxStore()
+	userRepo := memory.NewUserRepository(outbox)
+	inviteRepo := memory.NewInviteRepository(outbox)
+
+	inviter, err := user.NewUser("alice@example.com", "alice", "Alice", "Example", "Correct-Horse-9")
+	if err != nil {
+		t.Fatalf("new user: %v", err)
+	}
+	if err := userRepo.Create(context.Background(), inviter); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+
+	// Saved flags go through the cache the feature service reads
+	readThrough := cache.NewReadThrough(cache.NewLRUCache(100), time.Hour, discardLogger)
+	storedFlags := cache.NewFlagStore(memory.NewFlagRepository(), readThrough)
+	defaults, err := featureflags.NewFileFlagStore("")
+	if err != nil {
+		t.Fatalf("default flags: %v", err)
+	}
+	featureService := features.NewService(
+		featureflags.NewCompositeFlagStore(defaults, storedFlags),
+		featureflags.NewFixedPlanStore(feature.PlanFree),
+		"test",
+		discardLogger,
+	)
+
+	relationshipRoutes := routes.NewRelationshipRoutes(
+		signedIn{userID: inviter.ID},
+		middleware.RequireFeature(featureService, userRepo, "invite_codes"),
+		nil, nil, nil, nil, nil,
+		relationshipCase.NewCreateInviteCodeCase(userRepo, inviteRepo, "https://amora.test", discardLogger),
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+	)
+	router := httpInfra.NewRouter()
+	router.RegisterRoutes(relationshipRoutes)
+	handler := router.Handler()
+
+	createCode := func() int {
+		req := httptest.NewRequest(http.MethodPost, "/relationships/invite-codes", strings.NewReader(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		return recorder.Code
+	}
+
+	if status := createCode(); status != http.StatusCreated {
+		t.Fatalf("status with the default flag = %d, want %d", status, http.StatusCreated)
+	}
+
+	off := feature.Flag{Key: "invite_codes", Enabled: false}
+	if err := storedFlags.SaveFlag(context.Background(), &off, nil); err != nil {
+		t.Fatalf("save flag: %v", err)
+	}
+	if status := createCode(); status != http.StatusNotFound {
+		t.Errorf("status with the flag off = %d, want %d", status, http.StatusNotFound)
+	}
+}
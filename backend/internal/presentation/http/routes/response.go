@@ -0,0 +1,36 @@
+package routes
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+)
+
+// writeJSON encodes the payload with the given status code
+func writeJSON(w http.ResponseWriter, status int, payload interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(payload)
+}
+
+// writeError responds with a JSON error message
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
+
+// decodeJSON decodes the request body, rejecting unknown fields
+func decodeJSON(r *http.Request, target interface{}) error {
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(target)
+}
+
+// clientIP returns the request IP without the port, ClientIPMiddleware has already
+// replaced RemoteAddr with the forwarded address of requests from a trusted proxy
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,34 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/StefanPenchev05/Amora/backend/internal/application/interfaces"
+	httpInfra "github.com/StefanPenchev05/Amora/backend/internal/infrastructure/http"
+	"github.com/go-chi/chi/v5"
+)
+
+// WellKnownRoutes publishes discovery documents for other services
+type WellKnownRoutes struct {
+	jwtService interfaces.JWTService
+}
+
+func NewWellKnownRoutes(jwtService interfaces.JWTService) *WellKnownRoutes {
+	return &WellKnownRoutes{jwtService: jwtService}
+}
+
+func (wk *WellKnownRoutes) Path() string {
+	return "/.well-known"
+}
+
+func (wk *WellKnownRoutes) RegisterRoutes(router httpInfra.Router) {
+	router.Route(wk.Path(), func(r chi.Router) {
+		r.Get("/jwks.json", wk.jwks)
+	})
+}
+
+func (wk *WellKnownRoutes) jwks(w http.ResponseWriter, r *http.Request) {
+	// Verifiers refetch on an unknown kid, so a short cache keeps rotation quick
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	writeJSON(w, http.StatusOK, wk.jwtService.PublicJWKS())
+}
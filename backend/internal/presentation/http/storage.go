@@ -0,0 +1,149 @@
+package http
+
+import (
+	"context"
+	"expvar"
+	"fmt"
+	"log/slog"
+	"time"
+
+	appEvents "github.com/StefanPenchev05/Amora/backend/internal/application/events"
+	"github.com/StefanPenchev05/Amora/backend/internal/application/interfaces"
+	"github.com/StefanPenchev05/Amora/backend/internal/application/outbox"
+	"github.com/StefanPenchev05/Amora/backend/internal/config"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/feature"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/relationship"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/securitylog"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/session"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/settings"
+	"github.com/StefanPenchev05/Amora/backend/internal/domain/user"
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/featureflags"
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/health"
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/persistence/memory"
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/persistence/mysql"
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/persistence/mysql/migrate"
+	"github.com/StefanPenchev05/Amora/backend/internal/infrastructure/persistence/mysql/migrations"
+)
+
+// migrationTimeout bounds applying migrations on boot
+const migrationTimeout = 5 * time.Minute
+
+// repositories groups the adapters of the configured storage backend
+type repositories struct {
+	user         user.Repository
+	settings     settings.Repository
+	session      session.Repository
+	device       session.DeviceRepository
+	mfaChallenge session.MFAChallengeRepository
+	securityLog  securitylog.Repository
+	relationship relationship.Repository
+	invite       relationship.InviteRepository
+	outbox       outbox.Store
+	deliveries   appEvents.DeliveryLog
+	unitOfWork   interfaces.UnitOfWork
+
+	// flags are stored flags on top of the defaults and flags file
+	flags feature.FlagStore
+	plans feature.PlanRepository
+}
+
+func newRepositories(cfg *config.Config, checks *health.Registry, logger *slog.Logger) (*repositories, error) {
+	if cfg.UsesMemoryStorage() {
+		logger.Warn("Using in-memory storage, data is lost on restart")
+		return newMemoryRepositories(), nil
+	}
+
+	return newMySQLRepositories(cfg.Database, checks, logger)
+}
+
+func newMemoryRepositories() *repositories {
+	outboxStore := memory.NewOutboxStore()
+	repos := &repositories{
+		user:         memory.NewUserRepository(outboxStore),
+		settings:     memory.NewSettingsRepository(),
+		session:      memory.NewSessionRepository(outboxStore),
+		device:       memory.NewDeviceRepository(),
+		mfaChallenge: memory.NewMFAChallengeRepository(),
+		securityLog:  memory.NewSecurityLogRepository(),
+		relationship: memory.NewRelationshipRepository(outboxStore),
+		invite:       memory.NewInviteRepository(outboxStore),
+		outbox:       outboxStore,
+		deliveries:   memory.NewDeliveryLog(),
+		flags:        memory.NewFlagRepository(),
+		plans:        featureflags.NewFixedPlanStore(feature.PlanFree),
+	}
+	repos.unitOfWork = memory.NewUnitOfWork(
+		repos.user, repos.settings, repos.session, repos.device, repos.securityLog,
+		repos.relationship, repos.invite, outboxStore,
+	)
+
+	return repos
+}
+
+func newMySQLRepositories(cfg config.DBConfig, checks *health.Registry, logger *slog.Logger) (*repositories, error) {
+	if cfg.MigrateOnBoot {
+		if err := applyMigrations(cfg, logger); err != nil {
+			return nil, err
+		}
+	}
+
+	connection, err := mysql.NewConnection(cfg)
+	if err != nil {
+		return nil, err
+	}
+	db := connection.DB
+
+	checks.Register(health.Check{
+		Name:     "database",
+		Critical: true,
+		Run:      connection.Ping,
+	})
+	if connection.HasReplicas() {
+		// Reads are spread over the replicas with no failover, one down fails its share of them
+		checks.Register(health.Check{Name: "database_replicas", Critical: true, Run: connection.PingReplicas})
+	}
+
+	// Pool statistics for metrics scrapers, served at /debug/vars in debug mode
+	expvar.Publish("db_pools", expvar.Func(func() any { return connection.Stats() }))
+
+	return &repositories{
+		user:         mysql.NewUserRepository(db),
+		settings:     mysql.NewSettingsRepository(db),
+		session:      mysql.NewSessionRepository(db),
+		device:       mysql.NewDeviceRepository(db),
+		mfaChallenge: mysql.NewMFAChallengeRepository(db),
+		securityLog:  mysql.NewSecurityLogRepository(db),
+		relationship: mysql.NewRelationshipRepository(db),
+		invite:       mysql.NewInviteRepository(db),
+		outbox:       mysql.NewOutboxStore(db),
+		deliveries:   mysql.NewDeliveryLog(db),
+		unitOfWork:   mysql.NewUnitOfWork(db),
+		flags:        mysql.NewFlagRepository(db),
+		plans:        mysql.NewPlanRepository(db),
+	}, nil
+}
+
+// applyMigrations brings the schema up to date before any repository uses it
+func applyMigrations(cfg config.DBConfig, logger *slog.Logger) error {
+	db, err := migrate.Open(cfg.DSN)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	migrator, err := migrate.NewMigrator(db, migrations.FS, logger)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), migrationTimeout)
+	defer cancel()
+
+	count, err := migrator.Up(ctx, 0)
+	if err != nil {
+		return fmt.Errorf("failed to apply migrations: %w", err)
+	}
+
+	logger.Info("Database migrations applied", "count", count)
+	return nil
+}